@@ -352,6 +352,8 @@ func writeIncomingQueriesToCSV(queries []*incomingQueryWithEndpoint, outputPath
 		"data_source_kind",
 		"rows_read",
 		"bytes_read",
+		"memory_bytes",
+		"peak_memory_bytes",
 		"state",
 		"created_at",
 		"finished_at",
@@ -393,6 +395,8 @@ func writeIncomingQueriesToCSV(queries []*incomingQueryWithEndpoint, outputPath
 			q.DataSourceKind,
 			strconv.FormatInt(q.RowsRead, 10),
 			strconv.FormatInt(q.BytesRead, 10),
+			strconv.FormatInt(q.MemoryBytes, 10),
+			strconv.FormatInt(q.PeakMemoryBytes, 10),
 			q.State.String(), // Human-readable state
 			createdAt,
 			finishedAt,
@@ -67,6 +67,8 @@ func listIncomingQueries(cmd *cobra.Command, _ []string, state observation.Query
 			fmt.Printf("  Data Source: %s\n", query.DataSourceKind)
 			fmt.Printf("  Rows Read: %d\n", query.RowsRead)
 			fmt.Printf("  Bytes Read: %d\n", query.BytesRead)
+			fmt.Printf("  Memory Bytes: %d\n", query.MemoryBytes)
+			fmt.Printf("  Peak Memory Bytes: %d\n", query.PeakMemoryBytes)
 			fmt.Printf("  State: %s\n", query.State.String())
 			fmt.Printf("  Created At: %s\n", query.CreatedAt.AsTime().Format(time.RFC3339))
 			fmt.Printf("  Finished At: %s\n", finishedAt)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"strings"
 
 	"google.golang.org/protobuf/encoding/prototext"
 
@@ -44,6 +45,10 @@ func fillServerConfigDefaults(c *config.TServerConfig) {
 		}
 	}
 
+	if c.Paging.Adaptive != nil && c.Paging.Adaptive.SendLatencyThreshold == "" {
+		c.Paging.Adaptive.SendLatencyThreshold = "100ms"
+	}
+
 	if c.Logger == nil {
 		c.Logger = &config.TLoggerConfig{
 			LogLevel:              config.ELogLevel_INFO,
@@ -57,6 +62,14 @@ func fillServerConfigDefaults(c *config.TServerConfig) {
 		}
 	}
 
+	if c.ReadCache != nil {
+		fillReadCacheConfigDefaults(c.ReadCache)
+	}
+
+	if c.SchemaCache != nil {
+		fillSchemaCacheConfigDefaults(c.SchemaCache)
+	}
+
 	if c.Datasources == nil {
 		c.Datasources = &config.TDatasourcesConfig{}
 	}
@@ -94,6 +107,10 @@ func fillServerConfigDefaults(c *config.TServerConfig) {
 		c.Datasources.Greenplum.Pushdown = makeDefaultPushdownConfig()
 	}
 
+	if c.Datasources.Greenplum.ReadMode == config.TPostgreSQLConfig_READ_MODE_UNSPECIFIED {
+		c.Datasources.Greenplum.ReadMode = config.TPostgreSQLConfig_READ_MODE_QUERY
+	}
+
 	// MS SQL Server
 
 	if c.Datasources.MsSqlServer == nil {
@@ -182,6 +199,7 @@ func fillServerConfigDefaults(c *config.TServerConfig) {
 			PingConnectionTimeout: "5s",
 			ScrollTimeout:         "10s",
 			BatchSize:             100,
+			SchemaSamplingSize:    100,
 		}
 	}
 
@@ -224,6 +242,10 @@ func fillServerConfigDefaults(c *config.TServerConfig) {
 		}
 	}
 
+	if c.Datasources.Postgresql.ReadMode == config.TPostgreSQLConfig_READ_MODE_UNSPECIFIED {
+		c.Datasources.Postgresql.ReadMode = config.TPostgreSQLConfig_READ_MODE_QUERY
+	}
+
 	// YDB
 
 	if c.Datasources.Ydb == nil {
@@ -304,6 +326,46 @@ func fillYdbConfigDefaults(c *config.TYdbConfig) {
 	}
 }
 
+func fillReadCacheConfigDefaults(c *config.TReadCacheConfig) {
+	if c.Ttl == "" {
+		c.Ttl = "1m"
+	}
+
+	if c.MaxResultSizeBytes == 0 {
+		c.MaxResultSizeBytes = 16 * 1024 * 1024
+	}
+
+	if c.Ristretto == nil {
+		c.Ristretto = &config.TReadCacheConfig_TRistretto{}
+	}
+
+	if c.Ristretto.MaxKeys == 0 {
+		c.Ristretto.MaxKeys = 10000
+	}
+
+	if c.Ristretto.MaxSizeBytes == 0 {
+		c.Ristretto.MaxSizeBytes = 256 * 1024 * 1024
+	}
+}
+
+func fillSchemaCacheConfigDefaults(c *config.TSchemaCacheConfig) {
+	if c.Ttl == "" {
+		c.Ttl = "10m"
+	}
+
+	if c.Ristretto == nil {
+		c.Ristretto = &config.TSchemaCacheConfig_TRistretto{}
+	}
+
+	if c.Ristretto.MaxKeys == 0 {
+		c.Ristretto.MaxKeys = 10000
+	}
+
+	if c.Ristretto.MaxSizeBytes == 0 {
+		c.Ristretto.MaxSizeBytes = 64 * 1024 * 1024
+	}
+}
+
 func validateServerConfig(c *config.TServerConfig) error {
 	if err := validateConnectorServerConfig(c.ConnectorServer); err != nil {
 		return fmt.Errorf("validate `connector_server`: %w", err)
@@ -313,10 +375,34 @@ func validateServerConfig(c *config.TServerConfig) error {
 		return fmt.Errorf("validate `pprof_server`: %w", err)
 	}
 
+	if err := validateFlightServerConfig(c.FlightServer); err != nil {
+		return fmt.Errorf("validate `flight_server`: %w", err)
+	}
+
+	if err := validateAdminServerConfig(c.AdminServer); err != nil {
+		return fmt.Errorf("validate `admin_server`: %w", err)
+	}
+
 	if err := validatePagingConfig(c.Paging); err != nil {
 		return fmt.Errorf("validate `paging`: %w", err)
 	}
 
+	if err := validateMemoryConfig(c.Memory); err != nil {
+		return fmt.Errorf("validate `memory`: %w", err)
+	}
+
+	if err := validateReadCacheConfig(c.ReadCache); err != nil {
+		return fmt.Errorf("validate `read_cache`: %w", err)
+	}
+
+	if err := validateSchemaCacheConfig(c.SchemaCache); err != nil {
+		return fmt.Errorf("validate `schema_cache`: %w", err)
+	}
+
+	if err := validateTypeMappingConfig(c.TypeMapping); err != nil {
+		return fmt.Errorf("validate `type_mapping`: %w", err)
+	}
+
 	if err := validateConversionConfig(c.Conversion); err != nil {
 		return fmt.Errorf("validate `conversion`: %w", err)
 	}
@@ -412,7 +498,43 @@ func validatePprofServerConfig(c *config.TPprofServerConfig) error {
 	return nil
 }
 
-const maxInterconnectMessageSize = 50 * 1024 * 1024
+func validateFlightServerConfig(c *config.TFlightServerConfig) error {
+	if c == nil {
+		// It's OK to disable Arrow Flight
+		return nil
+	}
+
+	if err := validateEndpoint(c.Endpoint); err != nil {
+		return fmt.Errorf("validate `endpoint`: %w", err)
+	}
+
+	if err := validateServerTLSConfig(c.Tls); err != nil {
+		return fmt.Errorf("validate `tls`: %w", err)
+	}
+
+	return nil
+}
+
+func validateAdminServerConfig(c *config.TAdminServerConfig) error {
+	if c == nil {
+		// It's OK to disable administrative API
+		return nil
+	}
+
+	if err := validateEndpoint(c.Endpoint); err != nil {
+		return fmt.Errorf("validate `endpoint`: %w", err)
+	}
+
+	if err := validateServerTLSConfig(c.Tls); err != nil {
+		return fmt.Errorf("validate `tls`: %w", err)
+	}
+
+	return nil
+}
+
+// MaxInterconnectMessageSize is the largest message that can be passed through the interconnect system used by YDB engine,
+// so it limits the size of every page sent to the engine.
+const MaxInterconnectMessageSize = 50 * 1024 * 1024
 
 func validatePagingConfig(c *config.TPagingConfig) error {
 	if c == nil {
@@ -424,10 +546,127 @@ func validatePagingConfig(c *config.TPagingConfig) error {
 		return errors.New("you must set either `bytes_per_page` or `rows_per_page` or both of them")
 	}
 
-	if c.BytesPerPage > maxInterconnectMessageSize {
+	if c.BytesPerPage > MaxInterconnectMessageSize {
 		return errors.New("`bytes_per_page` limit exceeds the limits of interconnect system used by YDB engine")
 	}
 
+	if err := validateAdaptivePagingConfig(c.Adaptive, c); err != nil {
+		return fmt.Errorf("validate `adaptive`: %w", err)
+	}
+
+	return nil
+}
+
+func validateAdaptivePagingConfig(c *config.TAdaptivePagingConfig, paging *config.TPagingConfig) error {
+	if c == nil {
+		// It's OK to have static page size limits
+		return nil
+	}
+
+	if paging.RowsPerPage != 0 {
+		if c.MinRowsPerPage == 0 || c.MinRowsPerPage > paging.RowsPerPage {
+			return fmt.Errorf("invalid value of field `min_rows_per_page`: %v", c.MinRowsPerPage)
+		}
+
+		if c.MaxRowsPerPage < paging.RowsPerPage {
+			return fmt.Errorf("invalid value of field `max_rows_per_page`: %v", c.MaxRowsPerPage)
+		}
+	}
+
+	if paging.BytesPerPage != 0 {
+		if c.MinBytesPerPage == 0 || c.MinBytesPerPage > paging.BytesPerPage {
+			return fmt.Errorf("invalid value of field `min_bytes_per_page`: %v", c.MinBytesPerPage)
+		}
+
+		if c.MaxBytesPerPage < paging.BytesPerPage || c.MaxBytesPerPage > MaxInterconnectMessageSize {
+			return fmt.Errorf("invalid value of field `max_bytes_per_page`: %v", c.MaxBytesPerPage)
+		}
+	}
+
+	if _, err := common.DurationFromString(c.SendLatencyThreshold); err != nil {
+		return fmt.Errorf("validate `send_latency_threshold`: %v", err)
+	}
+
+	return nil
+}
+
+func validateMemoryConfig(c *config.TMemoryConfig) error {
+	// memory is not limited if the section is missing
+	if c == nil {
+		return nil
+	}
+
+	if c.RequestQuotaRatio < 0 || c.RequestQuotaRatio > 1 {
+		return fmt.Errorf("invalid value of field `request_quota_ratio`: %v", c.RequestQuotaRatio)
+	}
+
+	return nil
+}
+
+func validateReadCacheConfig(c *config.TReadCacheConfig) error {
+	// it's OK not to have this cache
+	if c == nil {
+		return nil
+	}
+
+	if _, err := common.DurationFromString(c.Ttl); err != nil {
+		return fmt.Errorf("validate `ttl`: %v", err)
+	}
+
+	if c.Ristretto.MaxSizeBytes <= 0 {
+		return fmt.Errorf("invalid `ristretto.max_size_bytes` value: %v", c.Ristretto.MaxSizeBytes)
+	}
+
+	if c.Ristretto.MaxKeys <= 0 {
+		return fmt.Errorf("invalid `ristretto.max_keys` value: %v", c.Ristretto.MaxKeys)
+	}
+
+	if diskSpill := c.DiskSpill; diskSpill != nil {
+		if diskSpill.Directory == "" {
+			return errors.New("`disk_spill.directory` must be set")
+		}
+
+		if diskSpill.MaxSizeBytes <= 0 {
+			return fmt.Errorf("invalid `disk_spill.max_size_bytes` value: %v", diskSpill.MaxSizeBytes)
+		}
+	}
+
+	return nil
+}
+
+func validateSchemaCacheConfig(c *config.TSchemaCacheConfig) error {
+	// it's OK not to have this cache
+	if c == nil {
+		return nil
+	}
+
+	if _, err := common.DurationFromString(c.Ttl); err != nil {
+		return fmt.Errorf("validate `ttl`: %v", err)
+	}
+
+	if c.Ristretto.MaxSizeBytes <= 0 {
+		return fmt.Errorf("invalid `ristretto.max_size_bytes` value: %v", c.Ristretto.MaxSizeBytes)
+	}
+
+	if c.Ristretto.MaxKeys <= 0 {
+		return fmt.Errorf("invalid `ristretto.max_keys` value: %v", c.Ristretto.MaxKeys)
+	}
+
+	return nil
+}
+
+func validateTypeMappingConfig(c *config.TTypeMappingConfig) error {
+	// it's OK not to have server-wide rules
+	if c == nil {
+		return nil
+	}
+
+	for i, rule := range c.Rules {
+		if strings.TrimSpace(rule.SourceType) == "" {
+			return fmt.Errorf("rule #%d: empty `source_type`", i)
+		}
+	}
+
 	return nil
 }
 
@@ -488,9 +727,35 @@ func validateDatasourcesConfig(c *config.TDatasourcesConfig) error {
 		return fmt.Errorf("validate `redis`: %w", err)
 	}
 
+	if err := validateNativeQueryConfig(c.NativeQuery); err != nil {
+		return fmt.Errorf("validate `native_query`: %w", err)
+	}
+
 	return nil
 }
 
+func validateNativeQueryConfig(c *config.TNativeQueryConfig) error {
+	for _, kind := range c.GetAllowedKinds() {
+		if !NativeQuerySupported(kind) {
+			return fmt.Errorf("native queries are not supported for data source kind %s", kind)
+		}
+	}
+
+	return nil
+}
+
+// NativeQuerySupported tells if the data source kind is able to run native queries.
+// Only PostgreSQL-like data sources are able to do it now, since the results of the native queries
+// are described by PostgreSQL schema provider only.
+func NativeQuerySupported(kind api_common.EGenericDataSourceKind) bool {
+	switch kind {
+	case api_common.EGenericDataSourceKind_POSTGRESQL, api_common.EGenericDataSourceKind_GREENPLUM:
+		return true
+	default:
+		return false
+	}
+}
+
 type relationalDatasourceConfig interface {
 	GetOpenConnectionTimeout() string
 	GetExponentialBackoff() *config.TExponentialBackoffConfig
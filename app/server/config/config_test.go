@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	"github.com/ydb-platform/fq-connector-go/app/config"
 )
 
@@ -57,3 +58,20 @@ func TestConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateNativeQueryConfig(t *testing.T) {
+	supported := []api_common.EGenericDataSourceKind{
+		api_common.EGenericDataSourceKind_POSTGRESQL,
+		api_common.EGenericDataSourceKind_GREENPLUM,
+	}
+
+	require.NoError(t, validateNativeQueryConfig(&config.TNativeQueryConfig{AllowedKinds: supported}))
+
+	// the results of the native queries can't be described for the other data sources
+	for _, kind := range []api_common.EGenericDataSourceKind{
+		api_common.EGenericDataSourceKind_CLICKHOUSE,
+		api_common.EGenericDataSourceKind_ORACLE,
+	} {
+		require.Error(t, validateNativeQueryConfig(&config.TNativeQueryConfig{AllowedKinds: append(supported, kind)}), kind)
+	}
+}
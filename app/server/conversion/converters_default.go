@@ -52,6 +52,13 @@ func (collectionDefault) TimestampToString(utc bool) ValuePtrConverter[time.Time
 
 	return timestampToStringConverterNaive{}
 }
+func (collectionDefault) Date32() ValuePtrConverter[time.Time, int32] { return date32Converter{} }
+func (collectionDefault) Datetime64() ValuePtrConverter[time.Time, int64] {
+	return datetime64Converter{}
+}
+func (collectionDefault) Timestamp64() ValuePtrConverter[time.Time, int64] {
+	return timestamp64Converter{}
+}
 
 type noopConverter[T common.ValueType] struct {
 }
@@ -142,3 +149,36 @@ type timestampToStringConverterNaive struct{}
 func (timestampToStringConverterNaive) Convert(in *time.Time) (string, error) {
 	return in.Format("2006-01-02T15:04:05.999999999"), nil
 }
+
+type date32Converter struct{}
+
+func (date32Converter) Convert(in *time.Time) (int32, error) {
+	out, err := common.TimeToYDBDate32(in)
+	if err != nil {
+		return 0, fmt.Errorf("convert time to YDB Date32: %w", err)
+	}
+
+	return out, nil
+}
+
+type datetime64Converter struct{}
+
+func (datetime64Converter) Convert(in *time.Time) (int64, error) {
+	out, err := common.TimeToYDBDatetime64(in)
+	if err != nil {
+		return 0, fmt.Errorf("convert time to YDB Datetime64: %w", err)
+	}
+
+	return out, nil
+}
+
+type timestamp64Converter struct{}
+
+func (timestamp64Converter) Convert(in *time.Time) (int64, error) {
+	out, err := common.TimeToYDBTimestamp64(in)
+	if err != nil {
+		return 0, fmt.Errorf("convert time to YDB Timestamp64: %w", err)
+	}
+
+	return out, nil
+}
@@ -32,4 +32,7 @@ type Collection interface {
 	DatetimeToString() ValuePtrConverter[time.Time, string]
 	Timestamp() ValuePtrConverter[time.Time, uint64]
 	TimestampToString(utc bool) ValuePtrConverter[time.Time, string]
+	Date32() ValuePtrConverter[time.Time, int32]
+	Datetime64() ValuePtrConverter[time.Time, int64]
+	Timestamp64() ValuePtrConverter[time.Time, int64]
 }
@@ -3,8 +3,9 @@ package server
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
-	"github.com/apache/arrow/go/v13/arrow/memory"
 	"go.uber.org/zap"
 
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
@@ -21,17 +22,25 @@ import (
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/ydb/table_metadata_cache"
 	"github.com/ydb-platform/fq-connector-go/app/server/observation"
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+	"github.com/ydb-platform/fq-connector-go/app/server/read_cache"
+	"github.com/ydb-platform/fq-connector-go/app/server/schema_cache"
 	"github.com/ydb-platform/fq-connector-go/app/server/streaming"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils/retry"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
+// queryMemoryReportPeriod defines how often the memory usage of the running queries is saved to the observation storage
+const queryMemoryReportPeriod = time.Second
+
 type DataSourceCollection struct {
 	rdbms               datasource.Factory[any]
-	memoryAllocator     memory.Allocator
+	memoryBudget        *paging.MemoryBudget
 	readLimiterFactory  *paging.ReadLimiterFactory
 	converterCollection conversion.Collection
 	observationStorage  observation.Storage
+	readCache           read_cache.Cache
+	schemaCache         schema_cache.Cache
+	openSearchMappings  *opensearch.MappingCache
 	cfg                 *config.TServerConfig
 	queryLoggerFactory  common.QueryLoggerFactory
 }
@@ -40,6 +49,32 @@ func (dsc *DataSourceCollection) DescribeTable(
 	ctx context.Context,
 	logger *zap.Logger,
 	request *api_service_protos.TDescribeTableRequest,
+) (*api_service_protos.TDescribeTableResponse, error) {
+	cacheKey, err := dsc.schemaCache.MakeKey(request)
+	if err != nil {
+		return nil, fmt.Errorf("make schema cache key: %w", err)
+	}
+
+	if response, found := dsc.schemaCache.Get(logger, cacheKey); found {
+		logger.Debug("table schema is served from the schema cache")
+
+		return response, nil
+	}
+
+	response, err := dsc.describeTable(ctx, logger, request)
+	if err != nil {
+		return nil, err
+	}
+
+	dsc.schemaCache.Put(logger, cacheKey, response)
+
+	return response, nil
+}
+
+func (dsc *DataSourceCollection) describeTable(
+	ctx context.Context,
+	logger *zap.Logger,
+	request *api_service_protos.TDescribeTableRequest,
 ) (*api_service_protos.TDescribeTableResponse, error) {
 	kind := request.GetDataSourceInstance().GetKind()
 
@@ -88,6 +123,7 @@ func (dsc *DataSourceCollection) DescribeTable(
 				Query:          retry.NewRetrierFromConfig(openSearchCfg.ExponentialBackoff, retry.ErrorCheckerNoop),
 			},
 			openSearchCfg,
+			dsc.openSearchMappings,
 			logger,
 			dsc.converterCollection,
 			dsc.queryLoggerFactory.Make(logger),
@@ -176,6 +212,7 @@ func (dsc *DataSourceCollection) ListSplits(
 					Query:          retry.NewRetrierFromConfig(openSearchCfg.ExponentialBackoff, retry.ErrorCheckerNoop),
 				},
 				openSearchCfg,
+				dsc.openSearchMappings,
 				logger,
 				dsc.converterCollection,
 				dsc.queryLoggerFactory.Make(logger),
@@ -216,6 +253,50 @@ func (dsc *DataSourceCollection) ReadSplit(
 	stream api_service.Connector_ReadSplitsServer,
 	request *api_service_protos.TReadSplitsRequest,
 	split *api_service_protos.TSplit,
+) error {
+	if !dsc.readCache.Enabled(split.GetSelect().GetDataSourceInstance().GetKind()) {
+		return dsc.readSplit(logger, stream, request, split)
+	}
+
+	cacheKey, err := read_cache.MakeKey(request, split)
+	if err != nil {
+		return fmt.Errorf("make read cache key: %w", err)
+	}
+
+	if !read_cache.IsBypassed(stream.Context()) {
+		if pages, found := dsc.readCache.Get(logger, cacheKey); found {
+			logger.Debug("split is served from the read cache", zap.Int("pages", len(pages)))
+
+			if err := read_cache.Replay(stream, pages); err != nil {
+				return fmt.Errorf("replay cached result: %w", err)
+			}
+
+			return nil
+		}
+	}
+
+	// the recorded pages are kept until the split is read, so they must be accounted as well
+	recorderQuota := dsc.memoryBudget.MakeQuota()
+	defer recorderQuota.Close()
+
+	recorder := read_cache.NewRecorder(stream, dsc.cfg.ReadCache.MaxResultSizeBytes, recorderQuota)
+
+	if err := dsc.readSplit(logger, recorder, request, split); err != nil {
+		return err
+	}
+
+	if pages, ok := recorder.Pages(); ok {
+		dsc.readCache.Put(logger, cacheKey, pages)
+	}
+
+	return nil
+}
+
+func (dsc *DataSourceCollection) readSplit(
+	logger *zap.Logger,
+	stream api_service.Connector_ReadSplitsServer,
+	request *api_service_protos.TReadSplitsRequest,
+	split *api_service_protos.TSplit,
 ) error {
 	kind := split.GetSelect().GetDataSourceInstance().GetKind()
 
@@ -230,7 +311,7 @@ func (dsc *DataSourceCollection) ReadSplit(
 		}
 
 		return doReadSplit[any](
-			logger, stream, request, split, ds, dsc.memoryAllocator, dsc.readLimiterFactory, dsc.observationStorage, dsc.cfg)
+			logger, stream, request, split, ds, dsc.memoryBudget, dsc.readLimiterFactory, dsc.observationStorage, dsc.cfg)
 	case api_common.EGenericDataSourceKind_MONGO_DB:
 		mongoDbCfg := dsc.cfg.Datasources.Mongodb
 		ds := mongodb.NewDataSource(
@@ -244,7 +325,7 @@ func (dsc *DataSourceCollection) ReadSplit(
 		)
 
 		return doReadSplit(
-			logger, stream, request, split, ds, dsc.memoryAllocator, dsc.readLimiterFactory, dsc.observationStorage, dsc.cfg)
+			logger, stream, request, split, ds, dsc.memoryBudget, dsc.readLimiterFactory, dsc.observationStorage, dsc.cfg)
 
 	case api_common.EGenericDataSourceKind_REDIS:
 		redisCfg := dsc.cfg.Datasources.Redis
@@ -259,7 +340,7 @@ func (dsc *DataSourceCollection) ReadSplit(
 		)
 
 		return doReadSplit(
-			logger, stream, request, split, ds, dsc.memoryAllocator, dsc.readLimiterFactory, dsc.observationStorage, dsc.cfg)
+			logger, stream, request, split, ds, dsc.memoryBudget, dsc.readLimiterFactory, dsc.observationStorage, dsc.cfg)
 	case api_common.EGenericDataSourceKind_OPENSEARCH:
 		openSearchCfg := dsc.cfg.Datasources.Opensearch
 		ds := opensearch.NewDataSource(
@@ -268,13 +349,14 @@ func (dsc *DataSourceCollection) ReadSplit(
 				Query:          retry.NewRetrierFromConfig(openSearchCfg.ExponentialBackoff, retry.ErrorCheckerNoop),
 			},
 			openSearchCfg,
+			dsc.openSearchMappings,
 			logger,
 			dsc.converterCollection,
 			dsc.queryLoggerFactory.Make(logger),
 		)
 
 		return doReadSplit(
-			logger, stream, request, split, ds, dsc.memoryAllocator, dsc.readLimiterFactory, dsc.observationStorage, dsc.cfg)
+			logger, stream, request, split, ds, dsc.memoryBudget, dsc.readLimiterFactory, dsc.observationStorage, dsc.cfg)
 	case api_common.EGenericDataSourceKind_PROMETHEUS:
 		prometheusCfg := dsc.cfg.Datasources.Prometheus
 		ds := prometheus.NewDataSource(
@@ -287,7 +369,7 @@ func (dsc *DataSourceCollection) ReadSplit(
 		)
 
 		return doReadSplit(
-			logger, stream, request, split, ds, dsc.memoryAllocator, dsc.readLimiterFactory, dsc.observationStorage, dsc.cfg)
+			logger, stream, request, split, ds, dsc.memoryBudget, dsc.readLimiterFactory, dsc.observationStorage, dsc.cfg)
 
 	default:
 		return fmt.Errorf("unsupported data source type '%v': %w", kind, common.ErrDataSourceNotSupported)
@@ -301,7 +383,7 @@ func doReadSplit[T paging.Acceptor](
 	request *api_service_protos.TReadSplitsRequest,
 	split *api_service_protos.TSplit,
 	dataSource datasource.DataSource[T],
-	memoryAllocator memory.Allocator,
+	memoryBudget *paging.MemoryBudget,
 	readLimiterFactory *paging.ReadLimiterFactory,
 	observationStorage observation.Storage,
 	cfg *config.TServerConfig,
@@ -314,10 +396,20 @@ func doReadSplit[T paging.Acceptor](
 
 	logger.Debug("split reading started", common.SelectToFields(split.Select)...)
 
+	// Track the memory allocated for the request data
+	memoryQuota := memoryBudget.MakeQuota()
+	defer memoryQuota.Close()
+
+	ipcWriter, err := paging.NewArrowIPCWriter(memoryQuota, request.Compression, request.DictionaryEncoding, split.Select.What)
+	if err != nil {
+		return fmt.Errorf("new Arrow IPC writer: %w", err)
+	}
+
 	columnarBufferFactory, err := paging.NewColumnarBufferFactory[T](
 		logger,
-		memoryAllocator,
+		memoryQuota,
 		request.Format,
+		ipcWriter,
 		split.Select.What)
 	if err != nil {
 		return fmt.Errorf("new columnar buffer factory: %w", err)
@@ -326,9 +418,11 @@ func doReadSplit[T paging.Acceptor](
 	sinkFactory := paging.NewSinkFactory[T](
 		stream.Context(),
 		logger,
-		cfg.Paging,
+		paging.MakePagingConfig(cfg.Paging, request.Paging),
 		columnarBufferFactory,
+		ipcWriter,
 		readLimiterFactory.MakeReadLimiter(logger, split.Select.DataSourceInstance.Kind),
+		memoryQuota,
 	)
 
 	streamer := streaming.NewReadSplitsStreamer(
@@ -342,10 +436,15 @@ func doReadSplit[T paging.Acceptor](
 	)
 
 	// Run streaming reading
-	if err = streamer.Run(); err != nil {
+	stopMemoryReport := reportQueryMemory(stream.Context(), logger, observationStorage, queryID, memoryQuota)
+	err = streamer.Run()
+
+	stopMemoryReport()
+
+	if err != nil {
 		// Register query error
 		cancelQueryErr := observationStorage.CancelIncomingQuery(
-			context.Background(), logger, queryID, err.Error(), sinkFactory.FinalStats())
+			context.Background(), logger, queryID, err.Error(), sinkFactory.FinalStats(), memoryQuota.Peak())
 		if cancelQueryErr != nil {
 			logger.Error("observation storage cancel incoming query", zap.Error(cancelQueryErr))
 		}
@@ -360,12 +459,13 @@ func doReadSplit[T paging.Acceptor](
 	fields = append(fields,
 		zap.Uint64("total_bytes", readStats.GetBytes()),
 		zap.Uint64("total_rows", readStats.GetRows()),
+		zap.Uint64("peak_memory_bytes", memoryQuota.Peak()),
 	)
 
 	logger.Debug("split reading finished", fields...)
 
 	// Register query success
-	err = observationStorage.FinishIncomingQuery(context.Background(), logger, queryID, readStats)
+	err = observationStorage.FinishIncomingQuery(context.Background(), logger, queryID, readStats, memoryQuota.Peak())
 	if err != nil {
 		return fmt.Errorf("observation storage finish incoming query: %w", err)
 	}
@@ -373,17 +473,64 @@ func doReadSplit[T paging.Acceptor](
 	return nil
 }
 
+// reportQueryMemory saves the memory usage of the running query to the observation storage
+// until the returned function is called
+func reportQueryMemory(
+	ctx context.Context,
+	logger *zap.Logger,
+	observationStorage observation.Storage,
+	queryID string,
+	memoryQuota *paging.MemoryQuota,
+) func() {
+	var wg sync.WaitGroup
+
+	done := make(chan struct{})
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(queryMemoryReportPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				err := observationStorage.UpdateIncomingQueryMemory(ctx, logger, queryID, memoryQuota.Allocated(), memoryQuota.Peak())
+				if err != nil {
+					logger.Error("observation storage update incoming query memory", zap.Error(err))
+				}
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// the final values are saved when the query is finished, so the reports must stop before
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
 func (dsc *DataSourceCollection) Close() error {
+	dsc.readCache.Close()
+
 	return dsc.rdbms.Close()
 }
 
 func NewDataSourceCollection(
 	queryLoggerFactory common.QueryLoggerFactory,
-	memoryAllocator memory.Allocator,
+	memoryBudget *paging.MemoryBudget,
 	readLimiterFactory *paging.ReadLimiterFactory,
 	converterCollection conversion.Collection,
 	observationStorage observation.Storage,
 	ydbTableMetadataCache table_metadata_cache.Cache,
+	readCache read_cache.Cache,
+	schemaCache schema_cache.Cache,
 	cfg *config.TServerConfig,
 ) (*DataSourceCollection, error) {
 	rdbmsFactory, err := rdbms.NewDataSourceFactory(
@@ -397,12 +544,20 @@ func NewDataSourceCollection(
 		return nil, fmt.Errorf("new data source factory: %w", err)
 	}
 
+	openSearchMappings, err := opensearch.NewMappingCache()
+	if err != nil {
+		return nil, fmt.Errorf("new OpenSearch mapping cache: %w", err)
+	}
+
 	return &DataSourceCollection{
 		rdbms:               rdbmsFactory,
-		memoryAllocator:     memoryAllocator,
+		memoryBudget:        memoryBudget,
 		readLimiterFactory:  readLimiterFactory,
 		converterCollection: converterCollection,
 		observationStorage:  observationStorage,
+		readCache:           readCache,
+		schemaCache:         schemaCache,
+		openSearchMappings:  openSearchMappings,
 		cfg:                 cfg,
 		queryLoggerFactory:  queryLoggerFactory,
 	}, nil
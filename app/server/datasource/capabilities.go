@@ -0,0 +1,21 @@
+package datasource
+
+import (
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+// NewCapabilities returns the capabilities shared by all the data sources:
+// the format of the data, the dictionary encoding and the filtering modes. The rest of the capabilities
+// must be filled by the particular data source.
+func NewCapabilities() *api_service_protos.TCapabilities {
+	return &api_service_protos.TCapabilities{
+		Formats: []api_service_protos.TReadSplitsRequest_EFormat{
+			api_service_protos.TReadSplitsRequest_ARROW_IPC_STREAMING,
+		},
+		Filtering: []api_service_protos.TReadSplitsRequest_EFiltering{
+			api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+			api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY,
+		},
+		DictionaryEncoding: true,
+	}
+}
@@ -71,3 +71,9 @@ type TypeMapper interface {
 		rules *api_service_protos.TTypeMappingSettings,
 	) (*Ydb.Column, error)
 }
+
+// AggregationTypeMapper is implemented by the type mappers of the data sources that support aggregation pushdown.
+// It derives the type of the aggregate function result in terms of the data source type system.
+type AggregationTypeMapper interface {
+	AggregationToYDBColumn(agg *api_service_protos.TSelect_TAggregation) (*Ydb.Column, error)
+}
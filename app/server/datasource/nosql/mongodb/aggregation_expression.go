@@ -0,0 +1,194 @@
+package mongodb
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// Functions of this file translate YQL expressions into the aggregation expressions,
+// which are evaluated within `$expr` operator.
+
+func formatArithmeticalExpression(expr *api_service_protos.TExpression_TArithmeticalExpression) (any, error) {
+	var operator string
+
+	switch op := expr.Operation; op {
+	case api_service_protos.TExpression_TArithmeticalExpression_ADD:
+		operator = "$add"
+	case api_service_protos.TExpression_TArithmeticalExpression_SUB:
+		operator = "$subtract"
+	case api_service_protos.TExpression_TArithmeticalExpression_MUL:
+		operator = "$multiply"
+	case api_service_protos.TExpression_TArithmeticalExpression_MOD:
+		operator = "$mod"
+	case api_service_protos.TExpression_TArithmeticalExpression_BIT_AND:
+		operator = "$bitAnd"
+	case api_service_protos.TExpression_TArithmeticalExpression_BIT_OR:
+		operator = "$bitOr"
+	case api_service_protos.TExpression_TArithmeticalExpression_BIT_XOR:
+		operator = "$bitXor"
+	default:
+		// `$divide` always returns a floating point number, while YQL division of integers is integer
+		return nil, fmt.Errorf("%w, op: %s", common.ErrUnimplementedOperation, op)
+	}
+
+	left, err := formatScalarExpression(expr.LeftValue)
+	if err != nil {
+		return nil, fmt.Errorf("format left expression: %w", err)
+	}
+
+	right, err := formatScalarExpression(expr.RightValue)
+	if err != nil {
+		return nil, fmt.Errorf("format right expression: %w", err)
+	}
+
+	return bson.D{{Key: operator, Value: bson.A{left, right}}}, nil
+}
+
+func formatIf(expr *api_service_protos.TExpression_TIf) (any, error) {
+	predicate, err := formatPredicateExpression(expr.Predicate)
+	if err != nil {
+		return nil, fmt.Errorf("format predicate: %w", err)
+	}
+
+	thenExpr, err := formatScalarExpression(expr.ThenExpression)
+	if err != nil {
+		return nil, fmt.Errorf("format then expression: %w", err)
+	}
+
+	elseExpr, err := formatScalarExpression(expr.ElseExpression)
+	if err != nil {
+		return nil, fmt.Errorf("format else expression: %w", err)
+	}
+
+	return bson.D{{Key: "$cond", Value: bson.A{predicate, thenExpr, elseExpr}}}, nil
+}
+
+func formatCast(expr *api_service_protos.TExpression_TCast) (any, error) {
+	to, err := getConvertTargetType(expr.Type)
+	if err != nil {
+		return nil, fmt.Errorf("get target type: %w", err)
+	}
+
+	value, err := formatScalarExpression(expr.Value)
+	if err != nil {
+		return nil, fmt.Errorf("format value: %w", err)
+	}
+
+	// Failed CAST returns NULL in YQL
+	return bson.D{{Key: "$convert", Value: bson.D{
+		{Key: "input", Value: value},
+		{Key: "to", Value: to},
+		{Key: "onError", Value: nil},
+		{Key: "onNull", Value: nil},
+	}}}, nil
+}
+
+// getConvertTargetType returns the `$convert` target type which has the same range of values as the YDB type
+func getConvertTargetType(ydbType *Ydb.Type) (string, error) {
+	for ydbType.GetOptionalType() != nil {
+		ydbType = ydbType.GetOptionalType().GetItem()
+	}
+
+	switch typeID := ydbType.GetTypeId(); typeID {
+	case Ydb.Type_BOOL:
+		return "bool", nil
+	case Ydb.Type_INT32:
+		return "int", nil
+	case Ydb.Type_INT64:
+		return "long", nil
+	case Ydb.Type_DOUBLE:
+		return "double", nil
+	case Ydb.Type_STRING, Ydb.Type_UTF8:
+		return "string", nil
+	default:
+		return "", fmt.Errorf("%w, cast to type: %v", common.ErrUnimplementedOperation, ydbType)
+	}
+}
+
+// formatScalarExpression formats the expression which cannot be an ObjectId candidate
+func formatScalarExpression(expression *api_service_protos.TExpression) (any, error) {
+	out, err := formatExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := out.(objectIdPair); ok {
+		return nil, fmt.Errorf("%w, ObjectId candidate within expression", common.ErrUnsupportedExpression)
+	}
+
+	return out, nil
+}
+
+// formatPredicateExpression translates predicate into the boolean aggregation expression
+func formatPredicateExpression(predicate *api_service_protos.TPredicate) (any, error) {
+	switch p := predicate.GetPayload().(type) {
+	case *api_service_protos.TPredicate_Comparison:
+		return makeComparisonExpression(p.Comparison)
+	case *api_service_protos.TPredicate_IsNull:
+		value, err := formatScalarExpression(p.IsNull.Value)
+		if err != nil {
+			return nil, fmt.Errorf("format IsNull value: %w", err)
+		}
+
+		// Both null and missing values are less or equal than null in BSON comparison order
+		return bson.D{{Key: "$lte", Value: bson.A{value, nil}}}, nil
+	case *api_service_protos.TPredicate_IsNotNull:
+		value, err := formatScalarExpression(p.IsNotNull.Value)
+		if err != nil {
+			return nil, fmt.Errorf("format IsNotNull value: %w", err)
+		}
+
+		return bson.D{{Key: "$gt", Value: bson.A{value, nil}}}, nil
+	case *api_service_protos.TPredicate_BoolExpression:
+		value, err := formatScalarExpression(p.BoolExpression.Value)
+		if err != nil {
+			return nil, fmt.Errorf("format bool expression: %w", err)
+		}
+
+		return bson.D{{Key: "$eq", Value: bson.A{value, true}}}, nil
+	case *api_service_protos.TPredicate_Negation:
+		operand, err := formatPredicateExpression(p.Negation.Operand)
+		if err != nil {
+			return nil, fmt.Errorf("format negation operand: %w", err)
+		}
+
+		return bson.D{{Key: "$not", Value: bson.A{operand}}}, nil
+	case *api_service_protos.TPredicate_Conjunction:
+		operands, err := formatPredicateExpressions(p.Conjunction.Operands)
+		if err != nil {
+			return nil, fmt.Errorf("format conjunction operands: %w", err)
+		}
+
+		return bson.D{{Key: "$and", Value: operands}}, nil
+	case *api_service_protos.TPredicate_Disjunction:
+		operands, err := formatPredicateExpressions(p.Disjunction.Operands)
+		if err != nil {
+			return nil, fmt.Errorf("format disjunction operands: %w", err)
+		}
+
+		return bson.D{{Key: "$or", Value: operands}}, nil
+	default:
+		return nil, fmt.Errorf("%w, type: %T", common.ErrUnimplementedPredicateType, p)
+	}
+}
+
+func formatPredicateExpressions(predicates []*api_service_protos.TPredicate) (bson.A, error) {
+	out := make(bson.A, 0, len(predicates))
+
+	for _, predicate := range predicates {
+		operand, err := formatPredicateExpression(predicate)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, operand)
+	}
+
+	return out, nil
+}
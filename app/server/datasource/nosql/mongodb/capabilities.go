@@ -0,0 +1,57 @@
+package mongodb
+
+import (
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+)
+
+// makeCapabilities lists the parts of the query that are translated into the MongoDB filter and find options
+func makeCapabilities() *api_service_protos.TCapabilities {
+	out := datasource.NewCapabilities()
+
+	out.Predicates = []api_service_protos.TCapabilities_EPredicate{
+		api_service_protos.TCapabilities_PREDICATE_NEGATION,
+		api_service_protos.TCapabilities_PREDICATE_CONJUNCTION,
+		api_service_protos.TCapabilities_PREDICATE_DISJUNCTION,
+		api_service_protos.TCapabilities_PREDICATE_BETWEEN,
+		api_service_protos.TCapabilities_PREDICATE_IN,
+		api_service_protos.TCapabilities_PREDICATE_IS_NULL,
+		api_service_protos.TCapabilities_PREDICATE_IS_NOT_NULL,
+		api_service_protos.TCapabilities_PREDICATE_COMPARISON,
+		api_service_protos.TCapabilities_PREDICATE_BOOL_EXPRESSION,
+		api_service_protos.TCapabilities_PREDICATE_REGEXP,
+		api_service_protos.TCapabilities_PREDICATE_LIKE,
+	}
+
+	out.Expressions = []api_service_protos.TCapabilities_EExpression{
+		api_service_protos.TCapabilities_EXPRESSION_TYPED_VALUE,
+		api_service_protos.TCapabilities_EXPRESSION_COLUMN,
+		api_service_protos.TCapabilities_EXPRESSION_NULL,
+		api_service_protos.TCapabilities_EXPRESSION_ARITHMETICAL_EXPRESSION,
+		api_service_protos.TCapabilities_EXPRESSION_IF,
+		api_service_protos.TCapabilities_EXPRESSION_CAST,
+	}
+
+	out.Types = []Ydb.Type_PrimitiveTypeId{
+		Ydb.Type_BOOL,
+		Ydb.Type_INT8,
+		Ydb.Type_UINT8,
+		Ydb.Type_INT16,
+		Ydb.Type_UINT16,
+		Ydb.Type_INT32,
+		Ydb.Type_UINT32,
+		Ydb.Type_INT64,
+		Ydb.Type_UINT64,
+		Ydb.Type_FLOAT,
+		Ydb.Type_DOUBLE,
+		Ydb.Type_STRING,
+		Ydb.Type_UTF8,
+	}
+
+	out.Limit = true
+	out.OrderBy = true
+
+	return out
+}
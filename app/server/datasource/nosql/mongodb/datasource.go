@@ -11,11 +11,14 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/config"
 	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils/retry"
 	"github.com/ydb-platform/fq-connector-go/common"
@@ -89,36 +92,49 @@ func (ds *dataSource) DescribeTable(
 		}
 	}()
 
-	collection := conn.Database(dsi.Database).Collection(request.Table)
+	database := conn.Database(dsi.Database)
+	collection := database.Collection(request.Table)
 
-	cursor, err := collection.Find(ctx, bson.D{}, options.Find().SetLimit(int64(ds.cfg.GetCountDocsToDeduceSchema())))
-	if err != nil {
-		return nil, fmt.Errorf("find in collection: %w", err)
-	}
+	omitUnsupported :=
+		mongoDbOptions.UnsupportedTypeDisplayMode == api_common.TMongoDbDataSourceOptions_UNSUPPORTED_OMIT
+	typeMapIdOnly := isSerializedDocumentReadingMode(mongoDbOptions.ReadingMode)
+	strategy := mongoDbOptions.SchemaInferenceStrategy
 
-	defer func() {
-		if err = cursor.Close(ctx); err != nil {
-			logger.Error(fmt.Sprintf("cursor close: %v", err))
-		}
-	}()
+	var columns []*Ydb.Column
 
-	docs := make([]bson.Raw, 0, ds.cfg.GetCountDocsToDeduceSchema())
+	if strategy == api_common.TMongoDbDataSourceOptions_SCHEMA_INFERENCE_JSON_SCHEMA && !typeMapIdOnly {
+		jsonSchema, err := getJSONSchema(ctx, database, request.Table)
+		if err != nil {
+			return nil, fmt.Errorf("get $jsonSchema: %w", err)
+		}
 
-	for cursor.Next(ctx) {
-		docs = append(docs, cursor.Current)
+		if jsonSchema != nil {
+			columns, err = jsonSchemaToYql(logger, jsonSchema, omitUnsupported, objectIdType)
+			if err != nil {
+				return nil, fmt.Errorf("jsonSchemaToYql: %w", err)
+			}
+		} else {
+			logger.Warn("collection has no $jsonSchema validator, falling back to the first documents")
+		}
 	}
 
-	if err = cursor.Err(); err != nil {
-		return nil, fmt.Errorf("cursor: %w", err)
-	}
+	if columns == nil {
+		docs, err := fetchSampleDocuments(ctx, logger, collection, strategy, ds.cfg.GetCountDocsToDeduceSchema())
+		if err != nil {
+			return nil, fmt.Errorf("fetch sample documents: %w", err)
+		}
 
-	omitUnsupported :=
-		mongoDbOptions.UnsupportedTypeDisplayMode == api_common.TMongoDbDataSourceOptions_UNSUPPORTED_OMIT
-	typeMapIdOnly := isSerializedDocumentReadingMode(mongoDbOptions.ReadingMode)
+		columns, err = bsonToYql(logger, docs, omitUnsupported, typeMapIdOnly, objectIdType)
+		if err != nil {
+			return nil, fmt.Errorf("bsonToYqlColumn: %w", err)
+		}
+	}
 
-	columns, err := bsonToYql(logger, docs, omitUnsupported, typeMapIdOnly, objectIdType)
-	if err != nil {
-		return nil, fmt.Errorf("bsonToYqlColumn: %w", err)
+	if !typeMapIdOnly {
+		columns, err = applySchemaOverride(columns, mongoDbOptions.SchemaOverride, objectIdType)
+		if err != nil {
+			return nil, fmt.Errorf("apply schema override: %w", err)
+		}
 	}
 
 	if isSerializedDocumentReadingMode(mongoDbOptions.ReadingMode) {
@@ -132,10 +148,13 @@ func (ds *dataSource) DescribeTable(
 		documentType := getDocumentType(mongoDbOptions.ReadingMode)
 		schema := getSerializedDocumentSchema(request.Table, idColumnType, documentType)
 
-		return &api_service_protos.TDescribeTableResponse{Schema: schema}, nil
+		return &api_service_protos.TDescribeTableResponse{Schema: schema, Capabilities: makeCapabilities()}, nil
 	}
 
-	return &api_service_protos.TDescribeTableResponse{Schema: &api_service_protos.TSchema{Columns: columns}}, nil
+	return &api_service_protos.TDescribeTableResponse{
+		Schema:       &api_service_protos.TSchema{Columns: columns},
+		Capabilities: makeCapabilities(),
+	}, nil
 }
 
 func (*dataSource) ListSplits(
@@ -201,12 +220,7 @@ func (ds *dataSource) ReadSplit(
 		}
 	}()
 
-	sinks, err := sinkFactory.MakeSinks([]*paging.SinkParams{{Logger: logger}})
-	if err != nil {
-		return fmt.Errorf("make sinks: %w", err)
-	}
-
-	return ds.doReadSplitSingleConn(ctx, logger, dsi, mongoDbOptions, request, split, sinks[0], conn)
+	return ds.doReadSplitSingleConn(ctx, logger, dsi, mongoDbOptions, request, split, sinkFactory, conn)
 }
 
 func (ds *dataSource) makeConnection(
@@ -263,18 +277,30 @@ func (ds *dataSource) doReadSplitSingleConn(
 	mongoDbOptions *api_common.TMongoDbDataSourceOptions,
 	request *api_service_protos.TReadSplitsRequest,
 	split *api_service_protos.TSplit,
-	sink paging.Sink[any],
+	sinkFactory paging.SinkFactory[any],
 	conn *mongo.Client,
 ) error {
 	collection := conn.Database(dsi.Database).Collection(split.Select.From.Table)
 
 	ds.queryLogger.Dump(split.Select.From.Table, split.Select.What.String())
 
-	filter, opts, err := makeFilter(logger, split, request.GetFiltering(), mongoDbOptions.ReadingMode)
+	filter, opts, residualPredicate, err := makeFilter(logger, split, request.GetFiltering(), mongoDbOptions.ReadingMode)
 	if err != nil {
 		return fmt.Errorf("make filter: %w", err)
 	}
 
+	residualFilter, err := filtering.NewResidualFilter(logger, residualPredicate, split.Select.What)
+	if err != nil {
+		return fmt.Errorf("new residual filter: %w", err)
+	}
+
+	sinks, err := sinkFactory.MakeSinks([]*paging.SinkParams{{Logger: logger, ResidualFilter: residualFilter}})
+	if err != nil {
+		return fmt.Errorf("make sinks: %w", err)
+	}
+
+	sink := sinks[0]
+
 	ds.queryLogger.Dump("Query filter", zap.Any("filter", filter))
 
 	var cursor *mongo.Cursor
@@ -166,6 +166,32 @@ func convert[INTO any](acceptor **INTO, value any) {
 	}
 }
 
+// widenInt64 accepts the values of the narrower integer types,
+// since the column type could be widened during the schema inference (see widenTypes)
+func widenInt64(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return v, true
+	case int32:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
+
+func widenFloat64(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
 func (r *documentReader) accept(doc bson.M) error {
 	acceptors := r.transformer.GetAcceptors()
 
@@ -245,13 +271,32 @@ func (r *documentReader) acceptSingleField(acceptor any, doc bson.M, fieldName s
 	case **int32:
 		convert(a, doc[fieldName])
 	case *int64:
-		*a = doc[fieldName].(int64)
+		v, ok := widenInt64(doc[fieldName])
+		if !ok {
+			// required column can't hold NULL, so the value of the previous row must not leak into this one
+			return fmt.Errorf("field '%s' of type %T can't be read as int64: %w", fieldName, doc[fieldName], common.ErrDataTypeNotSupported)
+		}
+
+		*a = v
 	case **int64:
-		convert(a, doc[fieldName])
+		if v, ok := widenInt64(doc[fieldName]); ok {
+			*a = ptr.Int64(v)
+		} else {
+			*a = nil
+		}
 	case *float64:
-		*a = doc[fieldName].(float64)
+		v, ok := widenFloat64(doc[fieldName])
+		if !ok {
+			return fmt.Errorf("field '%s' of type %T can't be read as float64: %w", fieldName, doc[fieldName], common.ErrDataTypeNotSupported)
+		}
+
+		*a = v
 	case **float64:
-		convert(a, doc[fieldName])
+		if v, ok := widenFloat64(doc[fieldName]); ok {
+			*a = ptr.Float64(v)
+		} else {
+			*a = nil
+		}
 	case *string:
 		value, ok := doc[fieldName]
 		if !ok {
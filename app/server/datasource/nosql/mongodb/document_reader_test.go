@@ -0,0 +1,41 @@
+package mongodb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestAcceptSingleFieldTypeMismatch(t *testing.T) {
+	r := &documentReader{}
+
+	t.Run("int64", func(t *testing.T) {
+		var acceptor int64
+
+		require.NoError(t, r.acceptSingleField(&acceptor, bson.M{"a": int32(42)}, "a"))
+		require.Equal(t, int64(42), acceptor)
+
+		err := r.acceptSingleField(&acceptor, bson.M{"a": "str"}, "a")
+		require.ErrorIs(t, err, common.ErrDataTypeNotSupported)
+	})
+
+	t.Run("float64", func(t *testing.T) {
+		var acceptor float64
+
+		require.NoError(t, r.acceptSingleField(&acceptor, bson.M{"a": int64(42)}, "a"))
+		require.Equal(t, float64(42), acceptor)
+
+		err := r.acceptSingleField(&acceptor, bson.M{}, "a")
+		require.ErrorIs(t, err, common.ErrDataTypeNotSupported)
+	})
+
+	t.Run("optional", func(t *testing.T) {
+		acceptor := new(int64)
+
+		require.NoError(t, r.acceptSingleField(&acceptor, bson.M{"a": "str"}, "a"))
+		require.Nil(t, acceptor)
+	})
+}
@@ -14,21 +14,24 @@ import (
 
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
+// makeFilter returns the filter and the options of the MongoDB query, as well as the part of the predicate
+// that was not pushed down (in the FILTERING_OPTIONAL mode only).
 func makeFilter(
 	logger *zap.Logger,
 	split *api_service_protos.TSplit,
 	filtering api_service_protos.TReadSplitsRequest_EFiltering,
 	readingMode readingMode,
-) (bson.D, *options.FindOptions, error) {
+) (bson.D, *options.FindOptions, *api_service_protos.TPredicate, error) {
 	opts := options.Find()
 
 	if readingMode == api_common.TMongoDbDataSourceOptions_TABLE {
 		what := split.Select.What
 		if what == nil {
-			return nil, nil, errors.New("not specified columns to query in Select.What")
+			return nil, nil, nil, errors.New("not specified columns to query in Select.What")
 		}
 
 		projection := bson.D{}
@@ -40,41 +43,112 @@ func makeFilter(
 		opts.SetProjection(projection)
 	}
 
-	limit := split.Select.Limit
-	if limit != nil {
-		opts.SetSkip(int64(limit.Offset))
-		opts.SetLimit(int64(limit.Limit))
+	if orderBy := split.Select.GetOrderBy(); len(orderBy.GetKeys()) > 0 {
+		sort, err := makeSort(orderBy)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("make sort: %w", err)
+		}
+
+		opts.SetSort(sort)
 	}
 
-	where := split.Select.Where
+	filter, residualPredicate, err := makeWhereFilter(logger, split.Select.Where.GetFilterTyped(), filtering)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-	filterTyped := where.GetFilterTyped()
-	if filterTyped == nil {
-		return bson.D{}, opts, nil
+	// Limit is not pushed down if some documents are going to be filtered out afterwards,
+	// otherwise the result would be shorter than requested.
+	// Like in the relational data sources, `offset + limit` documents are returned, and the engine applies OFFSET by itself.
+	if limit := split.Select.GetLimit(); limit.GetLimit() > 0 && residualPredicate == nil {
+		opts.SetLimit(int64(limit.Limit + limit.Offset))
 	}
 
-	doSuppressConjunctionErrors := filtering == api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL
+	return filter, opts, residualPredicate, nil
+}
 
-	filter, err := makePredicateFilter(logger, filterTyped, doSuppressConjunctionErrors)
-	if err != nil {
-		switch filtering {
-		case api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY:
+// makeWhereFilter translates the predicate into the filter. In the FILTERING_OPTIONAL mode the conjuncts
+// that can't be translated are skipped and returned as the residual predicate.
+func makeWhereFilter(
+	logger *zap.Logger,
+	predicate *api_service_protos.TPredicate,
+	filteringMode api_service_protos.TReadSplitsRequest_EFiltering,
+) (bson.D, *api_service_protos.TPredicate, error) {
+	if predicate == nil {
+		return bson.D{}, nil, nil
+	}
+
+	switch filteringMode {
+	case api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY:
+		filter, err := makePredicateFilter(logger, predicate, false)
+		if err != nil {
 			return nil, nil, fmt.Errorf("encountered an error making a filter: %w", err)
-		case api_service_protos.TReadSplitsRequest_FILTERING_UNSPECIFIED,
-			api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL:
-			if common.OptionalFilteringAllowedErrors.Match(err) {
-				logger.Warn("considering pushdown error as acceptable", zap.Error(err))
+		}
+
+		return filter, nil, nil
+	case api_service_protos.TReadSplitsRequest_FILTERING_UNSPECIFIED,
+		api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL:
+	default:
+		return nil, nil, fmt.Errorf("unknown filtering mode: %d", filteringMode)
+	}
+
+	operands := []*api_service_protos.TPredicate{predicate}
+	if conjunction := predicate.GetConjunction(); conjunction != nil {
+		operands = conjunction.Operands
+	}
 
-				return filter, opts, nil
+	var (
+		filters  []bson.D
+		residual []*api_service_protos.TPredicate
+	)
+
+	for _, operand := range operands {
+		filter, err := makePredicateFilter(logger, operand, false)
+		if err != nil {
+			if !common.OptionalFilteringAllowedErrors.Match(err) {
+				return nil, nil, fmt.Errorf("encountered an error making a filter: %w", err)
 			}
 
-			return nil, nil, fmt.Errorf("encountered an error making a filter: %w", err)
+			logger.Warn("considering pushdown error as acceptable", zap.Error(err))
+
+			residual = append(residual, operand)
+
+			continue
+		}
+
+		filters = append(filters, filter)
+	}
+
+	switch len(filters) {
+	case 0:
+		return bson.D{}, filtering.MakeConjunction(residual), nil
+	case 1:
+		return filters[0], filtering.MakeConjunction(residual), nil
+	default:
+		return bson.D{{Key: "$and", Value: filters}}, filtering.MakeConjunction(residual), nil
+	}
+}
+
+// makeSort builds the `$sort` specification
+func makeSort(orderBy *api_service_protos.TSelect_TOrderBy) (bson.D, error) {
+	sort := make(bson.D, 0, len(orderBy.GetKeys()))
+
+	for _, key := range orderBy.GetKeys() {
+		if key.Column == "" {
+			return nil, fmt.Errorf("empty sort key column: %w", common.ErrInvalidRequest)
+		}
+
+		switch key.Direction {
+		case api_service_protos.TSelect_TOrderBy_DIRECTION_UNSPECIFIED, api_service_protos.TSelect_TOrderBy_ASC:
+			sort = append(sort, bson.E{Key: key.Column, Value: 1})
+		case api_service_protos.TSelect_TOrderBy_DESC:
+			sort = append(sort, bson.E{Key: key.Column, Value: -1})
 		default:
-			return nil, nil, fmt.Errorf("unknown filtering mode: %d", filtering)
+			return nil, fmt.Errorf("unexpected sort direction %v: %w", key.Direction, common.ErrInvalidRequest)
 		}
 	}
 
-	return filter, opts, nil
+	return sort, nil
 }
 
 //nolint:funlen,gocyclo
@@ -241,6 +315,25 @@ func getIsNullFilter(
 
 func getComparisonFilter(
 	comparison *api_service_protos.TPredicate_TComparison,
+) (bson.D, error) {
+	switch comparison.Operation {
+	case api_service_protos.TPredicate_TComparison_STARTS_WITH,
+		api_service_protos.TPredicate_TComparison_ENDS_WITH,
+		api_service_protos.TPredicate_TComparison_CONTAINS:
+		return getStringComparisonFilter(comparison)
+	}
+
+	expr, err := makeComparisonExpression(comparison)
+	if err != nil {
+		return nil, err
+	}
+
+	return bson.D{{Key: "$expr", Value: expr}}, nil
+}
+
+// makeComparisonExpression builds the aggregation expression comparing two arbitrary expressions
+func makeComparisonExpression(
+	comparison *api_service_protos.TPredicate_TComparison,
 ) (bson.D, error) {
 	var operation string
 
@@ -257,10 +350,6 @@ func getComparisonFilter(
 		operation = "$gte"
 	case api_service_protos.TPredicate_TComparison_G:
 		operation = "$gt"
-	case api_service_protos.TPredicate_TComparison_STARTS_WITH,
-		api_service_protos.TPredicate_TComparison_ENDS_WITH,
-		api_service_protos.TPredicate_TComparison_CONTAINS:
-		return getStringComparisonFilter(comparison)
 	default:
 		return nil, fmt.Errorf("%w, op: %d", common.ErrUnimplementedOperation, op)
 	}
@@ -289,9 +378,7 @@ func getComparisonFilter(
 	// as both binary and ObjectId, and if successful,
 	// generate a filter using a logical OR to match both.
 
-	return bson.D{{Key: "$expr",
-		Value: bson.D{{Key: "$or", Value: predicates}},
-	}}, nil
+	return bson.D{{Key: "$or", Value: predicates}}, nil
 }
 
 func getStringComparisonFilter(
@@ -403,21 +490,35 @@ func getBetweenFilter(
 func getRegexFilter(
 	regex *api_service_protos.TPredicate_TRegexp,
 ) (bson.D, error) {
-	var fieldName string
+	pattern, err := formatExpression(regex.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("format regex pattern expression: %v: %w", regex.Pattern, err)
+	}
 
-	switch e := regex.Value.Payload.(type) {
-	case *api_service_protos.TExpression_Column:
-		fieldName = e.Column
-	default:
-		return nil, fmt.Errorf("unsupported expression in Regexp filter: %w", common.ErrUnimplementedExpression)
+	// Patterns of YQL String type come as binary data
+	switch p := pattern.(type) {
+	case []byte:
+		pattern = string(p)
+	case objectIdPair:
+		if b, ok := p.bytes.([]byte); ok {
+			pattern = string(b)
+		}
 	}
 
-	pattern, err := formatExpression(regex.Pattern)
+	if column := regex.Value.GetColumn(); column != "" {
+		return bson.D{{Key: column, Value: bson.D{{Key: "$regex", Value: pattern}}}}, nil
+	}
+
+	// Other expressions (like `IF(col IS NOT NULL, CAST(col AS String), NULL)`) are matched within `$expr`
+	input, err := formatScalarExpression(regex.Value)
 	if err != nil {
-		return nil, fmt.Errorf("format regex pattern expression: %v: %w", regex.Pattern, err)
+		return nil, fmt.Errorf("format regex value expression: %w", err)
 	}
 
-	return bson.D{{Key: fieldName, Value: bson.D{{Key: "$regex", Value: pattern}}}}, nil
+	return bson.D{{Key: "$expr", Value: bson.D{{Key: "$regexMatch", Value: bson.D{
+		{Key: "input", Value: input},
+		{Key: "regex", Value: pattern},
+	}}}}}, nil
 }
 
 func formatExpression(expression *api_service_protos.TExpression) (any, error) {
@@ -430,6 +531,12 @@ func formatExpression(expression *api_service_protos.TExpression) (any, error) {
 		return nil, nil
 	case *api_service_protos.TExpression_Coalesce:
 		return formatCoalesce(e.Coalesce)
+	case *api_service_protos.TExpression_ArithmeticalExpression:
+		return formatArithmeticalExpression(e.ArithmeticalExpression)
+	case *api_service_protos.TExpression_If:
+		return formatIf(e.If)
+	case *api_service_protos.TExpression_Cast:
+		return formatCast(e.Cast)
 	default:
 		return nil, fmt.Errorf("%w, type: %T", common.ErrUnimplementedExpression, e)
 	}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/common"
 	tests_utils "github.com/ydb-platform/fq-connector-go/tests/utils"
@@ -155,3 +156,193 @@ func TestYqlStringFilter(t *testing.T) {
 		}
 	}
 }
+
+func TestMakeSort(t *testing.T) {
+	sort, err := makeSort(&api_service_protos.TSelect_TOrderBy{
+		Keys: []*api_service_protos.TSelect_TOrderBy_TSortKey{
+			{Column: "ts", Direction: api_service_protos.TSelect_TOrderBy_DESC},
+			{Column: "id"},
+		},
+	})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, bson.D{{Key: "ts", Value: -1}, {Key: "id", Value: 1}}, sort)
+	}
+
+	_, err = makeSort(&api_service_protos.TSelect_TOrderBy{
+		Keys: []*api_service_protos.TSelect_TOrderBy_TSortKey{{Column: ""}},
+	})
+	assert.ErrorIs(t, err, common.ErrInvalidRequest)
+}
+
+func TestAggregationExpressionFilter(t *testing.T) {
+	logger := common.NewDefaultLogger()
+
+	column := func(name string) *api_service_protos.TExpression {
+		return &api_service_protos.TExpression{Payload: &api_service_protos.TExpression_Column{Column: name}}
+	}
+
+	testCases := map[*api_service_protos.TPredicate]bson.D{
+		{
+			// WHERE price * qty > 1000
+			Payload: &api_service_protos.TPredicate_Comparison{
+				Comparison: &api_service_protos.TPredicate_TComparison{
+					LeftValue: &api_service_protos.TExpression{
+						Payload: &api_service_protos.TExpression_ArithmeticalExpression{
+							ArithmeticalExpression: &api_service_protos.TExpression_TArithmeticalExpression{
+								Operation:  api_service_protos.TExpression_TArithmeticalExpression_MUL,
+								LeftValue:  column("price"),
+								RightValue: column("qty"),
+							},
+						},
+					},
+					Operation: api_service_protos.TPredicate_TComparison_G,
+					RightValue: &api_service_protos.TExpression{
+						Payload: &api_service_protos.TExpression_TypedValue{
+							TypedValue: common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_INT32), int32(1000)),
+						},
+					},
+				},
+			},
+		}: {{Key: "$expr",
+			Value: bson.D{{Key: "$or", Value: []bson.D{
+				{{Key: "$gt", Value: bson.A{bson.D{{Key: "$multiply", Value: bson.A{"$price", "$qty"}}}, int32(1000)}}},
+			}}},
+		}},
+		{
+			// WHERE a < b
+			Payload: &api_service_protos.TPredicate_Comparison{
+				Comparison: &api_service_protos.TPredicate_TComparison{
+					LeftValue:  column("a"),
+					Operation:  api_service_protos.TPredicate_TComparison_L,
+					RightValue: column("b"),
+				},
+			},
+		}: {{Key: "$expr",
+			Value: bson.D{{Key: "$or", Value: []bson.D{
+				{{Key: "$lt", Value: bson.A{"$a", "$b"}}},
+			}}},
+		}},
+		{
+			// WHERE IF(a IS NOT NULL, CAST(a AS String), NULL) REGEXP 'abc'
+			Payload: tests_utils.MakePredicateRegexpIfCastColumn("a", Ydb.Type_STRING, "abc"),
+		}: {{Key: "$expr", Value: bson.D{{Key: "$regexMatch", Value: bson.D{
+			{Key: "input", Value: bson.D{{Key: "$cond", Value: bson.A{
+				bson.D{{Key: "$gt", Value: bson.A{"$a", nil}}},
+				bson.D{{Key: "$convert", Value: bson.D{
+					{Key: "input", Value: "$a"},
+					{Key: "to", Value: "string"},
+					{Key: "onError", Value: nil},
+					{Key: "onNull", Value: nil},
+				}}},
+				nil,
+			}}}},
+			{Key: "regex", Value: "abc"},
+		}}}}},
+	}
+
+	for fromPredicate, toFilter := range testCases {
+		filter, err := makePredicateFilter(logger, fromPredicate, false)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, toFilter, filter)
+		}
+	}
+
+	// Integer division cannot be expressed with `$divide`
+	_, err := makePredicateFilter(logger, &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_BoolExpression{
+			BoolExpression: &api_service_protos.TPredicate_TBoolExpression{
+				Value: &api_service_protos.TExpression{
+					Payload: &api_service_protos.TExpression_ArithmeticalExpression{
+						ArithmeticalExpression: &api_service_protos.TExpression_TArithmeticalExpression{
+							Operation:  api_service_protos.TExpression_TArithmeticalExpression_DIV,
+							LeftValue:  column("a"),
+							RightValue: column("b"),
+						},
+					},
+				},
+			},
+		},
+	}, false)
+	assert.ErrorIs(t, err, common.ErrUnimplementedOperation)
+}
+
+func TestMakeFilterResidualPredicate(t *testing.T) {
+	logger := common.NewDefaultLogger()
+
+	supported := &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_IsNull{
+			IsNull: &api_service_protos.TPredicate_TIsNull{
+				Value: &api_service_protos.TExpression{Payload: &api_service_protos.TExpression_Column{Column: "a"}},
+			},
+		},
+	}
+
+	// predicate-level COALESCE is not pushed down into MongoDB
+	unsupported := &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_Coalesce{
+			Coalesce: &api_service_protos.TPredicate_TCoalesce{
+				Operands: []*api_service_protos.TPredicate{supported},
+			},
+		},
+	}
+
+	makeSplit := func(predicate *api_service_protos.TPredicate) *api_service_protos.TSplit {
+		return &api_service_protos.TSplit{
+			Select: &api_service_protos.TSelect{
+				Where: &api_service_protos.TSelect_TWhere{FilterTyped: predicate},
+				Limit: &api_service_protos.TSelect_TLimit{Limit: 10, Offset: 5},
+			},
+		}
+	}
+
+	conjunction := &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_Conjunction{
+			Conjunction: &api_service_protos.TPredicate_TConjunction{
+				Operands: []*api_service_protos.TPredicate{supported, unsupported},
+			},
+		},
+	}
+
+	t.Run("partially_pushed_down", func(t *testing.T) {
+		filter, opts, residualPredicate, err := makeFilter(
+			logger,
+			makeSplit(conjunction),
+			api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+			api_common.TMongoDbDataSourceOptions_JSON,
+		)
+		assert.NoError(t, err)
+
+		expected, err := makePredicateFilter(logger, supported, false)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, filter)
+		assert.Equal(t, unsupported, residualPredicate)
+		// the documents filtered out afterwards would make the result shorter than LIMIT
+		assert.Nil(t, opts.Limit)
+	})
+
+	t.Run("pushed_down", func(t *testing.T) {
+		_, opts, residualPredicate, err := makeFilter(
+			logger,
+			makeSplit(supported),
+			api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+			api_common.TMongoDbDataSourceOptions_JSON,
+		)
+		assert.NoError(t, err)
+		assert.Nil(t, residualPredicate)
+		// OFFSET is applied by the engine
+		assert.Equal(t, int64(15), *opts.Limit)
+		assert.Nil(t, opts.Skip)
+	})
+
+	t.Run("mandatory", func(t *testing.T) {
+		_, _, _, err := makeFilter(
+			logger,
+			makeSplit(conjunction),
+			api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY,
+			api_common.TMongoDbDataSourceOptions_JSON,
+		)
+		assert.ErrorIs(t, err, common.ErrUnimplementedPredicateType)
+	})
+}
@@ -0,0 +1,302 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+type schemaInferenceStrategy = api_common.TMongoDbDataSourceOptions_ESchemaInferenceStrategy
+
+// fetchSampleDocuments returns the documents that will be used to deduce the collection schema
+func fetchSampleDocuments(
+	ctx context.Context,
+	logger *zap.Logger,
+	collection *mongo.Collection,
+	strategy schemaInferenceStrategy,
+	count uint32,
+) ([]bson.Raw, error) {
+	var (
+		cursor *mongo.Cursor
+		err    error
+	)
+
+	switch strategy {
+	case api_common.TMongoDbDataSourceOptions_SCHEMA_INFERENCE_RANDOM_SAMPLE:
+		pipeline := mongo.Pipeline{{{Key: "$sample", Value: bson.D{{Key: "size", Value: count}}}}}
+
+		cursor, err = collection.Aggregate(ctx, pipeline)
+		if err != nil {
+			return nil, fmt.Errorf("aggregate in collection: %w", err)
+		}
+	case api_common.TMongoDbDataSourceOptions_SCHEMA_INFERENCE_UNSPECIFIED,
+		api_common.TMongoDbDataSourceOptions_SCHEMA_INFERENCE_FIRST_DOCUMENTS,
+		api_common.TMongoDbDataSourceOptions_SCHEMA_INFERENCE_JSON_SCHEMA:
+		cursor, err = collection.Find(ctx, bson.D{}, options.Find().SetLimit(int64(count)))
+		if err != nil {
+			return nil, fmt.Errorf("find in collection: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported schema inference strategy: %s", strategy.String())
+	}
+
+	defer func() {
+		if err = cursor.Close(ctx); err != nil {
+			logger.Error(fmt.Sprintf("cursor close: %v", err))
+		}
+	}()
+
+	docs := make([]bson.Raw, 0, count)
+
+	for cursor.Next(ctx) {
+		docs = append(docs, cursor.Current)
+	}
+
+	if err = cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor: %w", err)
+	}
+
+	return docs, nil
+}
+
+// getJSONSchema extracts `$jsonSchema` validator from the collection options.
+// Returns nil if the collection has no such validator.
+func getJSONSchema(ctx context.Context, database *mongo.Database, collectionName string) (bson.Raw, error) {
+	specs, err := database.ListCollectionSpecifications(ctx, bson.D{{Key: "name", Value: collectionName}})
+	if err != nil {
+		return nil, fmt.Errorf("list collection specifications: %w", err)
+	}
+
+	if len(specs) == 0 || specs[0].Options == nil {
+		return nil, nil
+	}
+
+	validator, ok := specs[0].Options.Lookup("validator").DocumentOK()
+	if !ok {
+		return nil, nil
+	}
+
+	jsonSchema, ok := validator.Lookup("$jsonSchema").DocumentOK()
+	if !ok {
+		return nil, nil
+	}
+
+	return jsonSchema, nil
+}
+
+// jsonSchemaToYql converts the top-level properties of `$jsonSchema` validator into the table columns
+func jsonSchemaToYql(
+	logger *zap.Logger,
+	jsonSchema bson.Raw,
+	omitUnsupported bool,
+	objectIdType *Ydb.Type,
+) ([]*Ydb.Column, error) {
+	properties, ok := jsonSchema.Lookup("properties").DocumentOK()
+	if !ok {
+		return nil, errors.New("`properties` are missing in $jsonSchema")
+	}
+
+	elements, err := properties.Elements()
+	if err != nil {
+		return nil, fmt.Errorf("properties elements: %w", err)
+	}
+
+	columns := make([]*Ydb.Column, 0, len(elements)+1)
+
+	// validators rarely describe the primary key, so it's assumed to be ObjectId by default
+	if _, err := properties.LookupErr(idColumn); err != nil {
+		columns = append(columns, &Ydb.Column{Name: idColumn, Type: common.MakeOptionalType(objectIdType)})
+	}
+
+	for _, elem := range elements {
+		property, ok := elem.Value().DocumentOK()
+		if !ok {
+			return nil, fmt.Errorf("invalid $jsonSchema property '%s'", elem.Key())
+		}
+
+		ydbType, err := jsonSchemaPropertyToYql(property, objectIdType)
+		if err != nil {
+			if !errors.Is(err, common.ErrDataTypeNotSupported) {
+				return nil, fmt.Errorf("property '%s': %w", elem.Key(), err)
+			}
+
+			logger.Debug(fmt.Sprintf("jsonSchemaToYql: data not supported: %v", elem.Key()))
+
+			if omitUnsupported {
+				continue
+			}
+
+			ydbType = common.MakePrimitiveType(Ydb.Type_UTF8)
+		}
+
+		columns = append(columns, &Ydb.Column{Name: elem.Key(), Type: common.MakeOptionalType(ydbType)})
+	}
+
+	return columns, nil
+}
+
+func jsonSchemaPropertyToYql(property bson.Raw, objectIdType *Ydb.Type) (*Ydb.Type, error) {
+	var bsonTypes []string
+
+	switch value := property.Lookup("bsonType"); value.Type {
+	case bson.TypeString:
+		bsonTypes = append(bsonTypes, value.StringValue())
+	case bson.TypeArray:
+		values, err := value.Array().Values()
+		if err != nil {
+			return nil, fmt.Errorf("bsonType values: %w", err)
+		}
+
+		for _, v := range values {
+			if s, ok := v.StringValueOK(); ok {
+				bsonTypes = append(bsonTypes, s)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("missing bsonType: %w", common.ErrDataTypeNotSupported)
+	}
+
+	var result *Ydb.Type
+
+	for _, bsonType := range bsonTypes {
+		// all the columns are nullable anyway
+		if bsonType == "null" {
+			continue
+		}
+
+		ydbType, err := bsonTypeNameToYql(bsonType, objectIdType)
+		if err != nil {
+			return nil, err
+		}
+
+		if result == nil {
+			result = ydbType
+
+			continue
+		}
+
+		widened, ok := widenTypes(result, ydbType)
+		if !ok {
+			return nil, fmt.Errorf("bsonTypes %v have no common type: %w", bsonTypes, common.ErrDataTypeNotSupported)
+		}
+
+		result = widened
+	}
+
+	if result == nil {
+		return nil, fmt.Errorf("no bsonType except null: %w", common.ErrDataTypeNotSupported)
+	}
+
+	return result, nil
+}
+
+// bsonTypeNameToYql maps BSON type aliases used in `$jsonSchema` to YDB types in the same way as typeMap does
+func bsonTypeNameToYql(bsonType string, objectIdType *Ydb.Type) (*Ydb.Type, error) {
+	switch bsonType {
+	case "int":
+		return common.MakePrimitiveType(Ydb.Type_INT32), nil
+	case "long":
+		return common.MakePrimitiveType(Ydb.Type_INT64), nil
+	case "bool":
+		return common.MakePrimitiveType(Ydb.Type_BOOL), nil
+	case "double", "number":
+		return common.MakePrimitiveType(Ydb.Type_DOUBLE), nil
+	case "string":
+		return common.MakePrimitiveType(Ydb.Type_UTF8), nil
+	case "binData":
+		return common.MakePrimitiveType(Ydb.Type_STRING), nil
+	case "objectId":
+		return objectIdType, nil
+	default:
+		return nil, fmt.Errorf("bsonType '%s': %w", bsonType, common.ErrDataTypeNotSupported)
+	}
+}
+
+// applySchemaOverride replaces the types of deduced columns with the ones provided by user
+// and appends the columns that were not deduced at all.
+func applySchemaOverride(
+	columns []*Ydb.Column,
+	override map[string]string,
+	objectIdType *Ydb.Type,
+) ([]*Ydb.Column, error) {
+	if len(override) == 0 {
+		return columns, nil
+	}
+
+	fieldNames := make([]string, 0, len(override))
+	for fieldName := range override {
+		fieldNames = append(fieldNames, fieldName)
+	}
+
+	sort.Strings(fieldNames)
+
+	for _, fieldName := range fieldNames {
+		ydbType, err := parseOverrideType(override[fieldName], objectIdType)
+		if err != nil {
+			return nil, fmt.Errorf("override type of field '%s': %w", fieldName, err)
+		}
+
+		ydbType = common.MakeOptionalType(ydbType)
+
+		found := false
+
+		for _, column := range columns {
+			if column.Name == fieldName {
+				column.Type = ydbType
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			columns = append(columns, &Ydb.Column{Name: fieldName, Type: ydbType})
+		}
+	}
+
+	return columns, nil
+}
+
+// parseOverrideType parses the names of the types supported by the document reader.
+// Optional types can be specified both as `Optional<T>` and `T?`.
+func parseOverrideType(typeName string, objectIdType *Ydb.Type) (*Ydb.Type, error) {
+	typeName = strings.TrimSpace(typeName)
+
+	switch {
+	case strings.HasPrefix(typeName, "Optional<") && strings.HasSuffix(typeName, ">"):
+		return parseOverrideType(typeName[len("Optional<"):len(typeName)-1], objectIdType)
+	case strings.HasSuffix(typeName, "?"):
+		return parseOverrideType(typeName[:len(typeName)-1], objectIdType)
+	}
+
+	switch typeName {
+	case "Bool":
+		return common.MakePrimitiveType(Ydb.Type_BOOL), nil
+	case "Int32":
+		return common.MakePrimitiveType(Ydb.Type_INT32), nil
+	case "Int64":
+		return common.MakePrimitiveType(Ydb.Type_INT64), nil
+	case "Double":
+		return common.MakePrimitiveType(Ydb.Type_DOUBLE), nil
+	case "Utf8":
+		return common.MakePrimitiveType(Ydb.Type_UTF8), nil
+	case "String":
+		return common.MakePrimitiveType(Ydb.Type_STRING), nil
+	case objectIdTag:
+		return objectIdType, nil
+	default:
+		return nil, fmt.Errorf("type '%s': %w", typeName, common.ErrDataTypeNotSupported)
+	}
+}
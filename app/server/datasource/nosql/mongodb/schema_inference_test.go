@@ -0,0 +1,167 @@
+package mongodb
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func mustMarshalDocs(t *testing.T, docs ...bson.D) []bson.Raw {
+	result := make([]bson.Raw, 0, len(docs))
+
+	for _, doc := range docs {
+		raw, err := bson.Marshal(doc)
+		require.NoError(t, err)
+
+		result = append(result, raw)
+	}
+
+	return result
+}
+
+func columnsToMap(columns []*Ydb.Column) map[string]string {
+	result := make(map[string]string, len(columns))
+
+	for _, column := range columns {
+		result[column.Name] = column.Type.String()
+	}
+
+	return result
+}
+
+func optionalPrimitive(typeID Ydb.Type_PrimitiveTypeId) string {
+	return common.MakeOptionalType(common.MakePrimitiveType(typeID)).String()
+}
+
+func TestBsonToYqlWidening(t *testing.T) {
+	logger := common.NewDefaultLogger()
+
+	docs := mustMarshalDocs(t,
+		bson.D{
+			{Key: "a", Value: int32(1)},
+			{Key: "b", Value: int32(1)},
+			{Key: "c", Value: "x"},
+			{Key: "d", Value: primitive.NewObjectID()},
+		},
+		bson.D{
+			{Key: "a", Value: int64(2)},
+			{Key: "b", Value: 1.5},
+			{Key: "c", Value: int32(1)},
+			{Key: "d", Value: primitive.Binary{Data: []byte{0xab}}},
+		},
+	)
+
+	columns, err := bsonToYql(logger, docs, false, false, objectIdTaggedType)
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]string{
+		"a": optionalPrimitive(Ydb.Type_INT64),
+		"b": optionalPrimitive(Ydb.Type_DOUBLE),
+		"c": optionalPrimitive(Ydb.Type_UTF8),
+		"d": optionalPrimitive(Ydb.Type_STRING),
+	}, columnsToMap(columns))
+}
+
+func TestWidenTypes(t *testing.T) {
+	type testCase struct {
+		name     string
+		lhs      *Ydb.Type
+		rhs      *Ydb.Type
+		expected *Ydb.Type // nil if types can't be widened
+	}
+
+	primitive := common.MakePrimitiveType
+
+	tcs := []testCase{
+		{name: "int32_int64", lhs: primitive(Ydb.Type_INT32), rhs: primitive(Ydb.Type_INT64), expected: primitive(Ydb.Type_INT64)},
+		{name: "double_int32", lhs: primitive(Ydb.Type_DOUBLE), rhs: primitive(Ydb.Type_INT32), expected: primitive(Ydb.Type_DOUBLE)},
+		{name: "object_id_binary", lhs: objectIdTaggedType, rhs: primitive(Ydb.Type_STRING), expected: primitive(Ydb.Type_STRING)},
+		{name: "object_id_string", lhs: objectIdTaggedType, rhs: primitive(Ydb.Type_UTF8), expected: primitive(Ydb.Type_UTF8)},
+		{name: "binary_string", lhs: primitive(Ydb.Type_STRING), rhs: primitive(Ydb.Type_UTF8), expected: primitive(Ydb.Type_UTF8)},
+		{name: "bool_int32", lhs: primitive(Ydb.Type_BOOL), rhs: primitive(Ydb.Type_INT32), expected: primitive(Ydb.Type_UTF8)},
+		{name: "double_object_id", lhs: primitive(Ydb.Type_DOUBLE), rhs: objectIdTaggedType, expected: primitive(Ydb.Type_UTF8)},
+		{name: "int32_list", lhs: primitive(Ydb.Type_INT32), rhs: common.MakeListType(primitive(Ydb.Type_INT32))},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			for _, args := range [][2]*Ydb.Type{{tc.lhs, tc.rhs}, {tc.rhs, tc.lhs}} {
+				actual, ok := widenTypes(args[0], args[1])
+				if tc.expected == nil {
+					require.False(t, ok)
+
+					continue
+				}
+
+				require.True(t, ok)
+				require.Equal(t, tc.expected.String(), actual.String())
+			}
+		})
+	}
+}
+
+func TestJSONSchemaToYql(t *testing.T) {
+	logger := common.NewDefaultLogger()
+
+	jsonSchema, err := bson.Marshal(bson.D{
+		{Key: "bsonType", Value: "object"},
+		{Key: "properties", Value: bson.D{
+			{Key: "name", Value: bson.D{{Key: "bsonType", Value: "string"}}},
+			{Key: "age", Value: bson.D{{Key: "bsonType", Value: bson.A{"int", "long", "null"}}}},
+			{Key: "tags", Value: bson.D{{Key: "bsonType", Value: "array"}}},
+			{Key: "mixed", Value: bson.D{{Key: "bsonType", Value: bson.A{"int", "string"}}}},
+			{Key: "ref", Value: bson.D{{Key: "bsonType", Value: bson.A{"objectId", "binData"}}}},
+		}},
+	})
+	require.NoError(t, err)
+
+	columns, err := jsonSchemaToYql(logger, jsonSchema, true, objectIdTaggedType)
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]string{
+		"_id":   common.MakeOptionalType(objectIdTaggedType).String(),
+		"name":  optionalPrimitive(Ydb.Type_UTF8),
+		"age":   optionalPrimitive(Ydb.Type_INT64),
+		"mixed": optionalPrimitive(Ydb.Type_UTF8),
+		"ref":   optionalPrimitive(Ydb.Type_STRING),
+	}, columnsToMap(columns))
+}
+
+func TestApplySchemaOverride(t *testing.T) {
+	columns := []*Ydb.Column{
+		{Name: "_id", Type: common.MakeOptionalType(objectIdTaggedType)},
+		{Name: "a", Type: common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8))},
+	}
+
+	columns, err := applySchemaOverride(columns, map[string]string{
+		"a": "Optional<Int64>",
+		"b": "Double?",
+		"c": "ObjectId",
+	}, objectIdTaggedType)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(columns))
+	for _, column := range columns {
+		names = append(names, column.Name)
+	}
+
+	require.True(t, sort.StringsAreSorted(names))
+	require.Equal(t, map[string]string{
+		"_id": common.MakeOptionalType(objectIdTaggedType).String(),
+		"a":   optionalPrimitive(Ydb.Type_INT64),
+		"b":   optionalPrimitive(Ydb.Type_DOUBLE),
+		"c":   common.MakeOptionalType(objectIdTaggedType).String(),
+	}, columnsToMap(columns))
+
+	_, err = applySchemaOverride(columns, map[string]string{"d": "List<Int32>"}, objectIdTaggedType)
+	require.ErrorIs(t, err, common.ErrDataTypeNotSupported)
+}
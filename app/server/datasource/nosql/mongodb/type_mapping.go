@@ -107,6 +107,17 @@ func bsonToYqlColumn(
 	tString := t.String()
 	_, prevIsArray := ambiguousArrayFields[key]
 
+	// Fields having values of different types are widened to the common type (see widenTypes).
+	if prevTypeExists && !common.TypesEqual(prevType, t) {
+		if widened, ok := widenTypes(prevType, t); ok {
+			deducedTypes[key] = widened
+
+			logger.Debug(fmt.Sprintf("bsonToYqlColumn: widening %v. prev: %v curr: %v", key, prevType.String(), tString))
+
+			return nil
+		}
+	}
+
 	// Leaving fields that have inconsistent types serialized
 	// Extra check for arrays because we might have encountered an empty one:
 	// we know it is an array, but prevType is not determined yet
@@ -125,6 +136,65 @@ func bsonToYqlColumn(
 	return nil
 }
 
+// widenTypes returns the narrowest type that is able to represent the values of both types:
+//   - numeric types are widened to the widest of them (Int32 < Int64 < Double);
+//   - ObjectId and binary values are kept as bytes in String;
+//   - any other combination of the supported scalar types is widened to Utf8,
+//     since all of them have a text representation (see bsonToString), e.g. Int32 and Bool values become "1" and "true".
+//
+// False is returned for the types having no common representation.
+func widenTypes(lhs, rhs *Ydb.Type) (*Ydb.Type, bool) {
+	if common.TypesEqual(lhs, rhs) {
+		return lhs, true
+	}
+
+	rank := func(t *Ydb.Type) int {
+		switch t.GetTypeId() {
+		case Ydb.Type_INT32:
+			return 1
+		case Ydb.Type_INT64:
+			return 2
+		case Ydb.Type_DOUBLE:
+			return 3
+		default:
+			return 0
+		}
+	}
+
+	if lhsRank, rhsRank := rank(lhs), rank(rhs); lhsRank > 0 && rhsRank > 0 {
+		if lhsRank > rhsRank {
+			return lhs, true
+		}
+
+		return rhs, true
+	}
+
+	if isBytesType(lhs) && isBytesType(rhs) {
+		return common.MakePrimitiveType(Ydb.Type_STRING), true
+	}
+
+	if isScalarType(lhs) && isScalarType(rhs) {
+		return common.MakePrimitiveType(Ydb.Type_UTF8), true
+	}
+
+	return nil, false
+}
+
+// isBytesType checks if the type is the one that binary data and ObjectId are mapped to
+func isBytesType(t *Ydb.Type) bool {
+	return t.GetTypeId() == Ydb.Type_STRING || t.GetTaggedType().GetTag() == objectIdTag
+}
+
+// isScalarType checks if the type is the one that typeMap maps scalar BSON values to
+func isScalarType(t *Ydb.Type) bool {
+	switch t.GetTypeId() {
+	case Ydb.Type_INT32, Ydb.Type_INT64, Ydb.Type_DOUBLE, Ydb.Type_BOOL, Ydb.Type_UTF8, Ydb.Type_STRING:
+		return true
+	default:
+		return isBytesType(t)
+	}
+}
+
 func bsonToYql(logger *zap.Logger, docs []bson.Raw, omitUnsupported, typeMapIdOnly bool, objectIdType *Ydb.Type) ([]*Ydb.Column, error) {
 	if len(docs) == 0 {
 		return []*Ydb.Column{}, nil
@@ -1,6 +1,7 @@
 package opensearch
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/json"
@@ -20,6 +21,7 @@ import (
 	"github.com/ydb-platform/fq-connector-go/app/config"
 	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils/retry"
 	"github.com/ydb-platform/fq-connector-go/common"
@@ -31,14 +33,15 @@ type dataSource struct {
 	retrierSet   *retry.RetrierSet
 	cc           conversion.Collection
 	cfg          *config.TOpenSearchConfig
+	mappingCache *MappingCache
 	logger       *zap.Logger
 	queryLogger  common.QueryLogger
-	queryBuilder *queryBuilder
 }
 
 func NewDataSource(
 	retrierSet *retry.RetrierSet,
 	cfg *config.TOpenSearchConfig,
+	mappingCache *MappingCache,
 	logger *zap.Logger,
 	cc conversion.Collection,
 	queryLogger common.QueryLogger,
@@ -47,9 +50,9 @@ func NewDataSource(
 		retrierSet:   retrierSet,
 		cc:           cc,
 		cfg:          cfg,
+		mappingCache: mappingCache,
 		logger:       logger,
 		queryLogger:  queryLogger,
-		queryBuilder: newQueryBuilder(logger),
 	}
 }
 
@@ -81,10 +84,56 @@ func (ds *dataSource) DescribeTable(
 
 	indexName := request.Table
 
-	res, err := client.Indices.Mapping.Get(
-		ctx,
-		&opensearchapi.MappingGetReq{Indices: []string{indexName}},
-	)
+	mapping, err := ds.getMapping(ctx, logger, client, indexName)
+	if err != nil {
+		return nil, fmt.Errorf("get mapping: %w", err)
+	}
+
+	// the mapping will be required to build the queries reading the index
+	ds.mappingCache.put(dsi, indexName, newFieldIndex(mapping))
+
+	var arrayFields []string
+
+	if ds.cfg.SchemaSamplingSize > 0 {
+		documents, err := ds.sampleDocuments(ctx, logger, client, indexName, ds.cfg.SchemaSamplingSize)
+		if err != nil {
+			return nil, fmt.Errorf("sample documents: %w", err)
+		}
+
+		arrayFields = detectArrayFields(documents)
+
+		logger.Debug("array fields detected", zap.Strings("fields", arrayFields))
+	}
+
+	columns, err := parseMapping(logger, mapping, arrayFields)
+	if err != nil {
+		return nil, fmt.Errorf("parse mapping: %w", err)
+	}
+
+	return &api_service_protos.TDescribeTableResponse{
+		Schema:       &api_service_protos.TSchema{Columns: columns},
+		Capabilities: makeCapabilities(),
+	}, nil
+}
+
+func (ds *dataSource) getMapping(
+	ctx context.Context,
+	logger *zap.Logger,
+	client *opensearchapi.Client,
+	indexName string,
+) (map[string]any, error) {
+	var res *opensearchapi.MappingGetResp
+
+	err := ds.retrierSet.Query.Run(ctx, logger, func() error {
+		var err error
+
+		res, err = client.Indices.Mapping.Get(
+			ctx,
+			&opensearchapi.MappingGetReq{Indices: []string{indexName}},
+		)
+
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("get mapping: %w", err)
 	}
@@ -103,19 +152,72 @@ func (ds *dataSource) DescribeTable(
 		return nil, fmt.Errorf("decode response body: %w", err)
 	}
 
-	mapping, ok := result[indexName].(map[string]any)["mappings"].(map[string]any)
+	indexMapping, ok := result[indexName].(map[string]any)
+	if !ok {
+		return nil, errors.New("extract index mapping: invalid response format")
+	}
+
+	mapping, ok := indexMapping["mappings"].(map[string]any)
 	if !ok {
 		return nil, errors.New("extract mappings: invalid response format")
 	}
 
-	columns, err := parseMapping(logger, mapping)
+	return mapping, nil
+}
+
+// sampleDocuments fetches a few documents from the index in order to
+// discover the properties of data that are not reflected in the mapping (e.g. arrays).
+func (ds *dataSource) sampleDocuments(
+	ctx context.Context,
+	logger *zap.Logger,
+	client *opensearchapi.Client,
+	indexName string,
+	sampleSize uint64,
+) ([]map[string]any, error) {
+	var buf bytes.Buffer
+
+	query := map[string]any{
+		"size": sampleSize,
+		"query": map[string]any{
+			"match_all": make(map[string]any),
+		},
+	}
+
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return nil, fmt.Errorf("encode query: %w", err)
+	}
+
+	var resp *opensearchapi.SearchResp
+
+	err := ds.retrierSet.Query.Run(ctx, logger, func() error {
+		var err error
+
+		resp, err = client.Search(ctx, &opensearchapi.SearchReq{
+			Indices: []string{indexName},
+			Body:    bytes.NewReader(buf.Bytes()),
+		})
+
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("parse mapping: %w", err)
+		return nil, fmt.Errorf("search: %w", err)
 	}
 
-	return &api_service_protos.TDescribeTableResponse{
-		Schema: &api_service_protos.TSchema{Columns: columns},
-	}, nil
+	closeResponseBody(logger, resp.Inspect().Response.Body)
+
+	documents := make([]map[string]any, 0, len(resp.Hits.Hits))
+
+	for _, hit := range resp.Hits.Hits {
+		var document map[string]any
+
+		if err := json.Unmarshal(hit.Source, &document); err != nil {
+			return nil, fmt.Errorf("unmarshal _source: %w", err)
+		}
+
+		documents = append(documents, document)
+	}
+
+	return documents, nil
 }
 
 func (*dataSource) ListSplits(
@@ -169,14 +271,44 @@ func (ds *dataSource) ReadSplit(
 
 	ds.queryLogger.Dump(split.Select.From.Table, split.Select.What.String())
 
-	sinks, err := sinkFactory.MakeSinks([]*paging.SinkParams{{Logger: logger}})
+	// Mapping is required to read multi-fields and to push down the predicates on nested fields and multi-fields properly.
+	// Usually it's obtained during the DescribeTable request.
+	fields, found := ds.mappingCache.get(dsi, split.Select.From.Table)
+	if !found {
+		mapping, err := ds.getMapping(ctx, logger, client, split.Select.From.Table)
+		if err != nil {
+			return fmt.Errorf("get mapping: %w", err)
+		}
+
+		fields = newFieldIndex(mapping)
+		ds.mappingCache.put(dsi, split.Select.From.Table, fields)
+	}
+
+	body, params, residualPredicate, err := newQueryBuilder(logger, fields).buildSearchQuery(
+		split,
+		request.GetFiltering(),
+		ds.cfg.BatchSize,
+		common.MustDurationFromString(ds.cfg.ScrollTimeout),
+	)
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	residualFilter, err := filtering.NewResidualFilter(logger, residualPredicate, split.Select.What)
+	if err != nil {
+		return fmt.Errorf("new residual filter: %w", err)
+	}
+
+	sinks, err := sinkFactory.MakeSinks([]*paging.SinkParams{{Logger: logger, ResidualFilter: residualFilter}})
 	if err != nil {
 		return fmt.Errorf("make sinks: %w", err)
 	}
 
 	sink := sinks[0]
 
-	if err := ds.doReadSplitSingleConn(ctx, logger, request, split, sink, client); err != nil {
+	rowsLimit := makeRowsLimit(split.Select.GetLimit(), residualPredicate)
+
+	if err := ds.doReadSplitSingleConn(ctx, logger, split, sink, client, body, params, rowsLimit); err != nil {
 		return fmt.Errorf("read split single conn: %w", err)
 	}
 
@@ -188,20 +320,14 @@ func (ds *dataSource) ReadSplit(
 func (ds *dataSource) doReadSplitSingleConn(
 	ctx context.Context,
 	logger *zap.Logger,
-	request *api_service_protos.TReadSplitsRequest,
 	split *api_service_protos.TSplit,
 	sink paging.Sink[any],
 	client *opensearchapi.Client,
+	body io.Reader,
+	params *opensearchapi.SearchParams,
+	rowsLimit uint64, // 0 means no limit
 ) error {
-	searchResp, err := ds.initialSearch(
-		ctx,
-		logger,
-		client,
-		request,
-		split,
-		ds.cfg.BatchSize,
-		common.MustDurationFromString(ds.cfg.ScrollTimeout),
-	)
+	searchResp, err := ds.initialSearch(ctx, logger, client, split, body, params)
 	if err != nil {
 		return fmt.Errorf("initial search: %w", err)
 	}
@@ -217,6 +343,7 @@ func (ds *dataSource) doReadSplitSingleConn(
 
 	scrollId := searchResp.ScrollID
 	hits := searchResp.Hits
+	rowsRead := uint64(0)
 
 	for {
 		if len(hits.Hits) == 0 {
@@ -225,7 +352,12 @@ func (ds *dataSource) doReadSplitSingleConn(
 			break
 		}
 
-		if err := processHitsBatch(logger, hits.Hits, reader, sink); err != nil {
+		batch := hits.Hits
+		if rowsLimit > 0 && rowsRead+uint64(len(batch)) > rowsLimit {
+			batch = batch[:rowsLimit-rowsRead]
+		}
+
+		if err := processHitsBatch(logger, batch, reader, sink); err != nil {
 			if clearErr := clearScroll(ctx, client, *scrollId); clearErr != nil {
 				return fmt.Errorf("clear scroll: %w", clearErr)
 			}
@@ -233,6 +365,13 @@ func (ds *dataSource) doReadSplitSingleConn(
 			return fmt.Errorf("process hit: %w", err)
 		}
 
+		rowsRead += uint64(len(batch))
+		if rowsLimit > 0 && rowsRead >= rowsLimit {
+			logger.Debug("limit reached", zap.Uint64("rows_read", rowsRead))
+
+			break
+		}
+
 		nextResp, err := ds.getNextScrollBatch(ctx, logger, client, *scrollId, common.MustDurationFromString(ds.cfg.ScrollTimeout))
 		if err != nil {
 			if clearErr := clearScroll(ctx, client, *scrollId); clearErr != nil {
@@ -258,21 +397,10 @@ func (ds *dataSource) initialSearch(
 	ctx context.Context,
 	logger *zap.Logger,
 	client *opensearchapi.Client,
-	request *api_service_protos.TReadSplitsRequest,
 	split *api_service_protos.TSplit,
-	batchSize uint64,
-	scrollTimeout time.Duration,
+	body io.Reader,
+	params *opensearchapi.SearchParams,
 ) (*opensearchapi.SearchResp, error) {
-	body, params, err := ds.queryBuilder.buildSearchQuery(
-		split,
-		request.GetFiltering(),
-		batchSize,
-		scrollTimeout,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("build query: %w", err)
-	}
-
 	req := &opensearchapi.SearchReq{
 		Indices: []string{split.Select.From.Table},
 		Body:    body,
@@ -284,7 +412,7 @@ func (ds *dataSource) initialSearch(
 		searchErr error
 	)
 
-	err = ds.retrierSet.Query.Run(
+	err := ds.retrierSet.Query.Run(
 		ctx,
 		logger,
 		func() error {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/apache/arrow/go/v13/arrow"
@@ -80,7 +81,7 @@ func (r *documentReader) accept(
 	for i, f := range r.arrowTypes.Fields() {
 		switch a := acceptors[i].(type) {
 		case **uint8:
-			value, ok := doc[f.Name]
+			value, ok := lookupField(doc, f.Name)
 			if !ok {
 				*a = nil
 
@@ -91,7 +92,7 @@ func (r *documentReader) accept(
 				return fmt.Errorf("convert: %w", err)
 			}
 		case **bool:
-			value, ok := doc[f.Name]
+			value, ok := lookupField(doc, f.Name)
 			if !ok || value == nil {
 				*a = nil
 
@@ -102,7 +103,7 @@ func (r *documentReader) accept(
 				return fmt.Errorf("convert: %w", err)
 			}
 		case **int32:
-			value, ok := doc[f.Name]
+			value, ok := lookupField(doc, f.Name)
 			if !ok || value == nil {
 				*a = nil
 
@@ -113,7 +114,7 @@ func (r *documentReader) accept(
 				return fmt.Errorf("convert: %w", err)
 			}
 		case **int64:
-			value, ok := doc[f.Name]
+			value, ok := lookupField(doc, f.Name)
 			if !ok || value == nil {
 				*a = nil
 
@@ -124,7 +125,7 @@ func (r *documentReader) accept(
 				return fmt.Errorf("convert: %w", err)
 			}
 		case **float32:
-			value, ok := doc[f.Name]
+			value, ok := lookupField(doc, f.Name)
 			if !ok {
 				*a = nil
 
@@ -135,7 +136,7 @@ func (r *documentReader) accept(
 				return fmt.Errorf("convert: %w", err)
 			}
 		case **float64:
-			value, ok := doc[f.Name]
+			value, ok := lookupField(doc, f.Name)
 			if !ok || value == nil {
 				*a = nil
 
@@ -152,7 +153,7 @@ func (r *documentReader) accept(
 
 			*a = hit.ID
 		case **string:
-			value, ok := doc[f.Name]
+			value, ok := lookupField(doc, f.Name)
 			if !ok || value == nil {
 				*a = nil
 
@@ -168,7 +169,7 @@ func (r *documentReader) accept(
 
 			*a = ptr.T(str)
 		case **time.Time:
-			value, ok := doc[f.Name]
+			value, ok := lookupField(doc, f.Name)
 			if !ok || value == nil {
 				*a = nil
 
@@ -181,20 +182,9 @@ func (r *documentReader) accept(
 			}
 
 			*a = ptr.T(t)
-		case **map[string]any:
-			value, ok := doc[f.Name]
-			if !ok || value == nil {
-				*a = nil
-
-				continue
-			}
-
-			convertedMap, err := convertToMapStringAny(value, f.Name)
-			if err != nil {
-				return fmt.Errorf("failed to convert map for field '%s': %w", f.Name, err)
-			}
-
-			*a = convertedMap
+		case *any:
+			// containers (objects and arrays) are converted by the appender
+			*a, _ = lookupField(doc, f.Name)
 		default:
 			return fmt.Errorf("unsupported type %T: %w for field %T", acceptors[i], common.ErrDataTypeNotSupported, f.Name)
 		}
@@ -203,19 +193,29 @@ func (r *documentReader) accept(
 	return nil
 }
 
-func convertToMapStringAny(value any, fieldName string) (*map[string]any, error) {
-	inputMap, ok := value.(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("expected map[string]any for field %s, got %T", fieldName, value)
+// lookupField returns the value of the field from the document object.
+// Multi-fields (e.g. `title.keyword`) are absent in the documents, so their values are taken from the parent fields.
+func lookupField(object map[string]any, name string) (any, bool) {
+	if value, ok := object[name]; ok {
+		return value, true
 	}
 
-	resultMap := make(map[string]any, len(inputMap))
+	dot := strings.LastIndex(name, ".")
+	if dot < 0 {
+		return nil, false
+	}
 
-	for key, val := range inputMap {
-		resultMap[key] = val
+	value, ok := object[name[:dot]]
+	if !ok {
+		return nil, false
 	}
 
-	return &resultMap, nil
+	// objects have subfields rather than multi-fields
+	if _, isObject := value.(map[string]any); isObject {
+		return nil, false
+	}
+
+	return value, true
 }
 
 func parseTime(value any) (time.Time, error) {
@@ -415,9 +415,9 @@ func addAcceptorAppenderNullable(
 		default:
 			return nil, nil, fmt.Errorf("unsupported: %v", ydbType.String())
 		}
-	case *Ydb.Type_StructType:
-		acceptors = append(acceptors, new(*map[string]any))
-		appenders = append(appenders, createStructAppender(t.StructType))
+	case *Ydb.Type_StructType, *Ydb.Type_ListType:
+		acceptors = append(acceptors, new(any))
+		appenders = append(appenders, makeContainerAppender(ydbType))
 	default:
 		return nil, nil, fmt.Errorf("unsupported: %v", ydbType.String())
 	}
@@ -425,120 +425,156 @@ func addAcceptorAppenderNullable(
 	return acceptors, appenders, nil
 }
 
-//nolint:gocyclo
-func createStructAppender(structType *Ydb.StructType) func(any, array.Builder) error {
-	fieldNames := make([]string, len(structType.Members))
-	for i, member := range structType.Members {
-		fieldNames[i] = member.Name
+// makeContainerAppender makes appender for the values of object and nested fields
+// (as well as arrays) that were decoded from JSON into the values of `map[string]any` and `[]any` types.
+func makeContainerAppender(ydbType *Ydb.Type) func(any, array.Builder) error {
+	return func(acceptor any, builder array.Builder) error {
+		pt, ok := acceptor.(*any)
+		if !ok {
+			return fmt.Errorf("invalid acceptor type: expected *any, got %T", acceptor)
+		}
+
+		return appendJSONValue(ydbType, *pt, builder)
 	}
+}
 
-	return func(acceptor any, builder array.Builder) error {
-		pt, ok := acceptor.(**map[string]any)
+func appendJSONValue(ydbType *Ydb.Type, value any, builder array.Builder) error {
+	if value == nil {
+		builder.AppendNull()
+
+		return nil
+	}
+
+	switch t := ydbType.Type.(type) {
+	case *Ydb.Type_OptionalType:
+		return appendJSONValue(t.OptionalType.Item, value, builder)
+	case *Ydb.Type_ListType:
+		listBuilder, ok := builder.(*array.ListBuilder)
 		if !ok {
-			return fmt.Errorf("invalid acceptor type: expected **map[string]any, got %T", acceptor)
+			return fmt.Errorf("invalid builder type: expected *array.ListBuilder, got %T", builder)
 		}
 
-		structBuilder, ok := builder.(*array.StructBuilder)
+		// OpenSearch doesn't distinguish a single value and an array consisting of one value
+		items, ok := value.([]any)
 		if !ok {
-			return fmt.Errorf("invalid builder type: expected *array.StructBuilder, got %T", builder)
+			items = []any{value}
 		}
 
-		if *pt == nil {
-			structBuilder.AppendNull()
+		listBuilder.Append(true)
 
-			return nil
+		for i, item := range items {
+			if err := appendJSONValue(t.ListType.Item, item, listBuilder.ValueBuilder()); err != nil {
+				return fmt.Errorf("list item #%d: %w", i, err)
+			}
 		}
 
-		structBuilder.Append(true) // Начинаем новую структуру
+		return nil
+	case *Ydb.Type_StructType:
+		structBuilder, ok := builder.(*array.StructBuilder)
+		if !ok {
+			return fmt.Errorf("invalid builder type: expected *array.StructBuilder, got %T", builder)
+		}
 
-		data := *pt
+		data, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("expected object, got %T", value)
+		}
 
-		// Для каждого поля в структуре добавляем значение
-		for fieldIdx := 0; fieldIdx < structBuilder.NumField(); fieldIdx++ {
-			fieldName := builder.Type().(*arrow.StructType).Field(fieldIdx).Name
-			fieldBuilder := structBuilder.FieldBuilder(fieldIdx)
+		structBuilder.Append(true)
 
-			fieldValue := (*data)[fieldName]
-			if fieldValue == nil {
-				fieldBuilder.AppendNull()
+		for i, member := range t.StructType.Members {
+			memberValue, _ := lookupField(data, member.Name)
 
-				continue
+			if err := appendJSONValue(member.Type, memberValue, structBuilder.FieldBuilder(i)); err != nil {
+				return fmt.Errorf("field %s: %w", member.Name, err)
 			}
+		}
 
-			switch fb := fieldBuilder.(type) {
-			case *array.Uint8Builder:
-				var val bool
-				if err := convert[bool](&val, fieldValue); err != nil {
-					return fmt.Errorf("field %s: %w", fieldName, err)
-				}
+		return nil
+	case *Ydb.Type_TypeId:
+		return appendJSONPrimitiveValue(t.TypeId, value, builder)
+	default:
+		return fmt.Errorf("unsupported type %v: %w", ydbType, common.ErrDataTypeNotSupported)
+	}
+}
 
-				if val {
-					fb.Append(uint8(1))
-				} else {
-					fb.Append(uint8(0))
-				}
+//nolint:gocyclo
+func appendJSONPrimitiveValue(typeID Ydb.Type_PrimitiveTypeId, value any, builder array.Builder) error {
+	switch fb := builder.(type) {
+	case *array.Uint8Builder:
+		var val bool
+		if err := convert[bool](&val, value); err != nil {
+			return err
+		}
 
-			case *array.Int32Builder:
-				var val int32
-				if err := convert(&val, fieldValue); err != nil {
-					return fmt.Errorf("field %s: %w", fieldName, err)
-				}
+		if val {
+			fb.Append(uint8(1))
+		} else {
+			fb.Append(uint8(0))
+		}
+	case *array.Int32Builder:
+		var val int32
+		if err := convert(&val, value); err != nil {
+			return err
+		}
 
-				fb.Append(val)
-			case *array.Int64Builder:
-				var val int64
-				if err := convert(&val, fieldValue); err != nil {
-					return fmt.Errorf("field %s: %w", fieldName, err)
-				}
+		fb.Append(val)
+	case *array.Int64Builder:
+		var val int64
+		if err := convert(&val, value); err != nil {
+			return err
+		}
 
-				fb.Append(val)
-			case *array.Uint64Builder:
-				val, err := parseTime(fieldValue)
-				if err != nil {
-					return fmt.Errorf("field %s: %w", fieldName, err)
-				}
+		fb.Append(val)
+	case *array.Uint64Builder:
+		if typeID != Ydb.Type_TIMESTAMP {
+			return fmt.Errorf("unexpected type %v for builder %T", typeID, fb)
+		}
 
-				in, err := common.TimeToYDBTimestamp(&val)
-				if err != nil {
-					return fmt.Errorf("to timestamp %s: %w", fieldName, err)
-				}
+		val, err := parseTime(value)
+		if err != nil {
+			return err
+		}
 
-				fb.Append(in)
-			case *array.Float32Builder:
-				var val float32
-				if err := convert(&val, fieldValue); err != nil {
-					return fmt.Errorf("field %s: %w", fieldName, err)
-				}
+		in, err := common.TimeToYDBTimestamp(&val)
+		if err != nil {
+			return fmt.Errorf("to timestamp: %w", err)
+		}
 
-				fb.Append(val)
-			case *array.Float64Builder:
-				var val float64
-				if err := convert(&val, fieldValue); err != nil {
-					return fmt.Errorf("field %s: %w", fieldName, err)
-				}
+		fb.Append(in)
+	case *array.Float32Builder:
+		var val float32
+		if err := convert(&val, value); err != nil {
+			return err
+		}
 
-				fb.Append(val)
-			case *array.StringBuilder:
-				strval, ok := fieldValue.(string)
-				if !ok {
-					return fmt.Errorf("field %s: expected string but got %T", fieldName, fieldValue)
-				}
+		fb.Append(val)
+	case *array.Float64Builder:
+		var val float64
+		if err := convert(&val, value); err != nil {
+			return err
+		}
 
-				fb.Append(strval)
-			case *array.BinaryBuilder:
-				strval, ok := fieldValue.(string)
-				if !ok {
-					return fmt.Errorf("field %s: expected binary but got %T", fieldName, fieldValue)
-				}
+		fb.Append(val)
+	case *array.StringBuilder:
+		strval, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected string but got %T", value)
+		}
 
-				fb.Append([]byte(strval))
-			default:
-				return fmt.Errorf("unsupported builder type %T for field %s", fb, fieldName)
-			}
+		fb.Append(strval)
+	case *array.BinaryBuilder:
+		strval, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected binary but got %T", value)
 		}
 
-		return nil
+		fb.Append([]byte(strval))
+	default:
+		return fmt.Errorf("unsupported builder type %T", fb)
 	}
+
+	return nil
 }
 
 func addAcceptorAppenderNonNullable(
@@ -0,0 +1,150 @@
+package opensearch
+
+import (
+	"fmt"
+	"strings"
+)
+
+// fieldInfo keeps the properties of the index field that affect the predicate pushdown
+type fieldInfo struct {
+	// OpenSearch field type (keyword, text, long, etc.)
+	fieldType string
+	// Paths of the enclosing fields of `nested` type, from the outermost to the innermost one.
+	// Queries on such fields must be wrapped into `nested` queries.
+	nestedPaths []string
+	// Qualified name of the `keyword` subfield (multi-field) if the field has one
+	keywordSubfield string
+	// Qualified name of the parent field if the field is a multi-field (e.g. `title` for `title.keyword`).
+	// Multi-fields are absent in the documents, their values are taken from the parent fields.
+	multiFieldOf string
+}
+
+// fieldIndex maps qualified (dotted) field names to their properties
+type fieldIndex map[string]*fieldInfo
+
+func newFieldIndex(mappings map[string]any) fieldIndex {
+	index := make(fieldIndex)
+
+	if properties, ok := mappings["properties"].(map[string]any); ok {
+		index.addProperties("", properties, nil)
+	}
+
+	return index
+}
+
+func (fi fieldIndex) addProperties(prefix string, properties map[string]any, nestedPaths []string) {
+	for fieldName, rawMapping := range properties {
+		mapping, ok := rawMapping.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		qualifiedName := fieldName
+		if prefix != "" {
+			qualifiedName = fmt.Sprintf("%s.%s", prefix, fieldName)
+		}
+
+		fieldType, _ := mapping["type"].(string)
+
+		info := &fieldInfo{
+			fieldType:   fieldType,
+			nestedPaths: nestedPaths,
+		}
+
+		// Multi-fields: the same value indexed in several ways, e.g. `text` with `keyword` subfield
+		if subfields, ok := mapping["fields"].(map[string]any); ok {
+			for _, subfieldName := range getSortedKeys(subfields) {
+				subfield, ok := subfields[subfieldName].(map[string]any)
+				if !ok {
+					continue
+				}
+
+				subfieldQualifiedName := fmt.Sprintf("%s.%s", qualifiedName, subfieldName)
+				subfieldType, _ := subfield["type"].(string)
+
+				fi[subfieldQualifiedName] = &fieldInfo{
+					fieldType:    subfieldType,
+					nestedPaths:  nestedPaths,
+					multiFieldOf: qualifiedName,
+				}
+
+				if subfieldType == fieldTypeKeyword && info.keywordSubfield == "" {
+					info.keywordSubfield = subfieldQualifiedName
+				}
+			}
+		}
+
+		fi[qualifiedName] = info
+
+		if childProperties, ok := mapping["properties"].(map[string]any); ok {
+			childNestedPaths := nestedPaths
+			if fieldType == fieldTypeNested {
+				childNestedPaths = append(append([]string{}, nestedPaths...), qualifiedName)
+			}
+
+			fi.addProperties(qualifiedName, childProperties, childNestedPaths)
+		}
+	}
+}
+
+// exactMatchField returns the name of the field that should be used for term-level queries
+// (term, terms, range, prefix, wildcard, regexp). Analyzed `text` fields are not suitable for them,
+// so their `keyword` subfields are used instead.
+func (fi fieldIndex) exactMatchField(fieldName string) string {
+	info, ok := fi[fieldName]
+	if !ok {
+		return fieldName
+	}
+
+	if info.keywordSubfield != "" {
+		return info.keywordSubfield
+	}
+
+	return fieldName
+}
+
+// sourceField returns the name of the field that should be requested from the document `_source`
+func (fi fieldIndex) sourceField(fieldName string) string {
+	if info, ok := fi[fieldName]; ok && info.multiFieldOf != "" {
+		return info.multiFieldOf
+	}
+
+	return fieldName
+}
+
+// wrapNested wraps the query on the field located inside `nested` objects
+// into the `nested` queries, one per nesting level.
+func (fi fieldIndex) wrapNested(fieldName string, query map[string]any) map[string]any {
+	nestedPaths := fi.nestedPaths(fieldName)
+
+	for i := len(nestedPaths) - 1; i >= 0; i-- {
+		query = map[string]any{
+			"nested": map[string]any{
+				"path":  nestedPaths[i],
+				"query": query,
+			},
+		}
+	}
+
+	return query
+}
+
+func (fi fieldIndex) nestedPaths(fieldName string) []string {
+	if info, ok := fi[fieldName]; ok {
+		return info.nestedPaths
+	}
+
+	// The field may be absent in the mapping (e. g. dynamic mapping is disabled),
+	// so try to find the closest known parent.
+	for i := strings.LastIndexByte(fieldName, '.'); i > 0; i = strings.LastIndexByte(fieldName[:i], '.') {
+		if info, ok := fi[fieldName[:i]]; ok {
+			if info.fieldType == fieldTypeNested {
+				return append(append([]string{}, info.nestedPaths...), fieldName[:i])
+			}
+
+			return info.nestedPaths
+		}
+	}
+
+	return nil
+}
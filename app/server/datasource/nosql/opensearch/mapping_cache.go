@@ -0,0 +1,51 @@
+package opensearch
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+const (
+	mappingCacheMaxKeys = 1024
+	// the mapping may be extended with new fields, so it shouldn't be kept for too long
+	mappingCacheTTL = 10 * time.Minute
+)
+
+// MappingCache keeps the index mappings obtained during DescribeTable requests,
+// so that ReadSplit requests could push down the predicates without fetching the mapping once again.
+// It should be instantiated once per server.
+// The mapping doesn't give access to the data: the documents are always read with the credentials of the request,
+// so the credentials are not a part of the key.
+type MappingCache struct {
+	cache *ristretto.Cache[string, fieldIndex]
+}
+
+func (c *MappingCache) get(dsi *api_common.TGenericDataSourceInstance, indexName string) (fieldIndex, bool) {
+	return c.cache.Get(makeMappingCacheKey(dsi, indexName))
+}
+
+func (c *MappingCache) put(dsi *api_common.TGenericDataSourceInstance, indexName string, fields fieldIndex) {
+	c.cache.SetWithTTL(makeMappingCacheKey(dsi, indexName), fields, 1, mappingCacheTTL)
+}
+
+func makeMappingCacheKey(dsi *api_common.TGenericDataSourceInstance, indexName string) string {
+	return fmt.Sprintf("%s/%t/%s/%s", common.EndpointToString(dsi.GetEndpoint()), dsi.GetUseTls(), dsi.GetDatabase(), indexName)
+}
+
+func NewMappingCache() (*MappingCache, error) {
+	cache, err := ristretto.NewCache(&ristretto.Config[string, fieldIndex]{
+		NumCounters: 10 * mappingCacheMaxKeys,
+		MaxCost:     mappingCacheMaxKeys, // every mapping costs 1
+		BufferItems: 64,                  // reasonable default
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ristretto new cache: %w", err)
+	}
+
+	return &MappingCache{cache: cache}, nil
+}
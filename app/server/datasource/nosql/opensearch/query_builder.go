@@ -6,7 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
 	"go.uber.org/zap"
@@ -14,15 +16,19 @@ import (
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
 type queryBuilder struct {
 	logger *zap.Logger
+	// fields contains the properties of the index fields obtained from the mapping;
+	// it may be empty if the mapping is unknown
+	fields fieldIndex
 }
 
-func newQueryBuilder(logger *zap.Logger) *queryBuilder {
-	return &queryBuilder{logger: logger}
+func newQueryBuilder(logger *zap.Logger, fields fieldIndex) *queryBuilder {
+	return &queryBuilder{logger: logger, fields: fields}
 }
 
 // buildSearchQuery constructs OpenSearch query with support for:
@@ -35,28 +41,33 @@ func newQueryBuilder(logger *zap.Logger) *queryBuilder {
 //   - OpenSearch requires full path to nested fields
 //   - Wildcards (e.g., "user.*") are NOT supported here
 //   - Invalid fields will be silently ignored by OpenSearch
-//   - Predicate pushdown: filter documents at source
+//   - Predicate pushdown: filter documents at source.
+//     Predicates on the fields located inside `nested` objects (e.g. "comments.author")
+//     are wrapped into `nested` queries, term-level queries on `text` fields
+//     are redirected to their `keyword` subfields (multi-fields).
+//   - Sorting: ORDER BY keys are turned into `sort` clause, `text` fields are sorted by their `keyword` subfields.
 //   - Pagination: control batch size via scroll API
 func (qb *queryBuilder) buildSearchQuery(
 	split *api_service_protos.TSplit,
 	filtering api_service_protos.TReadSplitsRequest_EFiltering,
 	batchSize uint64,
 	scrollTimeout time.Duration,
-) (io.Reader, *opensearchapi.SearchParams, error) {
+) (io.Reader, *opensearchapi.SearchParams, *api_service_protos.TPredicate, error) {
 	params := &opensearchapi.SearchParams{
 		Scroll: scrollTimeout,
 	}
 
 	what := split.Select.GetWhat()
 	if what == nil {
-		return nil, nil, errors.New("not specified columns to query in Select.What")
+		return nil, nil, nil, errors.New("not specified columns to query in Select.What")
 	}
 
 	// TODO (Test for top to bottom struct projection)
 	var projection []string
 
 	for _, item := range what.GetItems() {
-		projection = append(projection, item.GetColumn().Name)
+		// multi-fields are read from their parent fields
+		projection = append(projection, qb.fields.sourceField(item.GetColumn().Name))
 	}
 
 	query := map[string]any{
@@ -64,58 +75,106 @@ func (qb *queryBuilder) buildSearchQuery(
 		"_source": projection,
 	}
 
-	limit := split.Select.GetLimit()
-	if limit != nil {
-		from := int(limit.Offset)
-		size := int(limit.Limit)
-
-		params.From = &from
-		params.Size = &size
+	filter, residualPredicate, err := qb.makeWhereFilter(split.Select.GetWhere().GetFilterTyped(), filtering)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
-	where := split.Select.GetWhere()
-
-	var filter map[string]any
+	query["query"] = filter
 
-	if where != nil && where.FilterTyped != nil {
-		var err error
+	// There is no need to fetch the batches larger than the number of documents to read
+	if rowsLimit := makeRowsLimit(split.Select.GetLimit(), residualPredicate); rowsLimit > 0 && rowsLimit < batchSize {
+		query["size"] = rowsLimit
+	}
 
-		filter, err = qb.makePredicateFilter(where.FilterTyped, true)
+	if orderBy := split.Select.GetOrderBy(); len(orderBy.GetKeys()) > 0 {
+		sort, err := qb.makeSort(orderBy)
 		if err != nil {
-			switch filtering {
-			case api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY:
-				return nil, nil, fmt.Errorf("make predicate filter: %w", err)
-			case api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL:
-				if !common.OptionalFilteringAllowedErrors.Match(err) {
-					return nil, nil, fmt.Errorf("encountered an error making a filter: %w", err)
-				}
-
-				qb.logger.Warn("considering pushdown error as acceptable", zap.Error(err))
-			default:
-				return nil, nil, fmt.Errorf("unknown filtering mode: %d", filtering)
-			}
-		} else {
-			query["query"] = filter
-		}
-	} else {
-		query["query"] = map[string]any{
-			"match_all": make(map[string]any),
+			return nil, nil, nil, fmt.Errorf("make sort: %w", err)
 		}
+
+		query["sort"] = sort
 	}
 
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(query); err != nil {
-		return nil, nil, fmt.Errorf("encode query: %w", err)
+		return nil, nil, nil, fmt.Errorf("encode query: %w", err)
 	}
 
-	return &buf, params, nil
+	return &buf, params, residualPredicate, nil
 }
 
-//nolint:funlen,gocyclo
-func (qb *queryBuilder) makePredicateFilter(
+// makeWhereFilter translates the predicate into the query. In the FILTERING_OPTIONAL mode the conjuncts
+// that can't be translated are skipped and returned as the residual predicate.
+func (qb *queryBuilder) makeWhereFilter(
 	predicate *api_service_protos.TPredicate,
-	topLevel bool,
-) (map[string]any, error) {
+	filteringMode api_service_protos.TReadSplitsRequest_EFiltering,
+) (map[string]any, *api_service_protos.TPredicate, error) {
+	matchAll := map[string]any{
+		"match_all": make(map[string]any),
+	}
+
+	if predicate == nil {
+		return matchAll, nil, nil
+	}
+
+	switch filteringMode {
+	case api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY:
+		filter, err := qb.makePredicateFilter(predicate)
+		if err != nil {
+			return nil, nil, fmt.Errorf("make predicate filter: %w", err)
+		}
+
+		return filter, nil, nil
+	case api_service_protos.TReadSplitsRequest_FILTERING_UNSPECIFIED,
+		api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL:
+	default:
+		return nil, nil, fmt.Errorf("unknown filtering mode: %d", filteringMode)
+	}
+
+	operands := []*api_service_protos.TPredicate{predicate}
+	if conjunction := predicate.GetConjunction(); conjunction != nil {
+		operands = conjunction.Operands
+	}
+
+	var (
+		must     []map[string]any
+		residual []*api_service_protos.TPredicate
+	)
+
+	for _, operand := range operands {
+		filter, err := qb.makePredicateFilter(operand)
+		if err != nil {
+			if !common.OptionalFilteringAllowedErrors.Match(err) {
+				return nil, nil, fmt.Errorf("encountered an error making a filter: %w", err)
+			}
+
+			qb.logger.Warn("considering pushdown error as acceptable", zap.Error(err))
+
+			residual = append(residual, operand)
+
+			continue
+		}
+
+		must = append(must, filter)
+	}
+
+	switch len(must) {
+	case 0:
+		return matchAll, filtering.MakeConjunction(residual), nil
+	case 1:
+		return must[0], filtering.MakeConjunction(residual), nil
+	default:
+		return map[string]any{
+			"bool": map[string]any{
+				"must": must,
+			},
+		}, filtering.MakeConjunction(residual), nil
+	}
+}
+
+//nolint:funlen,gocyclo
+func (qb *queryBuilder) makePredicateFilter(predicate *api_service_protos.TPredicate) (map[string]any, error) {
 	switch p := predicate.Payload.(type) {
 	case *api_service_protos.TPredicate_IsNull:
 		filter, err := qb.makeIsNullFilter(p.IsNull.GetValue())
@@ -139,7 +198,7 @@ func (qb *queryBuilder) makePredicateFilter(
 
 		return filter, nil
 	case *api_service_protos.TPredicate_Conjunction:
-		filter, err := qb.makeConjunctionFilter(p.Conjunction, topLevel)
+		filter, err := qb.makeConjunctionFilter(p.Conjunction)
 		if err != nil {
 			return nil, fmt.Errorf("make conjunction filter: %w", err)
 		}
@@ -186,13 +245,20 @@ func (qb *queryBuilder) makePredicateFilter(
 			return nil, fmt.Errorf("make regex filter: %w", err)
 		}
 
+		return filter, nil
+	case *api_service_protos.TPredicate_Match:
+		filter, err := qb.makeMatchFilter(p.Match)
+		if err != nil {
+			return nil, fmt.Errorf("make match filter: %w", err)
+		}
+
 		return filter, nil
 	default:
 		return nil, fmt.Errorf("%w: %T", common.ErrUnimplementedPredicateType, p)
 	}
 }
 func (qb *queryBuilder) makeNegationFilter(negation *api_service_protos.TPredicate_TNegation) (map[string]any, error) {
-	filter, err := qb.makePredicateFilter(negation.Operand, false)
+	filter, err := qb.makePredicateFilter(negation.Operand)
 	if err != nil {
 		return nil, fmt.Errorf("make predicate filter: %w", err)
 	}
@@ -213,44 +279,28 @@ func (qb *queryBuilder) makeBooleanFilter(boolExpr *api_service_protos.TPredicat
 	return map[string]any{
 		"bool": map[string]any{
 			"must": []any{
-				map[string]any{
+				qb.fields.wrapNested(field, map[string]any{
 					"term": map[string]any{
 						field: true,
 					},
-				},
+				}),
 			},
 		},
 	}, nil
 }
 
-func (qb *queryBuilder) makeConjunctionFilter(
-	conjunction *api_service_protos.TPredicate_TConjunction,
-	topLevel bool,
-) (map[string]any, error) {
-	var (
-		must []map[string]any
-		errs []error
-	)
+func (qb *queryBuilder) makeConjunctionFilter(conjunction *api_service_protos.TPredicate_TConjunction) (map[string]any, error) {
+	var must []map[string]any
 
 	for _, op := range conjunction.Operands {
-		filter, err := qb.makePredicateFilter(op, false)
+		filter, err := qb.makePredicateFilter(op)
 		if err != nil {
-			if topLevel {
-				errs = append(errs, fmt.Errorf("operand error: %w", err))
-
-				continue
-			}
-
 			return nil, fmt.Errorf("make predicate filter: %w", err)
 		}
 
 		must = append(must, filter)
 	}
 
-	if topLevel && len(errs) > 0 {
-		return nil, fmt.Errorf("%d errors in conjunction: %v", len(errs), errs)
-	}
-
 	return map[string]any{
 		"bool": map[string]any{
 			"must": must,
@@ -262,7 +312,7 @@ func (qb *queryBuilder) makeDisjunctionFilter(disjunction *api_service_protos.TP
 	var should []map[string]any
 
 	for _, op := range disjunction.Operands {
-		filter, err := qb.makePredicateFilter(op, false)
+		filter, err := qb.makePredicateFilter(op)
 		if err != nil {
 			return nil, fmt.Errorf("make predicate filter: %w", err)
 		}
@@ -293,11 +343,11 @@ func (qb *queryBuilder) makeIsNullFilter(expr *api_service_protos.TExpression) (
 	return map[string]any{
 		"bool": map[string]any{
 			"must_not": []any{
-				map[string]any{
+				qb.fields.wrapNested(field, map[string]any{
 					"exists": map[string]any{
 						"field": field,
 					},
-				},
+				}),
 			},
 		},
 	}, nil
@@ -309,11 +359,11 @@ func (qb *queryBuilder) makeIsNotNullFilter(expr *api_service_protos.TExpression
 		return nil, fmt.Errorf("get field name: %w", err)
 	}
 
-	return map[string]any{
+	return qb.fields.wrapNested(field, map[string]any{
 		"exists": map[string]any{
 			"field": field,
 		},
-	}, nil
+	}), nil
 }
 
 func (qb *queryBuilder) makeComparisonFilter(comparison *api_service_protos.TPredicate_TComparison) (map[string]any, error) {
@@ -327,84 +377,78 @@ func (qb *queryBuilder) makeComparisonFilter(comparison *api_service_protos.TPre
 		return nil, fmt.Errorf("make expression value: %w", err)
 	}
 
+	exactField := qb.fields.exactMatchField(field)
+
+	var filter map[string]any
+
 	switch comparison.Operation {
 	case api_service_protos.TPredicate_TComparison_EQ:
-		return map[string]any{
+		filter = map[string]any{
 			"term": map[string]any{
-				field: value,
+				exactField: value,
 			},
-		}, nil
+		}
 	case api_service_protos.TPredicate_TComparison_NE:
+		// must_not is applied outside of the nested query,
+		// so that the documents without matching nested objects are returned
 		return map[string]any{
 			"bool": map[string]any{
 				"must_not": []any{
-					map[string]any{
+					qb.fields.wrapNested(field, map[string]any{
 						"term": map[string]any{
-							field: value,
+							exactField: value,
 						},
-					},
+					}),
 				},
 			},
 		}, nil
 	case api_service_protos.TPredicate_TComparison_L:
-		return map[string]any{
-			"range": map[string]any{
-				field: map[string]any{
-					"lt": value,
-				},
-			},
-		}, nil
+		filter = makeRangeFilter(exactField, "lt", value)
 	case api_service_protos.TPredicate_TComparison_LE:
-		return map[string]any{
-			"range": map[string]any{
-				field: map[string]any{
-					"lte": value,
-				},
-			},
-		}, nil
+		filter = makeRangeFilter(exactField, "lte", value)
 	case api_service_protos.TPredicate_TComparison_G:
-		return map[string]any{
-			"range": map[string]any{
-				field: map[string]any{
-					"gt": value,
-				},
-			},
-		}, nil
+		filter = makeRangeFilter(exactField, "gt", value)
 	case api_service_protos.TPredicate_TComparison_GE:
-		return map[string]any{
-			"range": map[string]any{
-				field: map[string]any{
-					"gte": value,
-				},
-			},
-		}, nil
+		filter = makeRangeFilter(exactField, "gte", value)
 	case api_service_protos.TPredicate_TComparison_STARTS_WITH:
-		return map[string]any{
+		filter = map[string]any{
 			"prefix": map[string]any{
-				fmt.Sprintf("%s.keyword", field): map[string]any{
+				qb.keywordField(field): map[string]any{
 					"value": value,
 				},
 			},
-		}, nil
+		}
 	case api_service_protos.TPredicate_TComparison_CONTAINS:
-		return map[string]any{
+		filter = map[string]any{
 			"wildcard": map[string]any{
-				fmt.Sprintf("%s.keyword", field): map[string]any{
-					"value": fmt.Sprintf("*%s*", value),
+				qb.keywordField(field): map[string]any{
+					"value": fmt.Sprintf("*%s*", escapeWildcard(value)),
 				},
 			},
-		}, nil
+		}
 	case api_service_protos.TPredicate_TComparison_ENDS_WITH:
-		return map[string]any{
+		filter = map[string]any{
 			"wildcard": map[string]any{
-				fmt.Sprintf("%s.keyword", field): map[string]any{
-					"value": fmt.Sprintf("*%s", value),
+				qb.keywordField(field): map[string]any{
+					"value": fmt.Sprintf("*%s", escapeWildcard(value)),
 				},
 			},
-		}, nil
+		}
 	default:
 		return nil, fmt.Errorf("%w: %d", common.ErrUnimplementedOperation, comparison.Operation)
 	}
+
+	return qb.fields.wrapNested(field, filter), nil
+}
+
+func makeRangeFilter(field string, operator string, value any) map[string]any {
+	return map[string]any{
+		"range": map[string]any{
+			field: map[string]any{
+				operator: value,
+			},
+		},
+	}
 }
 
 func (qb *queryBuilder) makeInSetFilter(in *api_service_protos.TPredicate_TIn) (map[string]any, error) {
@@ -424,11 +468,11 @@ func (qb *queryBuilder) makeInSetFilter(in *api_service_protos.TPredicate_TIn) (
 		values = append(values, value)
 	}
 
-	return map[string]any{
+	return qb.fields.wrapNested(field, map[string]any{
 		"terms": map[string]any{
-			field: values,
+			qb.fields.exactMatchField(field): values,
 		},
-	}, nil
+	}), nil
 }
 
 func (qb *queryBuilder) makeBetweenFilter(between *api_service_protos.TPredicate_TBetween) (map[string]any, error) {
@@ -447,14 +491,14 @@ func (qb *queryBuilder) makeBetweenFilter(between *api_service_protos.TPredicate
 		return nil, fmt.Errorf("make expression value: %w", err)
 	}
 
-	return map[string]any{
+	return qb.fields.wrapNested(field, map[string]any{
 		"range": map[string]any{
-			field: map[string]any{
+			qb.fields.exactMatchField(field): map[string]any{
 				"gte": mn,
 				"lte": mx,
 			},
 		},
-	}, nil
+	}), nil
 }
 
 func (qb *queryBuilder) makeRegexFilter(regex *api_service_protos.TPredicate_TRegexp) (map[string]any, error) {
@@ -468,13 +512,198 @@ func (qb *queryBuilder) makeRegexFilter(regex *api_service_protos.TPredicate_TRe
 		return nil, fmt.Errorf("make expression value: %w", err)
 	}
 
-	return map[string]any{
+	patternStr, ok := pattern.(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: regexp pattern of type %T", common.ErrUnsupportedExpression, pattern)
+	}
+
+	luceneRegexp, err := makeLuceneRegexp(patternStr)
+	if err != nil {
+		return nil, fmt.Errorf("make Lucene regexp: %w", err)
+	}
+
+	return qb.fields.wrapNested(field, map[string]any{
 		"regexp": map[string]any{
+			qb.keywordField(field): map[string]any{
+				"value": luceneRegexp,
+			},
+		},
+	}), nil
+}
+
+// makeMatchFilter builds the full-text `match` query, which is executed against the analyzed field
+func (qb *queryBuilder) makeMatchFilter(match *api_service_protos.TPredicate_TMatch) (map[string]any, error) {
+	field, err := qb.getFieldName(match.Value)
+	if err != nil {
+		return nil, fmt.Errorf("get field name: %w", err)
+	}
+
+	query, err := qb.makeExpressionValue(match.Query)
+	if err != nil {
+		return nil, fmt.Errorf("make expression value: %w", err)
+	}
+
+	if _, ok := query.(string); !ok {
+		return nil, fmt.Errorf("%w: match query of type %T", common.ErrUnsupportedExpression, query)
+	}
+
+	return qb.fields.wrapNested(field, map[string]any{
+		"match": map[string]any{
 			field: map[string]any{
-				"value": pattern,
+				"query": query,
 			},
 		},
-	}, nil
+	}), nil
+}
+
+// makeLuceneRegexp converts the RE2 pattern into the Lucene regular expression.
+// Lucene regular expressions are always anchored and have no anchor operators,
+// while YQL REGEXP looks for the match anywhere in the string.
+// Shorthand character classes, flags and groups modifiers have no Lucene counterparts,
+// and the characters which are operators in Lucene only are escaped.
+func makeLuceneRegexp(pattern string) (string, error) {
+	var sb strings.Builder
+
+	anchoredStart := strings.HasPrefix(pattern, "^")
+	if anchoredStart {
+		pattern = pattern[1:]
+	} else {
+		sb.WriteString(".*")
+	}
+
+	anchoredEnd := false
+	inBrackets := false
+	runes := []rune(pattern)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case r == '\\':
+			if i+1 == len(runes) {
+				return "", fmt.Errorf("%w: trailing backslash in regexp", common.ErrInvalidRequest)
+			}
+
+			next := runes[i+1]
+			if unicode.IsLetter(next) || unicode.IsDigit(next) {
+				return "", fmt.Errorf("%w: escape sequence \\%c in regexp", common.ErrUnimplementedOperation, next)
+			}
+
+			sb.WriteRune(r)
+			sb.WriteRune(next)
+
+			i++
+		case inBrackets:
+			// POSIX character classes like [[:alpha:]] are not supported by Lucene
+			if r == '[' && i+1 < len(runes) && runes[i+1] == ':' {
+				return "", fmt.Errorf("%w: character class in bracket expression in regexp", common.ErrUnimplementedOperation)
+			}
+
+			if r == ']' {
+				inBrackets = false
+			}
+
+			sb.WriteRune(r)
+		case r == '[':
+			inBrackets = true
+
+			sb.WriteRune(r)
+		case r == '(' && i+1 < len(runes) && runes[i+1] == '?':
+			return "", fmt.Errorf("%w: group modifiers in regexp", common.ErrUnimplementedOperation)
+		case r == '$' && i+1 == len(runes):
+			anchoredEnd = true
+		case r == '^' || r == '$':
+			return "", fmt.Errorf("%w: anchor in the middle of regexp", common.ErrUnimplementedOperation)
+		case strings.ContainsRune(luceneOperators, r):
+			sb.WriteRune('\\')
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	if inBrackets {
+		return "", fmt.Errorf("%w: unterminated character class in regexp", common.ErrInvalidRequest)
+	}
+
+	if !anchoredEnd {
+		sb.WriteString(".*")
+	}
+
+	return sb.String(), nil
+}
+
+// luceneOperators are the characters having the special meaning in Lucene regular expressions only
+const luceneOperators = `@&~<>#"`
+
+// escapeWildcard escapes the characters having the special meaning in `wildcard` queries
+func escapeWildcard(value any) any {
+	str, ok := value.(string)
+	if !ok {
+		return value
+	}
+
+	return wildcardEscaper.Replace(str)
+}
+
+var wildcardEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`)
+
+func (qb *queryBuilder) makeSort(orderBy *api_service_protos.TSelect_TOrderBy) ([]any, error) {
+	sort := make([]any, 0, len(orderBy.GetKeys()))
+
+	for _, key := range orderBy.GetKeys() {
+		if key.Column == "" {
+			return nil, fmt.Errorf("empty sort key column: %w", common.ErrInvalidRequest)
+		}
+
+		var order string
+
+		switch key.Direction {
+		case api_service_protos.TSelect_TOrderBy_DIRECTION_UNSPECIFIED, api_service_protos.TSelect_TOrderBy_ASC:
+			order = "asc"
+		case api_service_protos.TSelect_TOrderBy_DESC:
+			order = "desc"
+		default:
+			return nil, fmt.Errorf("unexpected sort direction %v: %w", key.Direction, common.ErrInvalidRequest)
+		}
+
+		// Analyzed text and nested objects cannot be sorted
+		if info, ok := qb.fields[key.Column]; ok {
+			if info.fieldType == fieldTypeNested || (info.fieldType == fieldTypeText && info.keywordSubfield == "") {
+				return nil, fmt.Errorf(
+					"sorting by field '%s' of type '%s': %w", key.Column, info.fieldType, common.ErrUnimplementedOperation)
+			}
+		}
+
+		sort = append(sort, map[string]any{
+			qb.fields.exactMatchField(key.Column): map[string]any{"order": order},
+		})
+	}
+
+	return sort, nil
+}
+
+// makeRowsLimit returns the number of documents that should be read to satisfy LIMIT clause, or 0 if it's unlimited.
+// Like in the relational data sources, `offset + limit` documents are returned, and the engine applies OFFSET by itself.
+// Limit is not applied if some documents are going to be filtered out afterwards,
+// otherwise the result would be shorter than requested.
+func makeRowsLimit(limit *api_service_protos.TSelect_TLimit, residualPredicate *api_service_protos.TPredicate) uint64 {
+	if limit.GetLimit() == 0 || residualPredicate != nil {
+		return 0
+	}
+
+	return limit.GetLimit() + limit.GetOffset()
+}
+
+// keywordField returns the field suitable for the pattern matching queries (prefix, wildcard).
+// If the field is not described in the mapping, its `keyword` subfield
+// created by the OpenSearch dynamic mapping is assumed.
+func (qb *queryBuilder) keywordField(field string) string {
+	if _, ok := qb.fields[field]; !ok {
+		return fmt.Sprintf("%s.keyword", field)
+	}
+
+	return qb.fields.exactMatchField(field)
 }
 
 func (*queryBuilder) getFieldName(expr *api_service_protos.TExpression) (string, error) {
@@ -557,8 +786,14 @@ func (*queryBuilder) formatValue(exprType *Ydb.Type, value any) (any, error) {
 	case *Ydb.Type_TypeId:
 		switch t.TypeId {
 		case Ydb.Type_BOOL, Ydb.Type_UINT32, Ydb.Type_UINT64, Ydb.Type_INT32,
-			Ydb.Type_INT64, Ydb.Type_FLOAT, Ydb.Type_DOUBLE,
-			Ydb.Type_STRING, Ydb.Type_UTF8:
+			Ydb.Type_INT64, Ydb.Type_FLOAT, Ydb.Type_DOUBLE, Ydb.Type_UTF8:
+			return value, nil
+		case Ydb.Type_STRING:
+			// Otherwise binary data would be encoded into JSON as base64
+			if b, ok := value.([]byte); ok {
+				return string(b), nil
+			}
+
 			return value, nil
 		default:
 			return nil, fmt.Errorf("unsupported type %T for typed value", t)
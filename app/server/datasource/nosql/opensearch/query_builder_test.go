@@ -0,0 +1,506 @@
+package opensearch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestMakePredicateFilter(t *testing.T) {
+	mapping := map[string]any{
+		"properties": map[string]any{
+			"title": map[string]any{
+				"type": "text",
+				"fields": map[string]any{
+					"raw": map[string]any{"type": "keyword"},
+				},
+			},
+			"status": map[string]any{"type": "keyword"},
+			"comments": map[string]any{
+				"type": "nested",
+				"properties": map[string]any{
+					"author": map[string]any{"type": "keyword"},
+					"replies": map[string]any{
+						"type": "nested",
+						"properties": map[string]any{
+							"likes": map[string]any{"type": "integer"},
+						},
+					},
+				},
+			},
+			"user": map[string]any{
+				"properties": map[string]any{
+					"name": map[string]any{"type": "keyword"},
+				},
+			},
+		},
+	}
+
+	makeColumn := func(column string) *api_service_protos.TExpression {
+		return &api_service_protos.TExpression{Payload: &api_service_protos.TExpression_Column{Column: column}}
+	}
+
+	makeValue := func(value *Ydb.TypedValue) *api_service_protos.TExpression {
+		return &api_service_protos.TExpression{Payload: &api_service_protos.TExpression_TypedValue{TypedValue: value}}
+	}
+
+	makeComparison := func(
+		column string,
+		op api_service_protos.TPredicate_TComparison_EOperation,
+		value *Ydb.TypedValue,
+	) *api_service_protos.TPredicate {
+		return &api_service_protos.TPredicate{
+			Payload: &api_service_protos.TPredicate_Comparison{
+				Comparison: &api_service_protos.TPredicate_TComparison{
+					LeftValue:  makeColumn(column),
+					Operation:  op,
+					RightValue: makeValue(value),
+				},
+			},
+		}
+	}
+
+	type testCase struct {
+		name      string
+		fields    fieldIndex
+		predicate *api_service_protos.TPredicate
+		output    map[string]any
+	}
+
+	tcs := []testCase{
+		{
+			name:   "multi_field_term",
+			fields: newFieldIndex(mapping),
+			predicate: makeComparison(
+				"title", api_service_protos.TPredicate_TComparison_EQ,
+				common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_UTF8), "abc"),
+			),
+			output: map[string]any{
+				"term": map[string]any{"title.raw": "abc"},
+			},
+		},
+		{
+			name:   "keyword_prefix",
+			fields: newFieldIndex(mapping),
+			predicate: makeComparison(
+				"status", api_service_protos.TPredicate_TComparison_STARTS_WITH,
+				common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_UTF8), "ok"),
+			),
+			output: map[string]any{
+				"prefix": map[string]any{"status": map[string]any{"value": "ok"}},
+			},
+		},
+		{
+			name:   "unknown_field_prefix",
+			fields: nil,
+			predicate: makeComparison(
+				"status", api_service_protos.TPredicate_TComparison_STARTS_WITH,
+				common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_UTF8), "ok"),
+			),
+			output: map[string]any{
+				"prefix": map[string]any{"status.keyword": map[string]any{"value": "ok"}},
+			},
+		},
+		{
+			name:   "nested_term",
+			fields: newFieldIndex(mapping),
+			predicate: makeComparison(
+				"comments.author", api_service_protos.TPredicate_TComparison_EQ,
+				common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_UTF8), "alice"),
+			),
+			output: map[string]any{
+				"nested": map[string]any{
+					"path": "comments",
+					"query": map[string]any{
+						"term": map[string]any{"comments.author": "alice"},
+					},
+				},
+			},
+		},
+		{
+			name:   "deeply_nested_range",
+			fields: newFieldIndex(mapping),
+			predicate: makeComparison(
+				"comments.replies.likes", api_service_protos.TPredicate_TComparison_G,
+				common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_INT32), int32(10)),
+			),
+			output: map[string]any{
+				"nested": map[string]any{
+					"path": "comments",
+					"query": map[string]any{
+						"nested": map[string]any{
+							"path": "comments.replies",
+							"query": map[string]any{
+								"range": map[string]any{"comments.replies.likes": map[string]any{"gt": int32(10)}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "object_field_is_not_nested",
+			fields: newFieldIndex(mapping),
+			predicate: makeComparison(
+				"user.name", api_service_protos.TPredicate_TComparison_EQ,
+				common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_UTF8), "bob"),
+			),
+			output: map[string]any{
+				"term": map[string]any{"user.name": "bob"},
+			},
+		},
+		{
+			name:   "nested_not_equal",
+			fields: newFieldIndex(mapping),
+			predicate: makeComparison(
+				"comments.author", api_service_protos.TPredicate_TComparison_NE,
+				common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_UTF8), "alice"),
+			),
+			output: map[string]any{
+				"bool": map[string]any{
+					"must_not": []any{
+						map[string]any{
+							"nested": map[string]any{
+								"path": "comments",
+								"query": map[string]any{
+									"term": map[string]any{"comments.author": "alice"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "contains_escaped",
+			fields: newFieldIndex(mapping),
+			predicate: makeComparison(
+				"status", api_service_protos.TPredicate_TComparison_CONTAINS,
+				common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_STRING), []byte("a*b?")),
+			),
+			output: map[string]any{
+				"wildcard": map[string]any{"status": map[string]any{"value": `*a\*b\?*`}},
+			},
+		},
+		{
+			name:   "in_terms",
+			fields: newFieldIndex(mapping),
+			predicate: &api_service_protos.TPredicate{
+				Payload: &api_service_protos.TPredicate_In{
+					In: &api_service_protos.TPredicate_TIn{
+						Value: makeColumn("title"),
+						Set: []*api_service_protos.TExpression{
+							makeValue(common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_UTF8), "a")),
+							makeValue(common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_UTF8), "b")),
+						},
+					},
+				},
+			},
+			output: map[string]any{
+				"terms": map[string]any{"title.raw": []any{"a", "b"}},
+			},
+		},
+		{
+			name:   "between_range",
+			fields: newFieldIndex(mapping),
+			predicate: &api_service_protos.TPredicate{
+				Payload: &api_service_protos.TPredicate_Between{
+					Between: &api_service_protos.TPredicate_TBetween{
+						Value:    makeColumn("comments.replies.likes"),
+						Least:    makeValue(common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_INT32), int32(1))),
+						Greatest: makeValue(common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_INT32), int32(5))),
+					},
+				},
+			},
+			output: map[string]any{
+				"nested": map[string]any{
+					"path": "comments",
+					"query": map[string]any{
+						"nested": map[string]any{
+							"path": "comments.replies",
+							"query": map[string]any{
+								"range": map[string]any{
+									"comments.replies.likes": map[string]any{"gte": int32(1), "lte": int32(5)},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "regexp",
+			fields: newFieldIndex(mapping),
+			predicate: &api_service_protos.TPredicate{
+				Payload: &api_service_protos.TPredicate_Regexp{
+					Regexp: &api_service_protos.TPredicate_TRegexp{
+						Value:   makeColumn("title"),
+						Pattern: makeValue(common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_STRING), []byte("^err(or)?"))),
+					},
+				},
+			},
+			output: map[string]any{
+				"regexp": map[string]any{"title.raw": map[string]any{"value": "err(or)?.*"}},
+			},
+		},
+		{
+			name:   "match",
+			fields: newFieldIndex(mapping),
+			predicate: &api_service_protos.TPredicate{
+				Payload: &api_service_protos.TPredicate_Match{
+					Match: &api_service_protos.TPredicate_TMatch{
+						Value: makeColumn("title"),
+						Query: makeValue(common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_UTF8), "connection refused")),
+					},
+				},
+			},
+			output: map[string]any{
+				"match": map[string]any{"title": map[string]any{"query": "connection refused"}},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			qb := newQueryBuilder(common.NewTestLogger(t), tc.fields)
+
+			output, err := qb.makePredicateFilter(tc.predicate)
+			require.NoError(t, err)
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
+
+func TestMakeLuceneRegexp(t *testing.T) {
+	type testCase struct {
+		pattern string
+		output  string
+		err     error
+	}
+
+	tcs := []testCase{
+		{pattern: "abc", output: ".*abc.*"},
+		{pattern: "^abc$", output: "abc"},
+		{pattern: "a[^$]c$", output: ".*a[^$]c"},
+		{pattern: `a\.b`, output: `.*a\.b.*`},
+		{pattern: "a@b", output: `.*a\@b.*`},
+		{pattern: `\d+`, err: common.ErrUnimplementedOperation},
+		{pattern: "(?i)abc", err: common.ErrUnimplementedOperation},
+		{pattern: "a|^b", err: common.ErrUnimplementedOperation},
+		{pattern: "[[:alpha:]]+", err: common.ErrUnimplementedOperation},
+		{pattern: "[a[:digit:]]", err: common.ErrUnimplementedOperation},
+		{pattern: "[a[]", output: ".*[a[].*"},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.pattern, func(t *testing.T) {
+			output, err := makeLuceneRegexp(tc.pattern)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
+
+func TestMakeSort(t *testing.T) {
+	mapping := map[string]any{
+		"properties": map[string]any{
+			"title": map[string]any{
+				"type": "text",
+				"fields": map[string]any{
+					"raw": map[string]any{"type": "keyword"},
+				},
+			},
+			"body":     map[string]any{"type": "text"},
+			"ts":       map[string]any{"type": "date"},
+			"comments": map[string]any{"type": "nested"},
+		},
+	}
+
+	makeOrderBy := func(column string, direction api_service_protos.TSelect_TOrderBy_EDirection) *api_service_protos.TSelect_TOrderBy {
+		return &api_service_protos.TSelect_TOrderBy{
+			Keys: []*api_service_protos.TSelect_TOrderBy_TSortKey{
+				{Column: column, Direction: direction},
+			},
+		}
+	}
+
+	qb := newQueryBuilder(common.NewTestLogger(t), newFieldIndex(mapping))
+
+	t.Run("desc", func(t *testing.T) {
+		sort, err := qb.makeSort(makeOrderBy("ts", api_service_protos.TSelect_TOrderBy_DESC))
+		require.NoError(t, err)
+		require.Equal(t, []any{map[string]any{"ts": map[string]any{"order": "desc"}}}, sort)
+	})
+
+	t.Run("multi_field", func(t *testing.T) {
+		sort, err := qb.makeSort(makeOrderBy("title", api_service_protos.TSelect_TOrderBy_DIRECTION_UNSPECIFIED))
+		require.NoError(t, err)
+		require.Equal(t, []any{map[string]any{"title.raw": map[string]any{"order": "asc"}}}, sort)
+	})
+
+	t.Run("text", func(t *testing.T) {
+		_, err := qb.makeSort(makeOrderBy("body", api_service_protos.TSelect_TOrderBy_ASC))
+		require.ErrorIs(t, err, common.ErrUnimplementedOperation)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		_, err := qb.makeSort(makeOrderBy("comments", api_service_protos.TSelect_TOrderBy_ASC))
+		require.ErrorIs(t, err, common.ErrUnimplementedOperation)
+	})
+}
+
+func TestBuildSearchQueryResidualPredicate(t *testing.T) {
+	supported := &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_IsNull{
+			IsNull: &api_service_protos.TPredicate_TIsNull{
+				Value: &api_service_protos.TExpression{Payload: &api_service_protos.TExpression_Column{Column: "status"}},
+			},
+		},
+	}
+
+	// predicate-level COALESCE is not pushed down into OpenSearch
+	unsupported := &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_Coalesce{
+			Coalesce: &api_service_protos.TPredicate_TCoalesce{
+				Operands: []*api_service_protos.TPredicate{supported},
+			},
+		},
+	}
+
+	split := &api_service_protos.TSplit{
+		Select: &api_service_protos.TSelect{
+			What: &api_service_protos.TSelect_TWhat{},
+			Where: &api_service_protos.TSelect_TWhere{
+				FilterTyped: &api_service_protos.TPredicate{
+					Payload: &api_service_protos.TPredicate_Conjunction{
+						Conjunction: &api_service_protos.TPredicate_TConjunction{
+							Operands: []*api_service_protos.TPredicate{supported, unsupported},
+						},
+					},
+				},
+			},
+			Limit: &api_service_protos.TSelect_TLimit{Limit: 10},
+		},
+	}
+
+	qb := newQueryBuilder(common.NewTestLogger(t), nil)
+
+	t.Run("optional", func(t *testing.T) {
+		body, _, residualPredicate, err := qb.buildSearchQuery(split, api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL, 100, 0)
+		require.NoError(t, err)
+		require.Equal(t, unsupported, residualPredicate)
+
+		// the documents filtered out afterwards would make the result shorter than LIMIT
+		var query map[string]any
+
+		require.NoError(t, json.NewDecoder(body).Decode(&query))
+		require.Equal(t, 100.0, query["size"])
+		require.Zero(t, makeRowsLimit(split.Select.Limit, residualPredicate))
+
+		filter, residualPredicate, err := qb.makeWhereFilter(
+			split.Select.Where.FilterTyped,
+			api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+		)
+		require.NoError(t, err)
+		require.Equal(t, unsupported, residualPredicate)
+
+		expected, err := qb.makePredicateFilter(supported)
+		require.NoError(t, err)
+		require.Equal(t, expected, filter)
+	})
+
+	t.Run("mandatory", func(t *testing.T) {
+		_, _, _, err := qb.buildSearchQuery(split, api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY, 100, 0)
+		require.ErrorIs(t, err, common.ErrUnimplementedPredicateType)
+	})
+}
+
+func TestMakeRowsLimit(t *testing.T) {
+	residualPredicate := &api_service_protos.TPredicate{}
+
+	// OFFSET is applied by the engine
+	require.Equal(t, uint64(15), makeRowsLimit(&api_service_protos.TSelect_TLimit{Limit: 10, Offset: 5}, nil))
+	require.Zero(t, makeRowsLimit(&api_service_protos.TSelect_TLimit{Limit: 10, Offset: 5}, residualPredicate))
+	require.Zero(t, makeRowsLimit(nil, nil))
+}
+
+func TestMultiFields(t *testing.T) {
+	fields := newFieldIndex(map[string]any{
+		"properties": map[string]any{
+			"title": map[string]any{
+				"type": "text",
+				"fields": map[string]any{
+					"keyword": map[string]any{"type": "keyword"},
+				},
+			},
+			"user": map[string]any{
+				"properties": map[string]any{
+					"name": map[string]any{"type": "keyword"},
+				},
+			},
+		},
+	})
+
+	t.Run("projection", func(t *testing.T) {
+		split := &api_service_protos.TSplit{
+			Select: &api_service_protos.TSelect{
+				What: &api_service_protos.TSelect_TWhat{
+					Items: []*api_service_protos.TSelect_TWhat_TItem{
+						{Payload: &api_service_protos.TSelect_TWhat_TItem_Column{Column: &Ydb.Column{Name: "_id"}}},
+						{Payload: &api_service_protos.TSelect_TWhat_TItem_Column{Column: &Ydb.Column{Name: "title.keyword"}}},
+						{Payload: &api_service_protos.TSelect_TWhat_TItem_Column{Column: &Ydb.Column{Name: "user"}}},
+					},
+				},
+			},
+		}
+
+		qb := newQueryBuilder(common.NewTestLogger(t), fields)
+
+		body, _, _, err := qb.buildSearchQuery(split, api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL, 100, 0)
+		require.NoError(t, err)
+
+		var query map[string]any
+
+		require.NoError(t, json.NewDecoder(body).Decode(&query))
+		require.Equal(t, []any{"_id", "title", "user"}, query["_source"])
+	})
+
+	t.Run("exact_match", func(t *testing.T) {
+		require.Equal(t, "title.keyword", fields.exactMatchField("title"))
+		require.Equal(t, "title.keyword", fields.exactMatchField("title.keyword"))
+	})
+
+	t.Run("lookup", func(t *testing.T) {
+		document := map[string]any{
+			"title": "first",
+			"user":  map[string]any{"name": "alice"},
+		}
+
+		value, ok := lookupField(document, "title.keyword")
+		require.True(t, ok)
+		require.Equal(t, "first", value)
+
+		// subfields of objects are not multi-fields
+		_, ok = lookupField(document, "user.name")
+		require.False(t, ok)
+
+		_, ok = lookupField(document, "missing.keyword")
+		require.False(t, ok)
+	})
+}
@@ -12,24 +12,42 @@ import (
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
+const (
+	fieldTypeNested  = "nested"
+	fieldTypeText    = "text"
+	fieldTypeKeyword = "keyword"
+	metaValueList    = "list"
+)
+
 func parseMapping(
 	logger *zap.Logger,
 	mappings map[string]any,
+	arrayFields []string,
 ) ([]*Ydb.Column, error) {
 	// OpenSearch does not have a dedicated "array" data type.
 	// Any field can contain zero or more elements, as long as they are of the same type.
-	// To work with YDB fq-connector-go, users must explicitly indicate which fields
+	// To work with YDB fq-connector-go, users may explicitly indicate which fields
 	// should be treated as lists (LIST). This is done by adding a "_meta" property
 	// to the index. The "_meta" property is used during schema construction to identify
 	// which fields should be considered as arrays (lists).
+	// Besides that, the fields that were found to contain arrays in the sampled documents
+	// (see detectArrayFields) are treated as lists too.
 	meta := make(map[string]any)
 
 	if metaSection, ok := mappings["_meta"].(map[string]any); ok {
-		meta = metaSection
+		for k, v := range metaSection {
+			meta[k] = v
+		}
 	} else {
 		logger.Debug("_meta section is missing, continue with empty one")
 	}
 
+	for _, fieldName := range arrayFields {
+		if _, exists := meta[fieldName]; !exists {
+			meta[fieldName] = metaValueList
+		}
+	}
+
 	properties, ok := mappings["properties"].(map[string]any)
 	if !ok {
 		availableKeys := make([]string, 0, len(mappings))
@@ -65,6 +83,7 @@ func parseMapping(
 		}
 
 		columns = append(columns, field)
+		columns = append(columns, inferMultiFields(logger, fieldName, fieldName, props, meta)...)
 	}
 
 	logger.Info("parsing finished", zap.Int("total_columns", len(columns)))
@@ -81,12 +100,43 @@ func inferField(
 ) (*Ydb.Column, error) {
 	properties, ok := mapping["properties"].(map[string]any)
 	if !ok {
-		return handleSimpleField(fieldName, qualifiedName, mapping, meta)
+		if mapping["type"] == fieldTypeNested {
+			// nested field without any known subfields
+			properties = make(map[string]any)
+		} else {
+			return handleSimpleField(fieldName, qualifiedName, mapping, meta)
+		}
+	}
+
+	if mapping["type"] == fieldTypeNested {
+		return handleNestedField(logger, fieldName, qualifiedName, properties, meta)
 	}
 
 	return handleStructField(logger, fieldName, qualifiedName, properties, meta)
 }
 
+// handleNestedField maps the fields of `nested` type. Such fields always contain arrays of objects
+// that are indexed independently, so they are exposed as List<Struct<...>> regardless of the meta annotations.
+func handleNestedField(
+	logger *zap.Logger,
+	fieldName string,
+	qualifiedName string,
+	properties map[string]any,
+	meta map[string]any,
+) (*Ydb.Column, error) {
+	children, err := processChildFields(logger, qualifiedName, properties, meta)
+	if err != nil {
+		return nil, fmt.Errorf("process nested field '%s': %w", fieldName, err)
+	}
+
+	ydbType := common.MakeOptionalType(common.MakeListType(common.MakeOptionalType(common.MakeStructType(children))))
+
+	return &Ydb.Column{
+		Name: fieldName,
+		Type: ydbType,
+	}, nil
+}
+
 func handleStructField(
 	logger *zap.Logger,
 	fieldName string,
@@ -143,6 +193,13 @@ func processChildFields(
 			Name: childField.Name,
 			Type: childField.Type,
 		})
+
+		for _, multiField := range inferMultiFields(logger, childFieldName, childQualifiedName, childProps, meta) {
+			children = append(children, &Ydb.StructMember{
+				Name: multiField.Name,
+				Type: multiField.Type,
+			})
+		}
 	}
 
 	return children, nil
@@ -169,7 +226,7 @@ func applyMetaAnnotation(
 		return nil, fmt.Errorf("meta value for field '%s' must be string, got %T", qualifiedName, metaValue)
 	}
 
-	if metaStr != "list" {
+	if metaStr != metaValueList {
 		return nil, fmt.Errorf("unsupported meta value '%s' for field '%s'", metaStr, qualifiedName)
 	}
 
@@ -197,6 +254,50 @@ func handleSimpleField(
 	}, nil
 }
 
+// inferMultiFields maps the multi-fields of a simple field (e.g. `title.keyword` for the `title` field of `text` type).
+// Multi-fields are not stored in the documents: they index the value of the parent field in a different way,
+// so they share the value (and the array-ness) of the parent field.
+// Multi-fields of unsupported types are skipped, as they can always be obtained from the parent field.
+func inferMultiFields(
+	logger *zap.Logger,
+	fieldName string,
+	qualifiedName string,
+	mapping map[string]any,
+	meta map[string]any,
+) []*Ydb.Column {
+	subfields, ok := mapping["fields"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	var columns []*Ydb.Column
+
+	for _, subfieldName := range getSortedKeys(subfields) {
+		subfieldMapping, ok := subfields[subfieldName].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		ydbType, err := typeMap(subfieldMapping)
+		if err != nil {
+			logger.Debug("skipping multi-field", zap.String("field", qualifiedName), zap.String("subfield", subfieldName), zap.Error(err))
+
+			continue
+		}
+
+		if _, exists := meta[qualifiedName]; exists {
+			ydbType = common.MakeOptionalType(common.MakeListType(ydbType))
+		}
+
+		columns = append(columns, &Ydb.Column{
+			Name: fmt.Sprintf("%s.%s", fieldName, subfieldName),
+			Type: ydbType,
+		})
+	}
+
+	return columns
+}
+
 func typeMap(
 	mapping map[string]any,
 ) (*Ydb.Type, error) {
@@ -218,7 +319,7 @@ func typeMap(
 		ydbType = common.MakePrimitiveType(Ydb.Type_DOUBLE)
 	case "boolean":
 		ydbType = common.MakePrimitiveType(Ydb.Type_BOOL)
-	case "keyword", "text":
+	case fieldTypeKeyword, fieldTypeText:
 		ydbType = common.MakePrimitiveType(Ydb.Type_UTF8)
 	case "binary":
 		ydbType = common.MakePrimitiveType(Ydb.Type_STRING)
@@ -230,3 +331,45 @@ func typeMap(
 
 	return common.MakeOptionalType(ydbType), nil
 }
+
+// detectArrayFields walks through the sampled documents and returns
+// the qualified names of the fields containing arrays in at least one document.
+func detectArrayFields(documents []map[string]any) []string {
+	found := make(map[string]struct{})
+
+	for _, document := range documents {
+		collectArrayFields("", document, found)
+	}
+
+	result := make([]string, 0, len(found))
+	for fieldName := range found {
+		result = append(result, fieldName)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+func collectArrayFields(prefix string, document map[string]any, found map[string]struct{}) {
+	for key, value := range document {
+		qualifiedName := key
+		if prefix != "" {
+			qualifiedName = fmt.Sprintf("%s.%s", prefix, key)
+		}
+
+		switch v := value.(type) {
+		case []any:
+			found[qualifiedName] = struct{}{}
+
+			// arrays of objects may contain arrays on their own
+			for _, item := range v {
+				if child, ok := item.(map[string]any); ok {
+					collectArrayFields(qualifiedName, child, found)
+				}
+			}
+		case map[string]any:
+			collectArrayFields(qualifiedName, v, found)
+		}
+	}
+}
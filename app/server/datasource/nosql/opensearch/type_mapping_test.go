@@ -0,0 +1,78 @@
+package opensearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestParseMapping(t *testing.T) {
+	mapping := map[string]any{
+		"properties": map[string]any{
+			"tags": map[string]any{"type": "keyword"},
+			"title": map[string]any{
+				"type": "text",
+				"fields": map[string]any{
+					"keyword": map[string]any{"type": "keyword"},
+					// unsupported multi-fields are skipped
+					"length": map[string]any{"type": "token_count"},
+				},
+			},
+			"comments": map[string]any{
+				"type": "nested",
+				"properties": map[string]any{
+					"author": map[string]any{"type": "keyword"},
+					"likes":  map[string]any{"type": "integer"},
+				},
+			},
+		},
+	}
+
+	documents := []map[string]any{
+		{
+			"tags":     "single",
+			"title":    "first",
+			"comments": []any{map[string]any{"author": "alice", "likes": []any{1.0, 2.0}}},
+		},
+		{
+			"tags":  []any{"a", "b"},
+			"title": "second",
+		},
+	}
+
+	arrayFields := detectArrayFields(documents)
+	require.Equal(t, []string{"comments", "comments.likes", "tags"}, arrayFields)
+
+	columns, err := parseMapping(common.NewTestLogger(t), mapping, arrayFields)
+	require.NoError(t, err)
+
+	optional := func(typeID Ydb.Type_PrimitiveTypeId) *Ydb.Type {
+		return common.MakeOptionalType(common.MakePrimitiveType(typeID))
+	}
+
+	expected := []*Ydb.Column{
+		{Name: "_id", Type: common.MakePrimitiveType(Ydb.Type_UTF8)},
+		{
+			Name: "comments",
+			Type: common.MakeOptionalType(common.MakeListType(common.MakeOptionalType(common.MakeStructType(
+				[]*Ydb.StructMember{
+					{Name: "author", Type: optional(Ydb.Type_UTF8)},
+					{Name: "likes", Type: common.MakeOptionalType(common.MakeListType(optional(Ydb.Type_INT32)))},
+				},
+			)))),
+		},
+		{Name: "tags", Type: common.MakeOptionalType(common.MakeListType(optional(Ydb.Type_UTF8)))},
+		{Name: "title", Type: optional(Ydb.Type_UTF8)},
+		{Name: "title.keyword", Type: optional(Ydb.Type_UTF8)},
+	}
+
+	require.Equal(t, len(expected), len(columns))
+
+	for i := range expected {
+		require.Equal(t, expected[i].String(), columns[i].String())
+	}
+}
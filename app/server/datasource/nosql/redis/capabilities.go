@@ -0,0 +1,29 @@
+package redis
+
+import (
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+)
+
+// makeCapabilities lists the predicates that are pushed down into Redis:
+// only the equality comparison of the key column with a string value is supported.
+func makeCapabilities() *api_service_protos.TCapabilities {
+	out := datasource.NewCapabilities()
+
+	out.Predicates = []api_service_protos.TCapabilities_EPredicate{
+		api_service_protos.TCapabilities_PREDICATE_COMPARISON,
+	}
+
+	out.Expressions = []api_service_protos.TCapabilities_EExpression{
+		api_service_protos.TCapabilities_EXPRESSION_TYPED_VALUE,
+		api_service_protos.TCapabilities_EXPRESSION_COLUMN,
+	}
+
+	out.Types = []Ydb.Type_PrimitiveTypeId{
+		Ydb.Type_STRING,
+	}
+
+	return out
+}
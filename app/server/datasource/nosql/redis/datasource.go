@@ -20,6 +20,7 @@ import (
 	"github.com/ydb-platform/fq-connector-go/app/config"
 	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils/retry"
 	"github.com/ydb-platform/fq-connector-go/common"
@@ -403,7 +404,12 @@ func (ds *dataSource) ReadSplit(
 
 	ds.queryLogger.Dump(split.Select.From.Table, split.Select.What.String())
 
-	sinks, err := sinkFactory.MakeSinks([]*paging.SinkParams{{Logger: logger}})
+	residualFilter, err := filtering.NewResidualFilter(logger, residualPredicate(split.Select.Where), split.Select.What)
+	if err != nil {
+		return fmt.Errorf("new residual filter: %w", err)
+	}
+
+	sinks, err := sinkFactory.MakeSinks([]*paging.SinkParams{{Logger: logger, ResidualFilter: residualFilter}})
 	if err != nil {
 		return fmt.Errorf("make sinks: %w", err)
 	}
@@ -424,6 +430,29 @@ func (ds *dataSource) ReadSplit(
 	return nil
 }
 
+// residualPredicate returns the predicate if it cannot be expressed with the key pattern
+func residualPredicate(where *api_service_protos.TSelect_TWhere) *api_service_protos.TPredicate {
+	predicate := where.GetFilterTyped()
+	if predicate == nil {
+		return nil
+	}
+
+	comp := predicate.GetComparison()
+	if comp == nil || comp.GetLeftValue().GetColumn() != KeyColumnName {
+		return predicate
+	}
+
+	switch comp.Operation {
+	case api_service_protos.TPredicate_TComparison_EQ,
+		api_service_protos.TPredicate_TComparison_STARTS_WITH,
+		api_service_protos.TPredicate_TComparison_ENDS_WITH,
+		api_service_protos.TPredicate_TComparison_CONTAINS:
+		return nil
+	default:
+		return predicate
+	}
+}
+
 // DescribeTable retrieves table metadata by scanning Redis keys with a given prefix.
 // It accumulates keys until at least 'count' keys are collected or the scan finishes,
 // then analyzes key types and builds the schema.
@@ -469,7 +498,8 @@ func (ds *dataSource) DescribeTable(
 	// If no keys found, return an empty schema.
 	if len(allKeys) == 0 {
 		return &api_service_protos.TDescribeTableResponse{
-			Schema: &api_service_protos.TSchema{Columns: nil},
+			Schema:       &api_service_protos.TSchema{Columns: nil},
+			Capabilities: makeCapabilities(),
 		}, nil
 	}
 
@@ -481,7 +511,8 @@ func (ds *dataSource) DescribeTable(
 	columns := buildSchema(*keysInfo)
 
 	return &api_service_protos.TDescribeTableResponse{
-		Schema: &api_service_protos.TSchema{Columns: columns},
+		Schema:       &api_service_protos.TSchema{Columns: columns},
+		Capabilities: makeCapabilities(),
 	}, nil
 }
 
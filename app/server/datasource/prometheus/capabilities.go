@@ -0,0 +1,46 @@
+package prometheus
+
+import (
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+)
+
+// makeCapabilities lists the predicates that are translated into the time range and the label matchers
+// of the remote read request, or checked by the reader (the comparisons with the `value` column)
+func makeCapabilities() *api_service_protos.TCapabilities {
+	out := datasource.NewCapabilities()
+
+	out.Predicates = []api_service_protos.TCapabilities_EPredicate{
+		api_service_protos.TCapabilities_PREDICATE_NEGATION,
+		api_service_protos.TCapabilities_PREDICATE_CONJUNCTION,
+		api_service_protos.TCapabilities_PREDICATE_DISJUNCTION,
+		api_service_protos.TCapabilities_PREDICATE_IN,
+		api_service_protos.TCapabilities_PREDICATE_COMPARISON,
+		api_service_protos.TCapabilities_PREDICATE_REGEXP,
+	}
+
+	out.Expressions = []api_service_protos.TCapabilities_EExpression{
+		api_service_protos.TCapabilities_EXPRESSION_TYPED_VALUE,
+		api_service_protos.TCapabilities_EXPRESSION_COLUMN,
+	}
+
+	out.Types = []Ydb.Type_PrimitiveTypeId{
+		Ydb.Type_INT8,
+		Ydb.Type_UINT8,
+		Ydb.Type_INT16,
+		Ydb.Type_UINT16,
+		Ydb.Type_INT32,
+		Ydb.Type_UINT32,
+		Ydb.Type_INT64,
+		Ydb.Type_UINT64,
+		Ydb.Type_FLOAT,
+		Ydb.Type_DOUBLE,
+		Ydb.Type_STRING,
+		Ydb.Type_UTF8,
+		Ydb.Type_TIMESTAMP,
+	}
+
+	return out
+}
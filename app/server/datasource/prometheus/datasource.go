@@ -15,6 +15,7 @@ import (
 	"github.com/ydb-platform/fq-connector-go/app/config"
 	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils/retry"
 	"github.com/ydb-platform/fq-connector-go/common"
@@ -76,9 +77,10 @@ func (ds *dataSource) DescribeTable(
 
 	logger.Info("schema have been read successfully")
 
-	return &api_service_protos.TDescribeTableResponse{Schema: &api_service_protos.TSchema{
-		Columns: columns,
-	}}, nil
+	return &api_service_protos.TDescribeTableResponse{
+		Schema:       &api_service_protos.TSchema{Columns: columns},
+		Capabilities: makeCapabilities(),
+	}, nil
 }
 
 func (dataSource) ListSplits(
@@ -117,29 +119,34 @@ func (ds *dataSource) ReadSplit(
 		return fmt.Errorf("new read client: %w", err)
 	}
 
-	sinks, err := sinkFactory.MakeSinks([]*paging.SinkParams{{Logger: logger}})
+	promQLExpr, err := NewPromQLBuilder(logger).
+		From(split.Select.From.GetTable()).
+		WithYdbWhere(split.Select.GetWhere(), request.GetFiltering())
+	if err != nil {
+		return fmt.Errorf("build promql expression: %w", err)
+	}
+
+	residualFilter, err := filtering.NewResidualFilter(logger, promQLExpr.ResidualPredicate(), split.Select.What)
+	if err != nil {
+		return fmt.Errorf("new residual filter: %w", err)
+	}
+
+	sinks, err := sinkFactory.MakeSinks([]*paging.SinkParams{{Logger: logger, ResidualFilter: residualFilter}})
 	if err != nil {
 		return fmt.Errorf("make sinks: %w", err)
 	}
 
-	return ds.doReadSplit(ctx, logger, request, split, sinks[0], client)
+	return ds.doReadSplit(ctx, logger, split, promQLExpr, sinks[0], client)
 }
 
 func (ds *dataSource) doReadSplit(
 	ctx context.Context,
 	logger *zap.Logger,
-	request *api_service_protos.TReadSplitsRequest,
 	split *api_service_protos.TSplit,
+	promQLExpr PromQLBuilder,
 	sink paging.Sink[any],
 	client *ReadClient,
 ) error {
-	promQLExpr, err := NewPromQLBuilder(logger).
-		From(split.Select.From.GetTable()).
-		WithYdbWhere(split.Select.GetWhere(), request.GetFiltering())
-	if err != nil {
-		return fmt.Errorf("build promql expression: %w", err)
-	}
-
 	pbQuery, err := promQLExpr.ToQuery()
 	if err != nil {
 		return fmt.Errorf("promql builder to query: %w", err)
@@ -200,6 +207,10 @@ func (ds *dataSource) doReadSplit(
 			}
 
 			ts, v := iter.At()
+			if !promQLExpr.MatchValue(v) {
+				continue
+			}
+
 			if err = reader.accept(series.Labels(), ts, v); err != nil {
 				return fmt.Errorf("accept time series: %w", err)
 			}
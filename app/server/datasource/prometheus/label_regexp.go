@@ -0,0 +1,173 @@
+package prometheus
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// applyLabelRegexp turns the predicate over a single label into the `=~` or `!~` label matcher.
+// Regexps, IN lists and disjunctions of these predicates (including equalities) are supported.
+func (p PromQLBuilder) applyLabelRegexp(predicate *protos.TPredicate, matchType labels.MatchType) PromQLBuilder {
+	label, pattern, err := makeLabelRegexp(predicate)
+	if err != nil {
+		p.predicateErrors = append(p.predicateErrors, fmt.Errorf("make label regexp: %w", err))
+
+		return p
+	}
+
+	matcher, err := labels.NewMatcher(matchType, label, pattern)
+	if err != nil {
+		p.predicateErrors = append(p.predicateErrors, fmt.Errorf(
+			"new label matcher: %w, pattern: %s, error: %v",
+			common.ErrUnsupportedExpression, pattern, err))
+
+		return p
+	}
+
+	p.labelMatchers = append(p.labelMatchers, matcher)
+
+	// Prometheus treats the absent label as the empty one, so `!~` matches the series without the label,
+	// while YDB never matches NULL values
+	if matchType == labels.MatchNotRegexp {
+		p.labelMatchers = append(p.labelMatchers, &labels.Matcher{
+			Type: labels.MatchNotEqual,
+			Name: label,
+		})
+	}
+
+	return p
+}
+
+// makeLabelRegexp returns the label and the regular expression matching the same label values as the predicate.
+// Prometheus anchors the regular expressions of label matchers at both ends.
+func makeLabelRegexp(predicate *protos.TPredicate) (string, string, error) {
+	switch pred := predicate.GetPayload().(type) {
+	case *protos.TPredicate_Comparison:
+		if pred.Comparison.GetOperation() != protos.TPredicate_TComparison_EQ {
+			return "", "", fmt.Errorf("%w, type: %s", common.ErrUnimplementedOperation, pred.Comparison.GetOperation())
+		}
+
+		label, err := getLabel(pred.Comparison.GetLeftValue())
+		if err != nil {
+			return "", "", fmt.Errorf("get label: %w", err)
+		}
+
+		value, err := getStringValue(pred.Comparison.GetRightValue())
+		if err != nil {
+			return "", "", fmt.Errorf("get string value: %w", err)
+		}
+
+		return label, regexp.QuoteMeta(value), nil
+	case *protos.TPredicate_In:
+		label, err := getLabel(pred.In.GetValue())
+		if err != nil {
+			return "", "", fmt.Errorf("get label: %w", err)
+		}
+
+		if len(pred.In.GetSet()) == 0 {
+			return "", "", fmt.Errorf("empty IN list: %w", common.ErrInvalidRequest)
+		}
+
+		alternatives := make([]string, 0, len(pred.In.GetSet()))
+
+		for _, expr := range pred.In.GetSet() {
+			value, err := getStringValue(expr)
+			if err != nil {
+				return "", "", fmt.Errorf("get string value: %w", err)
+			}
+
+			alternatives = append(alternatives, regexp.QuoteMeta(value))
+		}
+
+		return label, strings.Join(alternatives, "|"), nil
+	case *protos.TPredicate_Regexp:
+		label, err := getLabel(pred.Regexp.GetValue())
+		if err != nil {
+			return "", "", fmt.Errorf("get label: %w", err)
+		}
+
+		pattern, err := getStringValue(pred.Regexp.GetPattern())
+		if err != nil {
+			return "", "", fmt.Errorf("get pattern: %w", err)
+		}
+
+		// YQL REGEXP looks for the match anywhere in the string
+		return label, ".*(?:" + pattern + ").*", nil
+	case *protos.TPredicate_Disjunction:
+		return makeDisjunctionLabelRegexp(pred.Disjunction)
+	default:
+		return "", "", fmt.Errorf("%w, type: %T", common.ErrUnimplementedPredicateType, pred)
+	}
+}
+
+func makeDisjunctionLabelRegexp(disjunction *protos.TPredicate_TDisjunction) (string, string, error) {
+	var label string
+
+	alternatives := make([]string, 0, len(disjunction.GetOperands()))
+
+	for _, operand := range disjunction.GetOperands() {
+		operandLabel, pattern, err := makeLabelRegexp(operand)
+		if err != nil {
+			return "", "", fmt.Errorf("make label regexp for disjunction operand: %w", err)
+		}
+
+		if label != "" && label != operandLabel {
+			return "", "", fmt.Errorf(
+				"%w, disjunction over different labels: %s, %s",
+				common.ErrUnimplementedPredicateType, label, operandLabel)
+		}
+
+		label = operandLabel
+
+		alternatives = append(alternatives, pattern)
+	}
+
+	if label == "" {
+		return "", "", fmt.Errorf("empty disjunction: %w", common.ErrInvalidRequest)
+	}
+
+	return label, strings.Join(alternatives, "|"), nil
+}
+
+func getLabel(expr *protos.TExpression) (string, error) {
+	column := expr.GetColumn()
+
+	switch column {
+	case "":
+		return "", fmt.Errorf("%w, expected column, got %T", common.ErrUnsupportedExpression, expr.GetPayload())
+	case timestampColumn, valueColumn:
+		return "", fmt.Errorf("%w, column %s is not a label", common.ErrUnsupportedExpression, column)
+	default:
+		return column, nil
+	}
+}
+
+func getStringValue(expr *protos.TExpression) (string, error) {
+	value := expr.GetTypedValue()
+	if value == nil {
+		return "", fmt.Errorf("%w, expected typed value, got %T", common.ErrUnsupportedExpression, expr.GetPayload())
+	}
+
+	switch typeID := value.GetType().GetTypeId(); typeID {
+	case Ydb.Type_STRING, Ydb.Type_UTF8:
+	default:
+		return "", fmt.Errorf("%w, type %s", common.ErrDataTypeNotSupported, typeID)
+	}
+
+	switch v := value.GetValue().GetValue().(type) {
+	case *Ydb.Value_BytesValue:
+		return string(v.BytesValue), nil
+	case *Ydb.Value_TextValue:
+		return v.TextValue, nil
+	default:
+		return "", fmt.Errorf("%w, unexpected value %T", common.ErrInvalidRequest, v)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
 	"github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
@@ -33,7 +34,13 @@ type PromQLBuilder struct {
 	startTime     int64
 	endTime       int64
 
+	// Remote read has no means to filter the samples by value,
+	// so these comparisons are checked by the reader.
+	valueFilters []valueFilter
+
 	predicateErrors []error
+	// The conjuncts of the predicate that were not pushed down.
+	residualPredicates []*protos.TPredicate
 }
 
 func NewPromQLBuilder(logger *zap.Logger) PromQLBuilder {
@@ -93,15 +100,51 @@ func (p PromQLBuilder) ToQuery() (*prompb.Query, error) {
 	return pbQuery, nil
 }
 
+// MatchValue checks the sample value against the comparisons with the `value` column
+func (p PromQLBuilder) MatchValue(value float64) bool {
+	for _, f := range p.valueFilters {
+		if !f.match(value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ResidualPredicate returns the part of the predicate that was not pushed down
+// and therefore can be evaluated by the connector itself
+func (p PromQLBuilder) ResidualPredicate() *protos.TPredicate {
+	return filtering.MakeConjunction(p.residualPredicates)
+}
+
 func applyPredicate(p PromQLBuilder, predicate *protos.TPredicate) PromQLBuilder {
-	// Now we support only conjunction and comparison predicates
-	switch pred := predicate.Payload.(type) {
-	case *protos.TPredicate_Conjunction:
-		for _, curPred := range pred.Conjunction.GetOperands() {
+	if conjunction := predicate.GetConjunction(); conjunction != nil {
+		for _, curPred := range conjunction.GetOperands() {
 			p = applyPredicate(p, curPred)
 		}
+
+		return p
+	}
+
+	errorsBefore := len(p.predicateErrors)
+
+	p = applyPredicateOperand(p, predicate)
+
+	if len(p.predicateErrors) > errorsBefore {
+		p.residualPredicates = append(p.residualPredicates, predicate)
+	}
+
+	return p
+}
+
+func applyPredicateOperand(p PromQLBuilder, predicate *protos.TPredicate) PromQLBuilder {
+	switch pred := predicate.Payload.(type) {
 	case *protos.TPredicate_Comparison:
 		return p.applyComparisonPredicate(predicate.GetComparison())
+	case *protos.TPredicate_Regexp, *protos.TPredicate_In, *protos.TPredicate_Disjunction:
+		return p.applyLabelRegexp(predicate, labels.MatchRegexp)
+	case *protos.TPredicate_Negation:
+		return p.applyLabelRegexp(pred.Negation.GetOperand(), labels.MatchNotRegexp)
 	default:
 		p.predicateErrors = append(p.predicateErrors, fmt.Errorf("%w, type: %T", common.ErrUnimplementedPredicateType, pred))
 	}
@@ -126,9 +169,9 @@ func (p PromQLBuilder) applyComparisonPredicate(c *protos.TPredicate_TComparison
 		case timestampColumn:
 			return p.applyTimestampExpr(op, rv.GetTypedValue())
 		// If column is `value`, we can`t push down this predicate, because remote read client provide only
-		// `from`/`to` time options and label matchers
+		// `from`/`to` time options and label matchers, so the samples are filtered by the reader
 		case valueColumn:
-			return p
+			return p.applyValueExpr(op, rv.GetTypedValue())
 		// Other columns are strings that represent prometheus labels
 		default:
 			return p.applyStringExpr(op, lv.GetColumn(), rv.GetTypedValue())
@@ -339,3 +339,214 @@ func TestWithYdbWhereFullExpression(t *testing.T) {
 	assert.Equal(t, timestamp.UnixMilli()+1, pbQuery.GetStartTimestampMs())
 	assert.Equal(t, timestamp.Add(10*time.Second).UnixMilli()-1, pbQuery.GetEndTimestampMs())
 }
+
+func TestWithYdbWhereLabelMatchers(t *testing.T) {
+	logger := common.NewTestLogger(t)
+	expectedLabels := []*prompb.LabelMatcher{
+		{
+			Type:  prompb.LabelMatcher_RE,
+			Name:  "instance",
+			Value: ".*(?:^host-[0-9]+$).*",
+		},
+		{
+			Type:  prompb.LabelMatcher_RE,
+			Name:  "job",
+			Value: `api|db\.main`,
+		},
+		{
+			Type:  prompb.LabelMatcher_NRE,
+			Name:  "env",
+			Value: "dev|test",
+		},
+		{
+			Type: prompb.LabelMatcher_NEQ,
+			Name: "env",
+		},
+		{
+			Type:  prompb.LabelMatcher_RE,
+			Name:  "region",
+			Value: "eu|.*(?:^us-).*",
+		},
+	}
+
+	where := &api_service_protos.TSelect_TWhere{
+		FilterTyped: &api_service_protos.TPredicate{
+			Payload: &api_service_protos.TPredicate_Conjunction{
+				Conjunction: &api_service_protos.TPredicate_TConjunction{
+					Operands: []*api_service_protos.TPredicate{
+						{
+							Payload: &api_service_protos.TPredicate_Regexp{
+								Regexp: &api_service_protos.TPredicate_TRegexp{
+									Value:   utils.NewColumnExpression("instance"),
+									Pattern: utils.NewTextValueExpression("^host-[0-9]+$"),
+								},
+							},
+						},
+						{
+							Payload: &api_service_protos.TPredicate_In{
+								In: &api_service_protos.TPredicate_TIn{
+									Value: utils.NewColumnExpression("job"),
+									Set: []*api_service_protos.TExpression{
+										utils.NewStringValueExpression("api"),
+										utils.NewStringValueExpression("db.main"),
+									},
+								},
+							},
+						},
+						{
+							Payload: &api_service_protos.TPredicate_Negation{
+								Negation: &api_service_protos.TPredicate_TNegation{
+									Operand: &api_service_protos.TPredicate{
+										Payload: &api_service_protos.TPredicate_In{
+											In: &api_service_protos.TPredicate_TIn{
+												Value: utils.NewColumnExpression("env"),
+												Set: []*api_service_protos.TExpression{
+													utils.NewStringValueExpression("dev"),
+													utils.NewStringValueExpression("test"),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Payload: &api_service_protos.TPredicate_Disjunction{
+								Disjunction: &api_service_protos.TPredicate_TDisjunction{
+									Operands: []*api_service_protos.TPredicate{
+										{
+											Payload: &api_service_protos.TPredicate_Comparison{
+												Comparison: &api_service_protos.TPredicate_TComparison{
+													Operation:  api_service_protos.TPredicate_TComparison_EQ,
+													LeftValue:  utils.NewColumnExpression("region"),
+													RightValue: utils.NewStringValueExpression("eu"),
+												},
+											},
+										},
+										{
+											Payload: &api_service_protos.TPredicate_Regexp{
+												Regexp: &api_service_protos.TPredicate_TRegexp{
+													Value:   utils.NewColumnExpression("region"),
+													Pattern: utils.NewTextValueExpression("^us-"),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	builder, err := prometheus.NewPromQLBuilder(logger).
+		WithYdbWhere(where, api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY)
+
+	assert.NoError(t, err)
+
+	pbQuery, err := builder.ToQuery()
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, expectedLabels, pbQuery.GetMatchers())
+}
+
+func TestWithYdbWhereDisjunctionOverDifferentLabels(t *testing.T) {
+	logger := common.NewTestLogger(t)
+	where := &api_service_protos.TSelect_TWhere{
+		FilterTyped: &api_service_protos.TPredicate{
+			Payload: &api_service_protos.TPredicate_Disjunction{
+				Disjunction: &api_service_protos.TPredicate_TDisjunction{
+					Operands: []*api_service_protos.TPredicate{
+						{
+							Payload: &api_service_protos.TPredicate_Comparison{
+								Comparison: &api_service_protos.TPredicate_TComparison{
+									Operation:  api_service_protos.TPredicate_TComparison_EQ,
+									LeftValue:  utils.NewColumnExpression("job"),
+									RightValue: utils.NewStringValueExpression("api"),
+								},
+							},
+						},
+						{
+							Payload: &api_service_protos.TPredicate_Comparison{
+								Comparison: &api_service_protos.TPredicate_TComparison{
+									Operation:  api_service_protos.TPredicate_TComparison_EQ,
+									LeftValue:  utils.NewColumnExpression("env"),
+									RightValue: utils.NewStringValueExpression("prod"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	//
+	// Without filtering mandatory parsing
+	//
+	builder, err := prometheus.NewPromQLBuilder(logger).
+		WithYdbWhere(where, 0)
+
+	assert.NoError(t, err)
+
+	pbQuery, err := builder.ToQuery()
+
+	assert.NoError(t, err)
+	assert.Empty(t, pbQuery.GetMatchers())
+
+	//
+	// With filtering mandatory parsing
+	//
+	_, err = prometheus.NewPromQLBuilder(logger).
+		WithYdbWhere(where, api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY)
+
+	assert.ErrorIs(t, err, common.ErrUnimplementedPredicateType)
+}
+
+func TestWithYdbWhereValueFilter(t *testing.T) {
+	logger := common.NewTestLogger(t)
+	where := &api_service_protos.TSelect_TWhere{
+		FilterTyped: &api_service_protos.TPredicate{
+			Payload: &api_service_protos.TPredicate_Conjunction{
+				Conjunction: &api_service_protos.TPredicate_TConjunction{
+					Operands: []*api_service_protos.TPredicate{
+						{
+							Payload: &api_service_protos.TPredicate_Comparison{
+								Comparison: &api_service_protos.TPredicate_TComparison{
+									Operation:  api_service_protos.TPredicate_TComparison_GE,
+									LeftValue:  utils.NewColumnExpression("value"),
+									RightValue: utils.NewInt32ValueExpression(10),
+								},
+							},
+						},
+						{
+							Payload: &api_service_protos.TPredicate_Comparison{
+								Comparison: &api_service_protos.TPredicate_TComparison{
+									Operation:  api_service_protos.TPredicate_TComparison_NE,
+									LeftValue:  utils.NewColumnExpression("value"),
+									RightValue: utils.NewInt64ValueExpression(15),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	builder, err := prometheus.NewPromQLBuilder(logger).
+		WithYdbWhere(where, api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY)
+
+	assert.NoError(t, err)
+	assert.False(t, builder.MatchValue(9.5))
+	assert.True(t, builder.MatchValue(10))
+	assert.False(t, builder.MatchValue(15))
+	assert.True(t, builder.MatchValue(20.5))
+
+	pbQuery, err := builder.ToQuery()
+
+	assert.NoError(t, err)
+	assert.Empty(t, pbQuery.GetMatchers())
+}
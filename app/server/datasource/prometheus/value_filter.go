@@ -0,0 +1,87 @@
+package prometheus
+
+import (
+	"fmt"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+type valueFilter struct {
+	operation protos.TPredicate_TComparison_EOperation
+	value     float64
+}
+
+func (f valueFilter) match(value float64) bool {
+	switch f.operation {
+	case protos.TPredicate_TComparison_EQ:
+		return value == f.value
+	case protos.TPredicate_TComparison_NE:
+		return value != f.value
+	case protos.TPredicate_TComparison_L:
+		return value < f.value
+	case protos.TPredicate_TComparison_LE:
+		return value <= f.value
+	case protos.TPredicate_TComparison_G:
+		return value > f.value
+	case protos.TPredicate_TComparison_GE:
+		return value >= f.value
+	default:
+		return true
+	}
+}
+
+func (p PromQLBuilder) applyValueExpr(op protos.TPredicate_TComparison_EOperation, value *Ydb.TypedValue) PromQLBuilder {
+	switch op {
+	case protos.TPredicate_TComparison_EQ,
+		protos.TPredicate_TComparison_NE,
+		protos.TPredicate_TComparison_L,
+		protos.TPredicate_TComparison_LE,
+		protos.TPredicate_TComparison_G,
+		protos.TPredicate_TComparison_GE:
+	default:
+		p.predicateErrors = append(p.predicateErrors, fmt.Errorf(
+			"apply value expression: %w, type: %s",
+			common.ErrUnimplementedOperation, op.String()))
+
+		return p
+	}
+
+	number, err := typedValueToFloat64(value)
+	if err != nil {
+		p.predicateErrors = append(p.predicateErrors, fmt.Errorf("get value: %w", err))
+
+		return p
+	}
+
+	p.valueFilters = append(p.valueFilters, valueFilter{operation: op, value: number})
+
+	return p
+}
+
+func typedValueToFloat64(value *Ydb.TypedValue) (float64, error) {
+	if value.GetValue() == nil {
+		return 0, fmt.Errorf("empty value: %w", common.ErrInvalidRequest)
+	}
+
+	v := value.GetValue()
+
+	switch typeID := value.GetType().GetTypeId(); typeID {
+	case Ydb.Type_DOUBLE:
+		return v.GetDoubleValue(), nil
+	case Ydb.Type_FLOAT:
+		return float64(v.GetFloatValue()), nil
+	case Ydb.Type_INT8, Ydb.Type_INT16, Ydb.Type_INT32:
+		return float64(v.GetInt32Value()), nil
+	case Ydb.Type_INT64:
+		return float64(v.GetInt64Value()), nil
+	case Ydb.Type_UINT8, Ydb.Type_UINT16, Ydb.Type_UINT32:
+		return float64(v.GetUint32Value()), nil
+	case Ydb.Type_UINT64:
+		return float64(v.GetUint64Value()), nil
+	default:
+		return 0, fmt.Errorf("%w, type %s", common.ErrDataTypeNotSupported, typeID)
+	}
+}
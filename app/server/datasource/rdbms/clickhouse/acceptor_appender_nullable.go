@@ -63,7 +63,13 @@ func addAcceptorAppenderFromSQLTypeNameNullable(
 	case typeName == typeString, tm.isFixedString.MatchString(typeName):
 		// Looks like []byte would be a better option here, but clickhouse driver prefers string
 		acceptors = append(acceptors, new(*string))
-		appenders = append(appenders, utils.MakeAppenderNullable[string, []byte, *array.BinaryBuilder](cc.StringToBytes()))
+
+		// Utf8 is expected when the column is converted to text by the user-defined type mapping rule
+		if ydbTypeID, err := common.YdbTypeToYdbPrimitiveTypeID(ydbType); err == nil && ydbTypeID == Ydb.Type_UTF8 {
+			appenders = append(appenders, utils.MakeAppenderNullable[string, string, *array.StringBuilder](cc.String()))
+		} else {
+			appenders = append(appenders, utils.MakeAppenderNullable[string, []byte, *array.BinaryBuilder](cc.StringToBytes()))
+		}
 	case typeName == typeDate:
 		acceptors = append(acceptors, new(*time.Time))
 
@@ -78,6 +84,8 @@ func addAcceptorAppenderFromSQLTypeNameNullable(
 				utils.MakeAppenderNullable[time.Time, string, *array.StringBuilder](dateToStringConverter{conv: cc.DateToString()}))
 		case Ydb.Type_DATE:
 			appenders = append(appenders, utils.MakeAppenderNullable[time.Time, uint16, *array.Uint16Builder](cc.Date()))
+		case Ydb.Type_DATE32:
+			appenders = append(appenders, utils.MakeAppenderNullable[time.Time, int32, *array.Int32Builder](cc.Date32()))
 		default:
 			return nil, nil, fmt.Errorf("unexpected ydb type %v with sql type %s: %w", ydbType, typeName, common.ErrDataTypeNotSupported)
 		}
@@ -95,6 +103,8 @@ func addAcceptorAppenderFromSQLTypeNameNullable(
 				utils.MakeAppenderNullable[time.Time, string, *array.StringBuilder](date32ToStringConverter{conv: cc.DateToString()}))
 		case Ydb.Type_DATE:
 			appenders = append(appenders, utils.MakeAppenderNullable[time.Time, uint16, *array.Uint16Builder](cc.Date()))
+		case Ydb.Type_DATE32:
+			appenders = append(appenders, utils.MakeAppenderNullable[time.Time, int32, *array.Int32Builder](cc.Date32()))
 		default:
 			return nil, nil, fmt.Errorf("unexpected ydb type %v with sql type %s: %w", ydbType, typeName, common.ErrDataTypeNotSupported)
 		}
@@ -113,6 +123,8 @@ func addAcceptorAppenderFromSQLTypeNameNullable(
 					dateTime64ToStringConverter{conv: cc.TimestampToString(true)}))
 		case Ydb.Type_TIMESTAMP:
 			appenders = append(appenders, utils.MakeAppenderNullable[time.Time, uint64, *array.Uint64Builder](cc.Timestamp()))
+		case Ydb.Type_TIMESTAMP64:
+			appenders = append(appenders, utils.MakeAppenderNullable[time.Time, int64, *array.Int64Builder](cc.Timestamp64()))
 		default:
 			return nil, nil, fmt.Errorf("unexpected ydb type %v with sql type %s: %w", ydbType, typeName, common.ErrDataTypeNotSupported)
 		}
@@ -130,6 +142,8 @@ func addAcceptorAppenderFromSQLTypeNameNullable(
 				utils.MakeAppenderNullable[time.Time, string, *array.StringBuilder](dateTimeToStringConverter{conv: cc.DatetimeToString()}))
 		case Ydb.Type_DATETIME:
 			appenders = append(appenders, utils.MakeAppenderNullable[time.Time, uint32, *array.Uint32Builder](cc.Datetime()))
+		case Ydb.Type_DATETIME64:
+			appenders = append(appenders, utils.MakeAppenderNullable[time.Time, int64, *array.Int64Builder](cc.Datetime64()))
 		default:
 			return nil, nil, fmt.Errorf("unexpected ydb type %v with sql type %s: %w", ydbType, typeName, common.ErrDataTypeNotSupported)
 		}
@@ -0,0 +1,127 @@
+package clickhouse
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var stringQuoteReplacer = strings.NewReplacer(`\`, `\\`, `'`, `\'`, "`", "\\`")
+
+// bindQueryArgs substitutes positional placeholders with the query arguments
+// rendered as ClickHouse literals. The native block API does not support positional arguments,
+// so the arguments are bound on the client side in the same way as clickhouse-go driver does it.
+func bindQueryArgs(query string, args []any) (string, error) {
+	if len(args) == 0 {
+		return query, nil
+	}
+
+	var (
+		sb       strings.Builder
+		argIndex int
+		start    int
+	)
+
+	for i := 0; i < len(query); i++ {
+		// skip string literals and quoted identifiers, they may contain question marks
+		if query[i] == '\'' || query[i] == '"' || query[i] == '`' {
+			end, err := skipQuoted(query, i)
+			if err != nil {
+				return "", err
+			}
+
+			i = end
+
+			continue
+		}
+
+		if query[i] != '?' {
+			continue
+		}
+
+		// escaped question mark is not a placeholder
+		if i > 0 && query[i-1] == '\\' {
+			sb.WriteString(query[start : i-1])
+			sb.WriteByte('?')
+
+			start = i + 1
+
+			continue
+		}
+
+		if argIndex >= len(args) {
+			return "", fmt.Errorf("no argument for placeholder at position %d", i)
+		}
+
+		value, err := formatQueryArg(args[argIndex])
+		if err != nil {
+			return "", fmt.Errorf("format argument #%d: %w", argIndex, err)
+		}
+
+		sb.WriteString(query[start:i])
+		sb.WriteString(value)
+
+		argIndex++
+		start = i + 1
+	}
+
+	if argIndex != len(args) {
+		return "", fmt.Errorf("%d arguments were provided, but only %d placeholders were found", len(args), argIndex)
+	}
+
+	sb.WriteString(query[start:])
+
+	return sb.String(), nil
+}
+
+// skipQuoted returns the position of the quote closing the region opened at the given position.
+// ClickHouse allows to escape quotes both with backslash and by doubling them,
+// the latter is handled as two adjacent quoted regions.
+func skipQuoted(query string, start int) (int, error) {
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			i++
+		case query[start]:
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unterminated quote at position %d", start)
+}
+
+func formatQueryArg(arg any) (string, error) {
+	switch v := arg.(type) {
+	case nil:
+		return "NULL", nil
+	case string:
+		return "'" + stringQuoteReplacer.Replace(v) + "'", nil
+	case []byte:
+		return "'" + stringQuoteReplacer.Replace(string(v)) + "'", nil
+	case bool:
+		if v {
+			return "1", nil
+		}
+
+		return "0", nil
+	case time.Time:
+		// the time zone is passed separately, so the offset is omitted from the RFC 3339 representation
+		return fmt.Sprintf("toDateTime64('%s', 6, 'UTC')", v.UTC().Format("2006-01-02T15:04:05.000000")), nil
+	case int8, int16, int32, int64, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(v), nil
+	case fmt.Stringer:
+		return "'" + stringQuoteReplacer.Replace(v.String()) + "'", nil
+	}
+
+	// optional values are passed by pointers
+	if value := reflect.ValueOf(arg); value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return "NULL", nil
+		}
+
+		return formatQueryArg(value.Elem().Interface())
+	}
+
+	return "", fmt.Errorf("unsupported argument type %T", arg)
+}
@@ -0,0 +1,98 @@
+package clickhouse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindQueryArgs(t *testing.T) {
+	type testCase struct {
+		testName string
+		query    string
+		args     []any
+		output   string
+		err      bool
+	}
+
+	text := "abc"
+
+	tcs := []testCase{
+		{
+			testName: "no_args",
+			query:    `SELECT "col" FROM "tab"`,
+			output:   `SELECT "col" FROM "tab"`,
+		},
+		{
+			testName: "numbers_and_bool",
+			query:    `SELECT "col" FROM "tab" WHERE ("a" = ?) AND ("b" > ?) AND ("c" = ?)`,
+			args:     []any{int32(-1), float64(0.5), true},
+			output:   `SELECT "col" FROM "tab" WHERE ("a" = -1) AND ("b" > 0.5) AND ("c" = 1)`,
+		},
+		{
+			testName: "strings",
+			query:    `SELECT "col" FROM "tab" WHERE ("a" = ?) AND ("b" = ?)`,
+			args:     []any{`it's \ quoted`, []byte("bytes`")},
+			output:   `SELECT "col" FROM "tab" WHERE ("a" = 'it\'s \\ quoted') AND ("b" = 'bytes\` + "`" + `')`,
+		},
+		{
+			testName: "optional_values",
+			query:    `SELECT "col" FROM "tab" WHERE ("a" = ?) AND ("b" = ?)`,
+			args:     []any{&text, (*int64)(nil)},
+			output:   `SELECT "col" FROM "tab" WHERE ("a" = 'abc') AND ("b" = NULL)`,
+		},
+		{
+			testName: "time",
+			query:    `SELECT "col" FROM "tab" WHERE "a" < ?`,
+			args:     []any{time.Date(2024, 1, 2, 3, 4, 5, 6000, time.FixedZone("UTC+3", 3*60*60))},
+			output:   `SELECT "col" FROM "tab" WHERE "a" < toDateTime64('2024-01-02T00:04:05.000006', 6, 'UTC')`,
+		},
+		{
+			testName: "escaped_placeholder",
+			query:    `SELECT "col" FROM "tab" WHERE ("a" \? "b") AND ("c" = ?)`,
+			args:     []any{uint8(1)},
+			output:   `SELECT "col" FROM "tab" WHERE ("a" ? "b") AND ("c" = 1)`,
+		},
+		{
+			testName: "quoted_question_marks",
+			query:    "SELECT `col?` FROM \"tab?\" WHERE (\"a\" = 'why?') AND (\"b\" = 'it\\'s ?') AND (\"c\" = ?)",
+			args:     []any{int64(1)},
+			output:   "SELECT `col?` FROM \"tab?\" WHERE (\"a\" = 'why?') AND (\"b\" = 'it\\'s ?') AND (\"c\" = 1)",
+		},
+		{
+			testName: "unterminated_quote",
+			query:    `SELECT "col" FROM "tab" WHERE "a" = 'abc`,
+			args:     []any{int64(1)},
+			err:      true,
+		},
+		{
+			testName: "not_enough_args",
+			query:    `SELECT "col" FROM "tab" WHERE ("a" = ?) AND ("b" = ?)`,
+			args:     []any{int64(1)},
+			err:      true,
+		},
+		{
+			testName: "too_many_args",
+			query:    `SELECT "col" FROM "tab" WHERE "a" = ?`,
+			args:     []any{int64(1), int64(2)},
+			err:      true,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			output, err := bindQueryArgs(tc.query, tc.args)
+			if tc.err {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package clickhouse
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/apache/arrow/go/v13/arrow/array"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// columnDecoder reads the column of a ClickHouse native block
+// and appends the whole column to the Arrow builder at once.
+type columnDecoder interface {
+	proto.ColResult
+	// appendValues appends decoded values to the builder; nulls is nil for non-nullable columns.
+	appendValues(builder array.Builder, nulls []uint8) error
+}
+
+type numericValue interface {
+	int8 | int16 | int32 | int64 | uint8 | uint16 | uint32 | uint64 | float32 | float64
+}
+
+type numericBuilder[T numericValue] interface {
+	AppendValues(values []T, valid []bool)
+}
+
+var _ columnDecoder = (*numericColumn[int8, proto.ColInt8, *proto.ColInt8])(nil)
+
+// numericColumn copies numbers into Arrow builder without per-value conversion,
+// because YDB numeric types have the same representation as ClickHouse ones.
+type numericColumn[T numericValue, C ~[]T, P interface {
+	*C
+	proto.ColResult
+}] struct {
+	data C
+}
+
+func (c *numericColumn[T, C, P]) Type() proto.ColumnType { return P(&c.data).Type() }
+
+func (c *numericColumn[T, C, P]) Rows() int { return len(c.data) }
+
+func (c *numericColumn[T, C, P]) Reset() { c.data = c.data[:0] }
+
+func (c *numericColumn[T, C, P]) DecodeColumn(r *proto.Reader, rows int) error {
+	return P(&c.data).DecodeColumn(r, rows)
+}
+
+func (c *numericColumn[T, C, P]) appendValues(builder array.Builder, nulls []uint8) error {
+	b, ok := builder.(numericBuilder[T])
+	if !ok {
+		return fmt.Errorf("unexpected builder %T for column of type %s", builder, c.Type())
+	}
+
+	b.AppendValues(c.data, makeValidity(nulls))
+
+	return nil
+}
+
+var _ columnDecoder = (*convertedColumn[bool, uint8, *array.Uint8Builder])(nil)
+
+// convertedColumn converts values one by one with the converters shared with the row-based read path.
+type convertedColumn[IN common.ValueType, OUT common.ValueType, AB common.ArrowBuilder[OUT]] struct {
+	proto.ColumnOf[IN]
+	conv conversion.ValuePtrConverter[IN, OUT]
+}
+
+func (c *convertedColumn[IN, OUT, AB]) appendValues(builder array.Builder, nulls []uint8) error {
+	b, ok := builder.(AB)
+	if !ok {
+		return fmt.Errorf("unexpected builder %T for column of type %s", builder, c.Type())
+	}
+
+	for i := 0; i < c.Rows(); i++ {
+		if nulls != nil && nulls[i] != 0 {
+			b.AppendNull()
+
+			continue
+		}
+
+		value := c.Row(i)
+
+		out, err := c.conv.Convert(&value)
+		if err != nil {
+			if errors.Is(err, common.ErrValueOutOfTypeBounds) {
+				b.AppendNull()
+
+				continue
+			}
+
+			return fmt.Errorf("convert value %v: %w", value, err)
+		}
+
+		b.Append(out)
+	}
+
+	return nil
+}
+
+var _ columnDecoder = (*bytesColumn)(nil)
+
+// bytesColumn serves String and FixedString columns. Values are copied directly from the block buffer,
+// since the conversion of ClickHouse strings to both YDB String and Utf8 doesn't change the bytes.
+type bytesColumn struct {
+	proto.ColResult
+	row func(i int) []byte
+}
+
+func (c *bytesColumn) appendValues(builder array.Builder, nulls []uint8) error {
+	var binaryBuilder *array.BinaryBuilder
+
+	switch b := builder.(type) {
+	case *array.BinaryBuilder:
+		binaryBuilder = b
+	case *array.StringBuilder:
+		binaryBuilder = b.BinaryBuilder
+	default:
+		return fmt.Errorf("unexpected builder %T for column of type %s", builder, c.Type())
+	}
+
+	rows := c.Rows()
+	binaryBuilder.Reserve(rows)
+
+	for i := 0; i < rows; i++ {
+		if nulls != nil && nulls[i] != 0 {
+			binaryBuilder.AppendNull()
+
+			continue
+		}
+
+		binaryBuilder.Append(c.row(i))
+	}
+
+	return nil
+}
+
+var _ columnDecoder = (*nullableColumn)(nil)
+
+// nullableColumn reads the null map followed by the values of the nested type.
+type nullableColumn struct {
+	nulls  proto.ColUInt8
+	values columnDecoder
+}
+
+func (c *nullableColumn) Type() proto.ColumnType {
+	return proto.ColumnTypeNullable.Sub(c.values.Type())
+}
+
+func (c *nullableColumn) Rows() int { return c.nulls.Rows() }
+
+func (c *nullableColumn) Reset() {
+	c.nulls.Reset()
+	c.values.Reset()
+}
+
+func (c *nullableColumn) DecodeColumn(r *proto.Reader, rows int) error {
+	if err := c.nulls.DecodeColumn(r, rows); err != nil {
+		return fmt.Errorf("decode nulls: %w", err)
+	}
+
+	if err := c.values.DecodeColumn(r, rows); err != nil {
+		return fmt.Errorf("decode values: %w", err)
+	}
+
+	return nil
+}
+
+func (c *nullableColumn) appendValues(builder array.Builder, _ []uint8) error {
+	return c.values.appendValues(builder, c.nulls)
+}
+
+func makeValidity(nulls []uint8) []bool {
+	if nulls == nil {
+		return nil
+	}
+
+	valid := make([]bool, len(nulls))
+	for i, isNull := range nulls {
+		valid[i] = isNull == 0
+	}
+
+	return valid
+}
+
+// makeColumnDecoder picks the decoder for the column of a given ClickHouse type
+// that must be represented with a given YDB type.
+//
+//nolint:gocyclo,funlen
+func makeColumnDecoder(typeName proto.ColumnType, ydbType *Ydb.Type, cc conversion.Collection) (columnDecoder, error) {
+	switch typeName.Base() {
+	case "Nullable":
+		values, err := makeColumnDecoder(typeName.Elem(), ydbType, cc)
+		if err != nil {
+			return nil, fmt.Errorf("nullable: %w", err)
+		}
+
+		return &nullableColumn{values: values}, nil
+	case typeBool:
+		return &convertedColumn[bool, uint8, *array.Uint8Builder]{ColumnOf: new(proto.ColBool), conv: cc.Bool()}, nil
+	case typeInt8:
+		return &numericColumn[int8, proto.ColInt8, *proto.ColInt8]{}, nil
+	case typeInt16:
+		return &numericColumn[int16, proto.ColInt16, *proto.ColInt16]{}, nil
+	case typeInt32:
+		return &numericColumn[int32, proto.ColInt32, *proto.ColInt32]{}, nil
+	case typeInt64:
+		return &numericColumn[int64, proto.ColInt64, *proto.ColInt64]{}, nil
+	case typeUInt8:
+		return &numericColumn[uint8, proto.ColUInt8, *proto.ColUInt8]{}, nil
+	case typeUInt16:
+		return &numericColumn[uint16, proto.ColUInt16, *proto.ColUInt16]{}, nil
+	case typeUInt32:
+		return &numericColumn[uint32, proto.ColUInt32, *proto.ColUInt32]{}, nil
+	case typeUInt64:
+		return &numericColumn[uint64, proto.ColUInt64, *proto.ColUInt64]{}, nil
+	case typeFloat32:
+		return &numericColumn[float32, proto.ColFloat32, *proto.ColFloat32]{}, nil
+	case typeFload64:
+		return &numericColumn[float64, proto.ColFloat64, *proto.ColFloat64]{}, nil
+	case typeString:
+		col := new(proto.ColStr)
+
+		return &bytesColumn{ColResult: col, row: func(i int) []byte { return col.RowBytes(i) }}, nil
+	case "FixedString":
+		size, err := strconv.Atoi(string(typeName.Elem()))
+		if err != nil {
+			return nil, fmt.Errorf("parse size of '%s': %w", typeName, err)
+		}
+
+		col := new(proto.ColFixedStr)
+		col.SetSize(size)
+
+		return &bytesColumn{ColResult: col, row: func(i int) []byte { return col.Row(i) }}, nil
+	case typeDate:
+		return makeDateColumnDecoder(new(proto.ColDate), dateToStringConverter{conv: cc.DateToString()}, ydbType, cc)
+	case typeDate32:
+		return makeDateColumnDecoder(new(proto.ColDate32), date32ToStringConverter{conv: cc.DateToString()}, ydbType, cc)
+	case "DateTime":
+		// time zone doesn't matter, because the values are converted into UTC anyway
+		col := &proto.ColDateTime{Location: time.UTC}
+
+		ydbTypeID, err := common.YdbTypeToYdbPrimitiveTypeID(ydbType)
+		if err != nil {
+			return nil, fmt.Errorf("ydb type to ydb primitive type id: %w", err)
+		}
+
+		switch ydbTypeID {
+		case Ydb.Type_UTF8:
+			return &convertedColumn[time.Time, string, *array.StringBuilder]{
+				ColumnOf: col, conv: dateTimeToStringConverter{conv: cc.DatetimeToString()}}, nil
+		case Ydb.Type_DATETIME:
+			return &convertedColumn[time.Time, uint32, *array.Uint32Builder]{ColumnOf: col, conv: cc.Datetime()}, nil
+		case Ydb.Type_DATETIME64:
+			return &convertedColumn[time.Time, int64, *array.Int64Builder]{ColumnOf: col, conv: cc.Datetime64()}, nil
+		default:
+			return nil, fmt.Errorf("unexpected ydb type %v with sql type %s: %w", ydbType, typeName, common.ErrDataTypeNotSupported)
+		}
+	case "DateTime64":
+		precision, err := strconv.ParseUint(strings.TrimSpace(strings.SplitN(string(typeName.Elem()), ",", 2)[0]), 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("parse precision of '%s': %w", typeName, err)
+		}
+
+		col := (&proto.ColDateTime64{Location: time.UTC}).WithPrecision(proto.Precision(precision))
+
+		ydbTypeID, err := common.YdbTypeToYdbPrimitiveTypeID(ydbType)
+		if err != nil {
+			return nil, fmt.Errorf("ydb type to ydb primitive type id: %w", err)
+		}
+
+		switch ydbTypeID {
+		case Ydb.Type_UTF8:
+			return &convertedColumn[time.Time, string, *array.StringBuilder]{
+				ColumnOf: col, conv: dateTime64ToStringConverter{conv: cc.TimestampToString(true)}}, nil
+		case Ydb.Type_TIMESTAMP:
+			return &convertedColumn[time.Time, uint64, *array.Uint64Builder]{ColumnOf: col, conv: cc.Timestamp()}, nil
+		case Ydb.Type_TIMESTAMP64:
+			return &convertedColumn[time.Time, int64, *array.Int64Builder]{ColumnOf: col, conv: cc.Timestamp64()}, nil
+		default:
+			return nil, fmt.Errorf("unexpected ydb type %v with sql type %s: %w", ydbType, typeName, common.ErrDataTypeNotSupported)
+		}
+	default:
+		return nil, fmt.Errorf("convert type '%s': %w", typeName, common.ErrDataTypeNotSupported)
+	}
+}
+
+func makeDateColumnDecoder(
+	col proto.ColumnOf[time.Time],
+	toString conversion.ValuePtrConverter[time.Time, string],
+	ydbType *Ydb.Type,
+	cc conversion.Collection,
+) (columnDecoder, error) {
+	ydbTypeID, err := common.YdbTypeToYdbPrimitiveTypeID(ydbType)
+	if err != nil {
+		return nil, fmt.Errorf("ydb type to ydb primitive type id: %w", err)
+	}
+
+	switch ydbTypeID {
+	case Ydb.Type_UTF8:
+		return &convertedColumn[time.Time, string, *array.StringBuilder]{ColumnOf: col, conv: toString}, nil
+	case Ydb.Type_DATE:
+		return &convertedColumn[time.Time, uint16, *array.Uint16Builder]{ColumnOf: col, conv: cc.Date()}, nil
+	case Ydb.Type_DATE32:
+		return &convertedColumn[time.Time, int32, *array.Int32Builder]{ColumnOf: col, conv: cc.Date32()}, nil
+	default:
+		return nil, fmt.Errorf("unexpected ydb type %v with sql type %s: %w", ydbType, col.Type(), common.ErrDataTypeNotSupported)
+	}
+}
@@ -0,0 +1,178 @@
+package clickhouse
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestColumnDecoder(t *testing.T) {
+	type testCase struct {
+		testName string
+		typeName proto.ColumnType
+		ydbType  *Ydb.Type
+		input    proto.ColInput
+		expected []any // nil stands for NULL
+	}
+
+	nullableStr := proto.NewColNullable[string](new(proto.ColStr))
+	nullableStr.Append(proto.NewNullable("abc"))
+	nullableStr.Append(proto.Null[string]())
+
+	nullableInt := proto.NewColNullable[int32](new(proto.ColInt32))
+	nullableInt.Append(proto.Null[int32]())
+	nullableInt.Append(proto.NewNullable[int32](-2))
+
+	fixedStr := new(proto.ColFixedStr)
+	fixedStr.SetSize(2)
+	fixedStr.Append([]byte("ab"))
+
+	date32 := new(proto.ColDate32)
+	date32.Append(time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC))
+	date32.Append(time.Date(1970, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	dateTime := new(proto.ColDateTime)
+	dateTime.Append(time.Date(1988, 11, 20, 12, 55, 28, 0, time.UTC))
+
+	dateTime64 := (&proto.ColDateTime64{}).WithPrecision(proto.PrecisionMilli)
+	dateTime64.Append(time.Date(1988, 11, 20, 12, 55, 28, 123000000, time.UTC))
+
+	tcs := []testCase{
+		{
+			testName: "int64",
+			typeName: "Int64",
+			ydbType:  common.MakePrimitiveType(Ydb.Type_INT64),
+			input:    proto.ColInt64{1, -1},
+			expected: []any{int64(1), int64(-1)},
+		},
+		{
+			testName: "nullable_int32",
+			typeName: "Nullable(Int32)",
+			ydbType:  common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INT32)),
+			input:    nullableInt,
+			expected: []any{nil, int32(-2)},
+		},
+		{
+			testName: "bool",
+			typeName: "Bool",
+			ydbType:  common.MakePrimitiveType(Ydb.Type_BOOL),
+			input:    proto.ColBool{true, false},
+			expected: []any{uint8(1), uint8(0)},
+		},
+		{
+			testName: "nullable_string_to_utf8",
+			typeName: "Nullable(String)",
+			ydbType:  common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8)),
+			input:    nullableStr,
+			expected: []any{"abc", nil},
+		},
+		{
+			testName: "fixed_string",
+			typeName: "FixedString(2)",
+			ydbType:  common.MakePrimitiveType(Ydb.Type_STRING),
+			input:    fixedStr,
+			expected: []any{[]byte("ab")},
+		},
+		{
+			testName: "date32_out_of_bounds",
+			typeName: "Date32",
+			ydbType:  common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_DATE)),
+			input:    date32,
+			expected: []any{nil, uint16(1)},
+		},
+		{
+			testName: "datetime_with_time_zone",
+			typeName: "DateTime('Europe/Moscow')",
+			ydbType:  common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_DATETIME)),
+			input:    dateTime,
+			expected: []any{uint32(596033728)},
+		},
+		{
+			testName: "datetime64_to_string",
+			typeName: "DateTime64(3)",
+			ydbType:  common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8)),
+			input:    dateTime64,
+			expected: []any{"1988-11-20T12:55:28.123Z"},
+		},
+	}
+
+	cc := conversion.NewCollection(&config.TConversionConfig{})
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			decoder, err := makeColumnDecoder(tc.typeName, tc.ydbType, cc)
+			require.NoError(t, err)
+
+			var buf proto.Buffer
+
+			tc.input.EncodeColumn(&buf)
+
+			require.NoError(t, decoder.DecodeColumn(proto.NewReader(bytes.NewReader(buf.Buf)), tc.input.Rows()))
+			require.Equal(t, len(tc.expected), decoder.Rows())
+
+			builders, err := common.YdbTypesToArrowBuilders([]*Ydb.Type{tc.ydbType}, memory.DefaultAllocator)
+			require.NoError(t, err)
+
+			defer builders[0].Release()
+
+			require.NoError(t, decoder.appendValues(builders[0], nil))
+
+			actual := builders[0].NewArray()
+			defer actual.Release()
+
+			require.Equal(t, tc.expected, arrayValues(actual))
+		})
+	}
+
+	t.Run("unsupported_type", func(t *testing.T) {
+		_, err := makeColumnDecoder("UUID", common.MakePrimitiveType(Ydb.Type_UTF8), cc)
+		require.ErrorIs(t, err, common.ErrDataTypeNotSupported)
+	})
+}
+
+func arrayValues(arr arrow.Array) []any {
+	out := make([]any, 0, arr.Len())
+
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			out = append(out, nil)
+
+			continue
+		}
+
+		switch a := arr.(type) {
+		case *array.Int32:
+			out = append(out, a.Value(i))
+		case *array.Int64:
+			out = append(out, a.Value(i))
+		case *array.Uint8:
+			out = append(out, a.Value(i))
+		case *array.Uint16:
+			out = append(out, a.Value(i))
+		case *array.Uint32:
+			out = append(out, a.Value(i))
+		case *array.String:
+			out = append(out, a.Value(i))
+		case *array.Binary:
+			out = append(out, a.Value(i))
+		default:
+			panic(a)
+		}
+	}
+
+	return out
+}
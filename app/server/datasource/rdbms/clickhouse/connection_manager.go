@@ -9,6 +9,7 @@ import (
 
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
@@ -18,6 +19,7 @@ var _ rdbms_utils.ConnectionManager = (*connectionManager)(nil)
 type connectionManager struct {
 	rdbms_utils.ConnectionManagerBase
 	cfg *config.TClickHouseConfig
+	cc  conversion.Collection
 }
 
 func (c *connectionManager) Make(
@@ -34,6 +36,17 @@ func (c *connectionManager) Make(
 
 	switch params.DataSourceInstance.Protocol {
 	case api_common.EGenericProtocol_NATIVE:
+		// table data is read in blocks, the other queries are served by the row-based driver
+		if params.QueryPhase == rdbms_utils.QueryPhaseReadSplits {
+			conn, err = makeConnectionNativeColumnar(
+				params.Ctx, params.Logger, c.cfg, params.DataSourceInstance, params.TableName, c.QueryLoggerFactory.Make(params.Logger), c.cc)
+			if err != nil {
+				return nil, fmt.Errorf("make connection native columnar: %w", err)
+			}
+
+			break
+		}
+
 		conn, err = makeConnectionNative(
 			params.Ctx, params.Logger, c.cfg, params.DataSourceInstance, params.TableName, c.QueryLoggerFactory.Make(params.Logger))
 		if err != nil {
@@ -61,6 +74,7 @@ func (*connectionManager) Release(_ context.Context, logger *zap.Logger, cs []rd
 func NewConnectionManager(
 	cfg *config.TClickHouseConfig,
 	base rdbms_utils.ConnectionManagerBase,
+	cc conversion.Collection,
 ) rdbms_utils.ConnectionManager {
-	return &connectionManager{ConnectionManagerBase: base, cfg: cfg}
+	return &connectionManager{ConnectionManagerBase: base, cfg: cfg, cc: cc}
 }
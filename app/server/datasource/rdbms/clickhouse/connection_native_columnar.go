@@ -0,0 +1,372 @@
+package clickhouse
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"go.uber.org/zap"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// the same as the default read timeout of clickhouse-go driver
+const readTimeoutNativeColumnar = 5 * time.Minute
+
+var _ proto.Result = (*blockResult)(nil)
+
+// blockResult decodes the blocks of ClickHouse native protocol and converts them into Arrow records.
+type blockResult struct {
+	ydbColumns   []*Ydb.Column
+	emptyColumns bool
+	cc           conversion.Collection
+	schema       *arrow.Schema
+	builders     []array.Builder
+	decoders     []columnDecoder // inferred from the first block
+}
+
+func (r *blockResult) DecodeResult(reader *proto.Reader, version int, b proto.Block) error {
+	if r.decoders != nil && b.Columns != len(r.decoders) {
+		return fmt.Errorf("block has %d columns, but %d columns were received before", b.Columns, len(r.decoders))
+	}
+
+	if !r.emptyColumns && b.Columns != len(r.ydbColumns) {
+		return fmt.Errorf("block has %d columns, but %d columns were requested", b.Columns, len(r.ydbColumns))
+	}
+
+	decoders := r.decoders
+
+	for i := 0; i < b.Columns; i++ {
+		name, err := reader.Str()
+		if err != nil {
+			return fmt.Errorf("column #%d name: %w", i, err)
+		}
+
+		typeName, err := reader.Str()
+		if err != nil {
+			return fmt.Errorf("column '%s' type: %w", name, err)
+		}
+
+		if proto.FeatureCustomSerialization.In(version) {
+			customSerialization, err := reader.Bool()
+			if err != nil {
+				return fmt.Errorf("column '%s' custom serialization: %w", name, err)
+			}
+
+			if customSerialization {
+				return fmt.Errorf("column '%s' has custom serialization: %w", name, common.ErrDataTypeNotSupported)
+			}
+		}
+
+		if r.decoders == nil {
+			var ydbType *Ydb.Type
+			if !r.emptyColumns {
+				ydbType = r.ydbColumns[i].Type
+			}
+
+			decoder, err := makeColumnDecoder(proto.ColumnType(typeName), ydbType, r.cc)
+			if err != nil {
+				return fmt.Errorf("make decoder for column '%s': %w", name, err)
+			}
+
+			decoders = append(decoders, decoder)
+		}
+
+		decoders[i].Reset()
+
+		if b.Rows == 0 {
+			continue
+		}
+
+		if err := decoders[i].DecodeColumn(reader, b.Rows); err != nil {
+			return fmt.Errorf("decode column '%s': %w", name, err)
+		}
+	}
+
+	r.decoders = decoders
+
+	return nil
+}
+
+// makeRecord converts the last decoded block into Arrow record
+func (r *blockResult) makeRecord(rows int) (arrow.Record, error) {
+	if r.emptyColumns {
+		return array.NewRecord(r.schema, nil, int64(rows)), nil
+	}
+
+	columns := make([]arrow.Array, 0, len(r.builders))
+
+	defer func() {
+		for _, column := range columns {
+			column.Release()
+		}
+	}()
+
+	for i, builder := range r.builders {
+		builder.Reserve(rows)
+
+		if err := r.decoders[i].appendValues(builder, nil); err != nil {
+			return nil, fmt.Errorf("append values of column '%s': %w", r.ydbColumns[i].Name, err)
+		}
+
+		columns = append(columns, builder.NewArray())
+	}
+
+	return array.NewRecord(r.schema, columns, int64(rows)), nil
+}
+
+func (r *blockResult) release() {
+	for _, builder := range r.builders {
+		builder.Release()
+	}
+}
+
+func newBlockResult(params *rdbms_utils.QueryParams, cc conversion.Collection) (*blockResult, error) {
+	r := &blockResult{
+		ydbColumns:   params.YdbColumns,
+		emptyColumns: params.EmptyColumns,
+		cc:           cc,
+	}
+
+	if r.emptyColumns {
+		r.schema = arrow.NewSchema(nil, nil)
+
+		return r, nil
+	}
+
+	var err error
+
+	r.schema, err = common.YDBColumnsToArrowSchema(r.ydbColumns)
+	if err != nil {
+		return nil, fmt.Errorf("YDB columns to Arrow schema: %w", err)
+	}
+
+	r.builders, err = common.YdbTypesToArrowBuilders(common.YDBColumnsToYDBTypes(r.ydbColumns), params.Allocator)
+	if err != nil {
+		return nil, fmt.Errorf("YDB types to Arrow builders: %w", err)
+	}
+
+	return r, nil
+}
+
+var _ rdbms_utils.Columns = (*columnsNative)(nil)
+
+// columnsNative receives the blocks that are converted into Arrow records in the background.
+type columnsNative struct {
+	cancel   context.CancelFunc
+	records  chan arrow.Record
+	record   arrow.Record
+	queryErr error // written by the query goroutine before the records channel is closed
+	err      error
+}
+
+func (c *columnsNative) Next() bool {
+	if c.record != nil {
+		c.record.Release()
+		c.record = nil
+	}
+
+	record, ok := <-c.records
+	if !ok {
+		c.err = c.queryErr
+
+		return false
+	}
+
+	c.record = record
+
+	return true
+}
+
+func (c *columnsNative) Record() arrow.Record {
+	return c.record
+}
+
+func (c *columnsNative) Err() error {
+	return c.err
+}
+
+func (c *columnsNative) Close() error {
+	c.cancel()
+
+	for record := range c.records {
+		record.Release()
+	}
+
+	if c.record != nil {
+		c.record.Release()
+		c.record = nil
+	}
+
+	return nil
+}
+
+var _ rdbms_utils.Connection = (*connectionNativeColumnar)(nil)
+
+// connectionNativeColumnar reads the data with the block API of ClickHouse native protocol,
+// so that the whole columns are converted into Arrow arrays without per-row processing.
+type connectionNativeColumnar struct {
+	client             *ch.Client
+	cc                 conversion.Collection
+	queryLogger        common.QueryLogger
+	dataSourceInstance *api_common.TGenericDataSourceInstance
+	tableName          string
+}
+
+func (c *connectionNativeColumnar) Query(params *rdbms_utils.QueryParams) (*rdbms_utils.QueryResult, error) {
+	if len(params.YdbColumns) == 0 {
+		return nil, fmt.Errorf("columnar connection requires the YDB columns: %w", common.ErrInvariantViolation)
+	}
+
+	c.queryLogger.Dump(params.QueryText, params.QueryArgs.Values()...)
+
+	queryText, err := bindQueryArgs(params.QueryText, params.QueryArgs.Values())
+	if err != nil {
+		return nil, fmt.Errorf("bind query args: %w", err)
+	}
+
+	result, err := newBlockResult(params, c.cc)
+	if err != nil {
+		return nil, fmt.Errorf("new block result: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(params.Ctx)
+
+	columns := &columnsNative{
+		cancel:  cancel,
+		records: make(chan arrow.Record, 1),
+	}
+
+	// the first block containing only the header is received when the query is started successfully
+	started := make(chan error, 1)
+
+	go func() {
+		defer close(columns.records)
+		defer result.release()
+
+		isStarted := false
+
+		err := c.client.Do(ctx, ch.Query{
+			Body:   queryText,
+			Result: result,
+			OnResult: func(ctx context.Context, block proto.Block) error {
+				if !isStarted {
+					isStarted = true
+					started <- nil
+				}
+
+				if block.Rows == 0 {
+					return nil
+				}
+
+				record, err := result.makeRecord(block.Rows)
+				if err != nil {
+					return fmt.Errorf("make record: %w", err)
+				}
+
+				select {
+				case columns.records <- record:
+					return nil
+				case <-ctx.Done():
+					record.Release()
+
+					return ctx.Err()
+				}
+			},
+		})
+
+		if !isStarted {
+			started <- err
+		}
+
+		if err != nil {
+			columns.queryErr = fmt.Errorf("query: %w", err)
+		}
+	}()
+
+	if err := <-started; err != nil {
+		common.LogCloserError(params.Logger, columns, "close columns")
+
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return &rdbms_utils.QueryResult{
+		Columns: columns,
+	}, nil
+}
+
+func (c *connectionNativeColumnar) DataSourceInstance() *api_common.TGenericDataSourceInstance {
+	return c.dataSourceInstance
+}
+
+func (c *connectionNativeColumnar) TableName() string {
+	return c.tableName
+}
+
+func (c *connectionNativeColumnar) Logger() *zap.Logger {
+	return c.queryLogger.Logger
+}
+
+func (c *connectionNativeColumnar) Close() error {
+	return c.client.Close()
+}
+
+func makeConnectionNativeColumnar(
+	ctx context.Context,
+	logger *zap.Logger,
+	cfg *config.TClickHouseConfig,
+	dsi *api_common.TGenericDataSourceInstance,
+	tableName string,
+	queryLogger common.QueryLogger,
+	cc conversion.Collection,
+) (rdbms_utils.Connection, error) {
+	opts := ch.Options{
+		Address:  common.EndpointToString(dsi.GetEndpoint()),
+		Database: dsi.Database,
+		User:     dsi.Credentials.GetBasic().Username,
+		Password: dsi.Credentials.GetBasic().Password,
+		// TODO: make it configurable via Connector API
+		Compression: ch.CompressionLZ4,
+		DialTimeout: common.MustDurationFromString(cfg.OpenConnectionTimeout),
+		ReadTimeout: readTimeoutNativeColumnar,
+	}
+
+	if dsi.UseTls {
+		opts.TLS = &tls.Config{
+			InsecureSkipVerify: false,
+		}
+	}
+
+	client, err := ch.Dial(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse dial: %w", err)
+	}
+
+	pingCtx, pingCtxCancel := context.WithTimeout(ctx, common.MustDurationFromString(cfg.PingConnectionTimeout))
+	defer pingCtxCancel()
+
+	if err := client.Ping(pingCtx); err != nil {
+		common.LogCloserError(logger, client, "close clickhouse client")
+
+		return nil, fmt.Errorf("client ping: %w", err)
+	}
+
+	return &connectionNativeColumnar{
+		client:             client,
+		cc:                 cc,
+		queryLogger:        queryLogger,
+		dataSourceInstance: dsi,
+		tableName:          tableName,
+	}, nil
+}
@@ -0,0 +1,35 @@
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestBlockResultAllocator(t *testing.T) {
+	allocator := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer allocator.AssertSize(t, 0)
+
+	params := &rdbms_utils.QueryParams{
+		YdbColumns: []*Ydb.Column{{Name: "id", Type: common.MakePrimitiveType(Ydb.Type_INT32)}},
+		Allocator:  allocator,
+	}
+
+	result, err := newBlockResult(params, conversion.NewCollection(&config.TConversionConfig{}))
+	require.NoError(t, err)
+
+	defer result.release()
+
+	// the memory of the builders is accounted by the allocator of the request
+	result.builders[0].(*array.Int32Builder).Append(1)
+	require.Positive(t, allocator.CurrentAlloc())
+}
@@ -107,6 +107,33 @@ func (sqlFormatter) RenderBetween(value, least, greatest string) (string, error)
 	return fmt.Sprintf("%s BETWEEN %s AND %s", value, least, greatest), nil
 }
 
+func (sqlFormatter) FormatTextCast(valueExpr string) (string, error) {
+	return fmt.Sprintf("toString(%s)", valueExpr), nil
+}
+
+// Conversion functions keep nullability of the argument
+var aggregationCastTemplates = map[Ydb.Type_PrimitiveTypeId]string{
+	Ydb.Type_INT64:  "toInt64(%s)",
+	Ydb.Type_UINT64: "toUInt64(%s)",
+	Ydb.Type_DOUBLE: "toFloat64(%s)",
+}
+
+func (sqlFormatter) SupportedAggregations() []api_service_protos.TSelect_TAggregation_EFunction {
+	return rdbms_utils.AllAggregations
+}
+
+func (sqlFormatter) FormatAggregation(
+	function api_service_protos.TSelect_TAggregation_EFunction,
+	argExpr string,
+	resultType *Ydb.Type,
+) (string, error) {
+	return rdbms_utils.FormatAggregationDefault(function, argExpr, resultType, aggregationCastTemplates)
+}
+
+func (f sqlFormatter) FormatOrderBy(orderBy *api_service_protos.TSelect_TOrderBy) (string, error) {
+	return rdbms_utils.FormatOrderByDefault(f, orderBy)
+}
+
 func NewSQLFormatter(cfg *config.TPushdownConfig) rdbms_utils.SQLFormatter {
 	return sqlFormatter{cfg: cfg}
 }
@@ -465,6 +465,7 @@ func TestMakeSelectQuery(t *testing.T) {
 			readSplitsQuery, err := rdbms_utils.MakeSelectQuery(
 				context.Background(),
 				logger, formatter,
+				NewTypeMapper(),
 				&api_service_protos.TSplit{Select: tc.selectReq},
 				api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
 				tc.selectReq.From.Table,
@@ -12,11 +12,13 @@ import (
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
 var _ datasource.TypeMapper = typeMapper{}
+var _ datasource.AggregationTypeMapper = typeMapper{}
 
 type typeMapper struct {
 	isFixedString *regexp.Regexp
@@ -106,11 +108,13 @@ func (tm typeMapper) SQLTypeToYDBColumn(
 	case typeName == "String", tm.isFixedString.MatchString(typeName):
 		ydbType = common.MakePrimitiveType(Ydb.Type_STRING)
 	case typeName == "Date", typeName == "Date32":
-		// NOTE: ClickHouse's Date32 value range is much more wide than YDB's Date value range
+		// NOTE: ClickHouse's Date32 value range is much more wide than YDB's Date value range,
+		// but it fits into YDB's Date32 value range
 		ydbType, err = common.MakeYdbDateTimeType(Ydb.Type_DATE, rules.GetDateTimeFormat())
 		nullable = nullable || rules.GetDateTimeFormat() == api_service_protos.EDateTimeFormat_YQL_FORMAT
 	case tm.isDateTime64.MatchString(typeName):
-		// NOTE: ClickHouse's DateTime64 value range is much more wide than YDB's Timestamp value range
+		// NOTE: ClickHouse's DateTime64 value range is much more wide than YDB's Timestamp value range,
+		// but it fits into YDB's Timestamp64 value range
 		ydbType, err = common.MakeYdbDateTimeType(Ydb.Type_TIMESTAMP, rules.GetDateTimeFormat())
 		nullable = nullable || rules.GetDateTimeFormat() == api_service_protos.EDateTimeFormat_YQL_FORMAT
 	case tm.isDateTime.MatchString(typeName):
@@ -234,6 +238,17 @@ func (c dateTime64ToStringConverter) Convert(in *time.Time) (string, error) {
 	return c.conv.Convert(saturateDateTime(in, minClickHouseDatetime64, maxClickHouseDatetime64))
 }
 
+// The results are converted with aggregationCastTemplates, that keep nullability of the argument
+var aggregationResultTypeNames = map[Ydb.Type_PrimitiveTypeId]string{
+	Ydb.Type_INT64:  "Int64",
+	Ydb.Type_UINT64: "UInt64",
+	Ydb.Type_DOUBLE: "Float64",
+}
+
+func (tm typeMapper) AggregationToYDBColumn(agg *api_service_protos.TSelect_TAggregation) (*Ydb.Column, error) {
+	return rdbms_utils.AggregationToYDBColumnDefault(tm, agg, aggregationResultTypeNames)
+}
+
 func NewTypeMapper() datasource.TypeMapper {
 	return typeMapper{
 		isFixedString: regexp.MustCompile(`FixedString\([0-9]+\)`),
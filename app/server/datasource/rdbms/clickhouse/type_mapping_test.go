@@ -0,0 +1,68 @@
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestAggregationToYDBColumn(t *testing.T) {
+	type testCase struct {
+		name     string
+		function api_service_protos.TSelect_TAggregation_EFunction
+		argument *Ydb.Type
+		output   *Ydb.Type
+	}
+
+	tcs := []testCase{
+		{
+			name:     "count",
+			function: api_service_protos.TSelect_TAggregation_COUNT,
+			output:   common.MakePrimitiveType(Ydb.Type_INT64),
+		},
+		{
+			name:     "sum_unsigned",
+			function: api_service_protos.TSelect_TAggregation_SUM,
+			argument: common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UINT32)),
+			output:   common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UINT64)),
+		},
+		{
+			name:     "avg",
+			function: api_service_protos.TSelect_TAggregation_AVG,
+			argument: common.MakePrimitiveType(Ydb.Type_INT8),
+			output:   common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_DOUBLE)),
+		},
+		{
+			name:     "max",
+			function: api_service_protos.TSelect_TAggregation_MAX,
+			argument: common.MakePrimitiveType(Ydb.Type_DATE),
+			output:   common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_DATE)),
+		},
+	}
+
+	typeMapper, ok := NewTypeMapper().(datasource.AggregationTypeMapper)
+	require.True(t, ok)
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			agg := &api_service_protos.TSelect_TAggregation{Function: tc.function, Alias: "result"}
+			if tc.argument != nil {
+				agg.Argument = &Ydb.Column{Name: "col", Type: tc.argument}
+			}
+
+			column, err := typeMapper.AggregationToYDBColumn(agg)
+			require.NoError(t, err)
+			require.Equal(t, "result", column.Name)
+			require.True(t, proto.Equal(tc.output, column.Type), column.Type)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
 	"github.com/ydb-platform/fq-connector-go/app/server/observation"
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils/retry"
@@ -25,20 +26,23 @@ type Preset struct {
 	SchemaProvider    rdbms_utils.SchemaProvider
 	SplitProvider     rdbms_utils.SplitProvider
 	RetrierSet        *retry.RetrierSet
+	// Optional, shared between the data sources
+	TextCastColumnsCache *TextCastColumnsCache
 }
 
 var _ datasource.DataSource[any] = (*dataSourceImpl)(nil)
 
 type dataSourceImpl struct {
-	typeMapper          datasource.TypeMapper
-	sqlFormatter        rdbms_utils.SQLFormatter
-	connectionManager   rdbms_utils.ConnectionManager
-	schemaProvider      rdbms_utils.SchemaProvider
-	splitProvider       rdbms_utils.SplitProvider
-	retrierSet          *retry.RetrierSet
-	converterCollection conversion.Collection
-	observationStorage  observation.Storage
-	logger              *zap.Logger
+	typeMapper           datasource.TypeMapper
+	sqlFormatter         rdbms_utils.SQLFormatter
+	connectionManager    rdbms_utils.ConnectionManager
+	schemaProvider       rdbms_utils.SchemaProvider
+	splitProvider        rdbms_utils.SplitProvider
+	retrierSet           *retry.RetrierSet
+	textCastColumnsCache *TextCastColumnsCache
+	converterCollection  conversion.Collection
+	observationStorage   observation.Storage
+	logger               *zap.Logger
 }
 
 func (ds *dataSourceImpl) DescribeTable(
@@ -46,13 +50,20 @@ func (ds *dataSourceImpl) DescribeTable(
 	logger *zap.Logger,
 	request *api_service_protos.TDescribeTableRequest,
 ) (*api_service_protos.TDescribeTableResponse, error) {
+	if request.Query != "" && !ds.sqlFormatter.SupportsNativeQuery() {
+		return nil, fmt.Errorf("native query: %w", common.ErrUnimplementedOperation)
+	}
+
 	// Pass ConnectionManager to SchemaProvider - it will decide whether to create a connection
 	schema, err := ds.schemaProvider.GetSchema(ctx, logger, ds.connectionManager, request)
 	if err != nil {
 		return nil, fmt.Errorf("get schema: %w", err)
 	}
 
-	return &api_service_protos.TDescribeTableResponse{Schema: schema}, nil
+	return &api_service_protos.TDescribeTableResponse{
+		Schema:       schema,
+		Capabilities: rdbms_utils.DescribeCapabilities(ds.sqlFormatter, ds.splitProvider),
+	}, nil
 }
 
 func (ds *dataSourceImpl) ListSplits(
@@ -71,6 +82,20 @@ func (ds *dataSourceImpl) ListSplits(
 		ResultChan:            resultChan,
 	}
 
+	if slct.GetFrom().GetQuery() != "" && !ds.sqlFormatter.SupportsNativeQuery() {
+		return fmt.Errorf("native query: %w", common.ErrUnimplementedOperation)
+	}
+
+	if len(slct.GetFrom().GetJoins()) > 0 {
+		if !ds.sqlFormatter.SupportsJoins() {
+			return fmt.Errorf("joins: %w", common.ErrUnimplementedOperation)
+		}
+
+		if err := rdbms_utils.ValidateJoinColumns(ctx, logger, ds.schemaProvider, ds.connectionManager, slct); err != nil {
+			return fmt.Errorf("validate join columns: %w", err)
+		}
+	}
+
 	if err := ds.splitProvider.ListSplits(params); err != nil {
 		return fmt.Errorf("list splits: %w", err)
 	}
@@ -118,10 +143,43 @@ func (ds *dataSourceImpl) ReadSplit(
 
 	defer ds.connectionManager.Release(ctx, logger, cs)
 
+	sqlFormatter, err := ds.makeSQLFormatter(ctx, logger, request, split)
+	if err != nil {
+		return fmt.Errorf("make sql formatter: %w", err)
+	}
+
+	// generate SQL queries
+	queries := make([]*rdbms_utils.SelectQuery, len(cs))
+
+	for i, conn := range cs {
+		queries[i], err = rdbms_utils.MakeSelectQuery(
+			ctx,
+			logger,
+			sqlFormatter,
+			ds.typeMapper,
+			split,
+			request.Filtering,
+			conn.TableName(),
+		)
+		if err != nil {
+			return fmt.Errorf("make select query: %w", err)
+		}
+
+		// the Arrow records built by the connection are accounted in the memory budget of the request
+		queries[i].Allocator = sinkFactory.Allocator()
+	}
+
+	// The part of the predicate that was not pushed down is the same for every connection
+	residualFilter, err := filtering.NewResidualFilter(logger, queries[0].ResidualPredicate, split.Select.What)
+	if err != nil {
+		return fmt.Errorf("new residual filter: %w", err)
+	}
+
 	sinkParams := make([]*paging.SinkParams, len(cs))
 	for i, conn := range cs {
 		sinkParams[i] = &paging.SinkParams{
-			Logger: conn.Logger(),
+			Logger:         conn.Logger(),
+			ResidualFilter: residualFilter,
 		}
 	}
 
@@ -131,27 +189,23 @@ func (ds *dataSourceImpl) ReadSplit(
 		return fmt.Errorf("make sinks: %w", err)
 	}
 
+	// The number of rows can't be limited before the residual filtering
+	var limiter *rowsLimiter
+	if queries[0].ResidualPredicate == nil {
+		limiter = newRowsLimiter(split.Select.GetLimit())
+	} else {
+		limiter = newRowsLimiter(nil)
+	}
+
 	// Read data from every connection in a distinct goroutine.
 	group := errgroup.Group{}
 
 	for i, conn := range cs {
 		conn := conn
+		query := queries[i]
 		sink := sinks[i]
 
 		group.Go(func() error {
-			// generate SQL query
-			query, err := rdbms_utils.MakeSelectQuery(
-				ctx,
-				logger,
-				ds.sqlFormatter,
-				split,
-				request.Filtering,
-				conn.TableName(),
-			)
-			if err != nil {
-				return fmt.Errorf("make select query: %w", err)
-			}
-
 			annotatedLogger, outgoingQueryID, err := ds.observationStorage.CreateOutgoingQuery(
 				ctx, logger, incomingQueryID, conn.DataSourceInstance(), query.QueryText, query.QueryArgs.Values())
 			if err != nil {
@@ -159,7 +213,7 @@ func (ds *dataSourceImpl) ReadSplit(
 			}
 
 			// execute query
-			rowsRead, err := ds.doReadSplitSingleConn(ctx, annotatedLogger, query, sink, conn)
+			rowsRead, err := ds.doReadSplitSingleConn(ctx, annotatedLogger, query, sink, conn, limiter)
 			if err != nil {
 				// register error
 				cancelErr := ds.observationStorage.CancelOutgoingQuery(
@@ -188,12 +242,86 @@ func (ds *dataSourceImpl) ReadSplit(
 	return nil
 }
 
+// makeSQLFormatter takes into account user-defined type mapping rules:
+// the columns mapped by these rules must be converted into text on the data source side.
+func (ds *dataSourceImpl) makeSQLFormatter(
+	ctx context.Context,
+	logger *zap.Logger,
+	request *api_service_protos.TReadSplitsRequest,
+	split *api_service_protos.TSplit,
+) (rdbms_utils.SQLFormatter, error) {
+	if !rdbms_utils.HasTypeMappingRules(request.TypeMappingSettings) {
+		return ds.sqlFormatter, nil
+	}
+
+	// The columns of the joined tables are rendered by the default formatting routines
+	if len(split.Select.GetFrom().GetJoins()) > 0 {
+		return nil, fmt.Errorf("type mapping rules for joined tables: %w", common.ErrUnimplementedOperation)
+	}
+
+	provider, ok := ds.schemaProvider.(rdbms_utils.TextCastColumnsProvider)
+	if !ok {
+		return ds.sqlFormatter, nil
+	}
+
+	describeTableRequest := &api_service_protos.TDescribeTableRequest{
+		DataSourceInstance:  split.Select.DataSourceInstance,
+		Table:               split.Select.From.Table,
+		TypeMappingSettings: request.TypeMappingSettings,
+		Query:               split.Select.From.Query,
+	}
+
+	columns, err := ds.getTextCastColumns(ctx, logger, provider, describeTableRequest)
+	if err != nil {
+		return nil, fmt.Errorf("get text cast columns: %w", err)
+	}
+
+	if len(columns) == 0 {
+		return ds.sqlFormatter, nil
+	}
+
+	return rdbms_utils.NewTextCastFormatter(ds.sqlFormatter, columns), nil
+}
+
+// getTextCastColumns requests the table schema only once per table, since it's required by every split of the table
+func (ds *dataSourceImpl) getTextCastColumns(
+	ctx context.Context,
+	logger *zap.Logger,
+	provider rdbms_utils.TextCastColumnsProvider,
+	request *api_service_protos.TDescribeTableRequest,
+) (map[string]struct{}, error) {
+	if ds.textCastColumnsCache == nil {
+		return provider.GetTextCastColumns(ctx, logger, ds.connectionManager, request)
+	}
+
+	columns, found, err := ds.textCastColumnsCache.get(request)
+	if err != nil {
+		return nil, fmt.Errorf("get from cache: %w", err)
+	}
+
+	if found {
+		return columns, nil
+	}
+
+	columns, err = provider.GetTextCastColumns(ctx, logger, ds.connectionManager, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ds.textCastColumnsCache.put(request, columns); err != nil {
+		return nil, fmt.Errorf("put to cache: %w", err)
+	}
+
+	return columns, nil
+}
+
 func (ds *dataSourceImpl) doReadSplitSingleConn(
 	ctx context.Context,
 	logger *zap.Logger,
 	query *rdbms_utils.SelectQuery,
 	sink paging.Sink[any],
 	conn rdbms_utils.Connection,
+	limiter *rowsLimiter,
 ) (int64, error) {
 	var queryResult *rdbms_utils.QueryResult
 
@@ -223,9 +351,9 @@ func (ds *dataSourceImpl) doReadSplitSingleConn(
 
 	// Choose the appropriate processing method based on which field is filled
 	if queryResult.Rows != nil {
-		rowsRead, processErr = ds.processRowBasedResult(query, queryResult.Rows, sink)
+		rowsRead, processErr = ds.processRowBasedResult(query, queryResult.Rows, sink, limiter)
 	} else if queryResult.Columns != nil {
-		rowsRead, processErr = ds.processArrowBasedResult(queryResult.Columns, sink)
+		rowsRead, processErr = ds.processArrowBasedResult(queryResult.Columns, sink, limiter)
 	} else {
 		return 0, errors.New("query result contains neither Rows nor Columns")
 	}
@@ -246,6 +374,7 @@ func (ds *dataSourceImpl) processRowBasedResult(
 	query *rdbms_utils.SelectQuery,
 	rows rdbms_utils.Rows,
 	sink paging.Sink[any],
+	limiter *rowsLimiter,
 ) (int64, error) {
 	transformer, err := rows.MakeTransformer(query.YdbColumns, ds.converterCollection)
 	if err != nil {
@@ -254,8 +383,13 @@ func (ds *dataSourceImpl) processRowBasedResult(
 
 	rowsRead := int64(0)
 
-	for cont := true; cont; cont = rows.NextResultSet() {
+	for cont := true; cont && !limiter.exhausted(); cont = rows.NextResultSet() {
 		for rows.Next() {
+			// stop early if the rows requested by LIMIT were already read
+			if !limiter.take(1) {
+				return rowsRead, nil
+			}
+
 			rowsRead++
 
 			if err := rows.Scan(transformer.GetAcceptors()...); err != nil {
@@ -285,12 +419,18 @@ func (ds *dataSourceImpl) processRowBasedResult(
 func (dataSourceImpl) processArrowBasedResult(
 	columns rdbms_utils.Columns,
 	sink paging.Sink[any],
+	limiter *rowsLimiter,
 ) (int64, error) {
 	rowsRead := int64(0)
 
-	for columns.Next() {
+	for !limiter.exhausted() && columns.Next() {
 		record := columns.Record()
 
+		// stop early if the rows requested by LIMIT were already read
+		if !limiter.take(record.NumRows()) {
+			break
+		}
+
 		rowsRead += record.NumRows()
 
 		if err := sink.AddArrowRecord(record); err != nil {
@@ -312,14 +452,15 @@ func NewDataSource(
 	observationStorage observation.Storage,
 ) datasource.DataSource[any] {
 	return &dataSourceImpl{
-		logger:              logger,
-		sqlFormatter:        preset.SQLFormatter,
-		connectionManager:   preset.ConnectionManager,
-		typeMapper:          preset.TypeMapper,
-		schemaProvider:      preset.SchemaProvider,
-		splitProvider:       preset.SplitProvider,
-		retrierSet:          preset.RetrierSet,
-		converterCollection: converterCollection,
-		observationStorage:  observationStorage,
+		logger:               logger,
+		sqlFormatter:         preset.SQLFormatter,
+		connectionManager:    preset.ConnectionManager,
+		typeMapper:           preset.TypeMapper,
+		schemaProvider:       preset.SchemaProvider,
+		splitProvider:        preset.SplitProvider,
+		retrierSet:           preset.RetrierSet,
+		textCastColumnsCache: preset.TextCastColumnsCache,
+		converterCollection:  converterCollection,
+		observationStorage:   observationStorage,
 	}
 }
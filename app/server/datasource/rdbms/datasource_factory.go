@@ -106,7 +106,7 @@ func NewDataSourceFactory(
 	dsf := &dataSourceFactory{
 		clickhouse: Preset{
 			SQLFormatter:      clickhouse.NewSQLFormatter(cfg.Clickhouse.Pushdown),
-			ConnectionManager: clickhouse.NewConnectionManager(cfg.Clickhouse, connManagerBase),
+			ConnectionManager: clickhouse.NewConnectionManager(cfg.Clickhouse, connManagerBase, converterCollection),
 			TypeMapper:        clickhouseTypeMapper,
 			SchemaProvider:    rdbms_utils.NewDefaultSchemaProvider(clickhouseTypeMapper, clickhouse.TableMetadataQuery),
 			SplitProvider:     rdbms_utils.NewDefaultSplitProvider(),
@@ -118,15 +118,18 @@ func NewDataSourceFactory(
 		postgresql: Preset{
 			SQLFormatter: postgresql.NewSQLFormatter(cfg.Postgresql.Pushdown),
 			ConnectionManager: postgresql.NewConnectionManager(
-				cfg.Postgresql, connManagerBase, schemaGetters[api_common.EGenericDataSourceKind_POSTGRESQL]),
+				cfg.Postgresql, connManagerBase, schemaGetters[api_common.EGenericDataSourceKind_POSTGRESQL], converterCollection),
 			TypeMapper: postgresqlTypeMapper,
-			SchemaProvider: rdbms_utils.NewDefaultSchemaProvider(
+			SchemaProvider: postgresql.NewSchemaProvider(
 				postgresqlTypeMapper,
-				func(request *api_service_protos.TDescribeTableRequest) (string, *rdbms_utils.QueryArgs) {
-					return postgresql.TableMetadataQuery(
-						request,
-						schemaGetters[api_common.EGenericDataSourceKind_POSTGRESQL](request.DataSourceInstance))
-				}),
+				rdbms_utils.NewDefaultSchemaProvider(
+					postgresqlTypeMapper,
+					func(request *api_service_protos.TDescribeTableRequest) (string, *rdbms_utils.QueryArgs) {
+						return postgresql.TableMetadataQuery(
+							request,
+							schemaGetters[api_common.EGenericDataSourceKind_POSTGRESQL](request.DataSourceInstance))
+					}),
+			),
 			SplitProvider: postgresql.NewSplitProvider(cfg.Postgresql.Splitting),
 			RetrierSet: &retry.RetrierSet{
 				MakeConnection: retry.NewRetrierFromConfig(cfg.Postgresql.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
@@ -169,15 +172,18 @@ func NewDataSourceFactory(
 		greenplum: Preset{
 			SQLFormatter: postgresql.NewSQLFormatter(cfg.Greenplum.Pushdown),
 			ConnectionManager: postgresql.NewConnectionManager(
-				cfg.Greenplum, connManagerBase, schemaGetters[api_common.EGenericDataSourceKind_GREENPLUM]),
+				cfg.Greenplum, connManagerBase, schemaGetters[api_common.EGenericDataSourceKind_GREENPLUM], converterCollection),
 			TypeMapper: postgresqlTypeMapper,
-			SchemaProvider: rdbms_utils.NewDefaultSchemaProvider(
+			SchemaProvider: postgresql.NewSchemaProvider(
 				postgresqlTypeMapper,
-				func(request *api_service_protos.TDescribeTableRequest) (string, *rdbms_utils.QueryArgs) {
-					return postgresql.TableMetadataQuery(
-						request,
-						schemaGetters[api_common.EGenericDataSourceKind_GREENPLUM](request.DataSourceInstance))
-				}),
+				rdbms_utils.NewDefaultSchemaProvider(
+					postgresqlTypeMapper,
+					func(request *api_service_protos.TDescribeTableRequest) (string, *rdbms_utils.QueryArgs) {
+						return postgresql.TableMetadataQuery(
+							request,
+							schemaGetters[api_common.EGenericDataSourceKind_GREENPLUM](request.DataSourceInstance))
+					}),
+			),
 			SplitProvider: rdbms_utils.NewDefaultSplitProvider(),
 			RetrierSet: &retry.RetrierSet{
 				MakeConnection: retry.NewRetrierFromConfig(cfg.Greenplum.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
@@ -222,5 +228,16 @@ func NewDataSourceFactory(
 
 	dsf.observationStorage = observationStorage
 
+	textCastColumnsCache, err := NewTextCastColumnsCache()
+	if err != nil {
+		return nil, fmt.Errorf("new text cast columns cache: %w", err)
+	}
+
+	for _, preset := range []*Preset{
+		&dsf.clickhouse, &dsf.postgresql, &dsf.ydb, &dsf.msSQLServer, &dsf.mysql, &dsf.greenplum, &dsf.oracle, &dsf.logging,
+	} {
+		preset.TextCastColumnsCache = textCastColumnsCache
+	}
+
 	return dsf, nil
 }
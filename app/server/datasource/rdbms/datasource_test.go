@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/apache/arrow/go/v13/arrow/memory"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -127,6 +128,7 @@ func TestReadSplit(t *testing.T) {
 
 		sinkFactory := &paging.SinkFactoryMock{}
 		sinkFactory.On("MakeSinks", []*paging.SinkParams{{Logger: logger}}).Return([]paging.Sink[any]{sink}, nil).Once()
+		sinkFactory.On("Allocator").Return(memory.DefaultAllocator).Once()
 
 		// FIXME: mock
 		observationStorage, err := observation.NewStorage(logger, nil)
@@ -199,6 +201,7 @@ func TestReadSplit(t *testing.T) {
 
 		sinkFactory := &paging.SinkFactoryMock{}
 		sinkFactory.On("MakeSinks", []*paging.SinkParams{{Logger: logger}}).Return([]paging.Sink[any]{sink}, nil).Once()
+		sinkFactory.On("Allocator").Return(memory.DefaultAllocator).Once()
 
 		// FIXME: mock
 		observationStorage, err := observation.NewStorage(logger, nil)
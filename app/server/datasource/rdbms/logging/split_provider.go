@@ -159,6 +159,11 @@ func (s *splitProviderImpl) handleYDBSource(
 	return nil
 }
 
+// SplittingEnabled returns true, because every column shard of the log group is read in a distinct split
+func (*splitProviderImpl) SplittingEnabled() bool {
+	return true
+}
+
 func NewSplitProvider(resolver Resolver, ydbSplitProvider ydb.SplitProvider) rdbms_utils.SplitProvider {
 	return &splitProviderImpl{
 		resolver:         resolver,
@@ -190,6 +190,11 @@ func (s sqlFormatter) RenderSelectQueryText(
 	return sb.String(), nil
 }
 
+// FormatLimit doesn't depend on the description of the split, which is specific for Logging data source
+func (sqlFormatter) FormatLimit(limit *api_service_protos.TSelect_TLimit, _ *api_service_protos.TSplit) (string, error) {
+	return rdbms_utils.FormatLimitDefault(limit), nil
+}
+
 func (sqlFormatter) TransformPredicateComparison(
 	src *api_service_protos.TPredicate_TComparison,
 ) (*api_service_protos.TPredicate_TComparison, error) {
@@ -343,6 +348,24 @@ func checkTimestampFilter(predicate *api_service_protos.TPredicate) (bool, error
 	return false, nil
 }
 
+// FormatOrderBy is not supported, because most of the Logging columns are computed from the underlying table
+func (sqlFormatter) FormatOrderBy(_ *api_service_protos.TSelect_TOrderBy) (string, error) {
+	return "", common.ErrUnimplementedOperation
+}
+
+// SupportedAggregations returns nothing, because every split keeps only a part of logs
+func (sqlFormatter) SupportedAggregations() []api_service_protos.TSelect_TAggregation_EFunction {
+	return nil
+}
+
+func (sqlFormatter) FormatAggregation(
+	_ api_service_protos.TSelect_TAggregation_EFunction,
+	_ string,
+	_ *Ydb.Type,
+) (string, error) {
+	return "", common.ErrUnimplementedOperation
+}
+
 func NewSQLFormatter(ydbSQLFormatter ydb.SQLFormatter) rdbms_utils.SQLFormatter {
 	return &sqlFormatter{
 		SQLFormatter: ydbSQLFormatter,
@@ -175,6 +175,7 @@ func TestMakeSelectQuery(t *testing.T) {
 				context.Background(),
 				logger,
 				formatter,
+				nil,
 				split,
 				api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
 				tc.splitDescription.GetYdb().GetTableName(),
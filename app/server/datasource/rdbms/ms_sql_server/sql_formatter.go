@@ -102,6 +102,61 @@ func (sqlFormatter) RenderBetween(value, least, greatest string) (string, error)
 	return fmt.Sprintf("%s BETWEEN %s AND %s", value, least, greatest), nil
 }
 
+// FormatLimit merges the offset into the limit, OFFSET is applied by the engine (see rdbms_utils.FormatLimitDefault)
+func (sqlFormatter) FormatLimit(limit *api_service_protos.TSelect_TLimit, split *api_service_protos.TSplit) (string, error) {
+	out := fmt.Sprintf("OFFSET 0 ROWS FETCH NEXT %d ROWS ONLY", limit.Limit+limit.Offset)
+
+	// OFFSET ... FETCH requires ORDER BY clause, so if it's missing, the order of rows is left unspecified
+	if len(split.GetSelect().GetOrderBy().GetKeys()) == 0 {
+		out = "ORDER BY (SELECT NULL) " + out
+	}
+
+	return out, nil
+}
+
+func (sqlFormatter) FormatTextCast(valueExpr string) (string, error) {
+	return fmt.Sprintf("CAST(%s AS NVARCHAR(MAX))", valueExpr), nil
+}
+
+var aggregationCastTemplates = map[Ydb.Type_PrimitiveTypeId]string{
+	Ydb.Type_INT64:  "CAST(%s AS BIGINT)",
+	Ydb.Type_DOUBLE: "CAST(%s AS FLOAT)",
+}
+
+func (sqlFormatter) SupportedAggregations() []api_service_protos.TSelect_TAggregation_EFunction {
+	return rdbms_utils.AllAggregations
+}
+
+// FormatAggregation converts the arguments of SUM and AVG before the aggregation,
+// because their results have the type of the argument: SUM over INT may overflow, and AVG over INT is truncated.
+func (sqlFormatter) FormatAggregation(
+	function api_service_protos.TSelect_TAggregation_EFunction,
+	argExpr string,
+	resultType *Ydb.Type,
+) (string, error) {
+	switch function {
+	case api_service_protos.TSelect_TAggregation_COUNT:
+		if argExpr == "" {
+			argExpr = "*"
+		}
+
+		return fmt.Sprintf("COUNT_BIG(%s)", argExpr), nil
+	case api_service_protos.TSelect_TAggregation_SUM, api_service_protos.TSelect_TAggregation_AVG:
+		castExpr, err := rdbms_utils.FormatAggregationCast(argExpr, resultType, aggregationCastTemplates)
+		if err != nil {
+			return "", fmt.Errorf("format aggregation cast: %w", err)
+		}
+
+		return rdbms_utils.FormatAggregationFunction(function, castExpr), nil
+	default:
+		return rdbms_utils.FormatAggregationDefault(function, argExpr, resultType, aggregationCastTemplates)
+	}
+}
+
+func (f sqlFormatter) FormatOrderBy(orderBy *api_service_protos.TSelect_TOrderBy) (string, error) {
+	return rdbms_utils.FormatOrderByDefault(f, orderBy)
+}
+
 func NewSQLFormatter(cfg *config.TPushdownConfig) rdbms_utils.SQLFormatter {
 	return sqlFormatter{cfg: cfg}
 }
@@ -11,12 +11,14 @@ import (
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
 var _ datasource.TypeMapper = typeMapper{}
+var _ datasource.AggregationTypeMapper = typeMapper{}
 
 type typeMapper struct{}
 
@@ -145,6 +147,8 @@ func transformerFromSQLTypes(types []string, ydbTypes []*Ydb.Type, cc conversion
 				appenders = append(appenders, utils.MakeAppenderNullable[time.Time, string, *array.StringBuilder](cc.DateToString()))
 			case Ydb.Type_DATE:
 				appenders = append(appenders, utils.MakeAppenderNullable[time.Time, uint16, *array.Uint16Builder](cc.Date()))
+			case Ydb.Type_DATE32:
+				appenders = append(appenders, utils.MakeAppenderNullable[time.Time, int32, *array.Int32Builder](cc.Date32()))
 			default:
 				return nil, fmt.Errorf(
 					"unexpected ydb type %v for ms sql server type %v: %w",
@@ -163,6 +167,8 @@ func transformerFromSQLTypes(types []string, ydbTypes []*Ydb.Type, cc conversion
 				appenders = append(appenders, utils.MakeAppenderNullable[time.Time, string, *array.StringBuilder](cc.DatetimeToString()))
 			case Ydb.Type_DATETIME:
 				appenders = append(appenders, utils.MakeAppenderNullable[time.Time, uint32, *array.Uint32Builder](cc.Datetime()))
+			case Ydb.Type_DATETIME64:
+				appenders = append(appenders, utils.MakeAppenderNullable[time.Time, int64, *array.Int64Builder](cc.Datetime64()))
 			default:
 				return nil, fmt.Errorf(
 					"unexpected ydb type %v for ms sql server type %v: %w",
@@ -182,6 +188,8 @@ func transformerFromSQLTypes(types []string, ydbTypes []*Ydb.Type, cc conversion
 					utils.MakeAppenderNullable[time.Time, string, *array.StringBuilder](cc.TimestampToString(true)))
 			case Ydb.Type_TIMESTAMP:
 				appenders = append(appenders, utils.MakeAppenderNullable[time.Time, uint64, *array.Uint64Builder](cc.Timestamp()))
+			case Ydb.Type_TIMESTAMP64:
+				appenders = append(appenders, utils.MakeAppenderNullable[time.Time, int64, *array.Int64Builder](cc.Timestamp64()))
 			default:
 				return nil, fmt.Errorf(
 					"unexpected ydb type %v for ms sql server type %v: %w",
@@ -195,4 +203,14 @@ func transformerFromSQLTypes(types []string, ydbTypes []*Ydb.Type, cc conversion
 	return paging.NewRowTransformer[any](acceptors, appenders, nil), nil
 }
 
+// The results are converted to these types with aggregationCastTemplates
+var aggregationResultTypeNames = map[Ydb.Type_PrimitiveTypeId]string{
+	Ydb.Type_INT64:  "bigint",
+	Ydb.Type_DOUBLE: "float",
+}
+
+func (tm typeMapper) AggregationToYDBColumn(agg *api_service_protos.TSelect_TAggregation) (*Ydb.Column, error) {
+	return rdbms_utils.AggregationToYDBColumnDefault(tm, agg, aggregationResultTypeNames)
+}
+
 func NewTypeMapper() datasource.TypeMapper { return typeMapper{} }
@@ -15,12 +15,14 @@ import (
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
 var _ datasource.TypeMapper = &typeMapper{}
+var _ datasource.AggregationTypeMapper = &typeMapper{}
 
 type typeMapper struct {
 	reType *regexp.Regexp
@@ -136,6 +138,17 @@ func (tm *typeMapper) SQLTypeToYDBColumn(
 	return &ydbColumn, nil
 }
 
+// The results are converted to these types with aggregationCastTemplates
+var aggregationResultTypeNames = map[Ydb.Type_PrimitiveTypeId]string{
+	Ydb.Type_INT64:  typeBigInt,
+	Ydb.Type_UINT64: typeBigInt + " unsigned",
+	Ydb.Type_DOUBLE: typeDouble,
+}
+
+func (tm *typeMapper) AggregationToYDBColumn(agg *api_service_protos.TSelect_TAggregation) (*Ydb.Column, error) {
+	return rdbms_utils.AggregationToYDBColumnDefault(tm, agg, aggregationResultTypeNames)
+}
+
 func NewTypeMapper() datasource.TypeMapper {
 	return &typeMapper{
 		regexp.MustCompile(`(?P<type>.*)(:?\((?P<size>\d+)\))`),
@@ -256,6 +269,12 @@ func addAcceptorAppender(
 
 				return utils.MakeAppenderNullable[time.Time, uint16, *array.Uint16Builder](cc.Date())(cast, builder)
 			})
+		case Ydb.Type_DATE32:
+			*appenders = append(*appenders, func(acceptor any, builder array.Builder) error {
+				cast := acceptor.(**time.Time)
+
+				return utils.MakeAppenderNullable[time.Time, int32, *array.Int32Builder](cc.Date32())(cast, builder)
+			})
 		default:
 			return fmt.Errorf("type mismatch: mysql '%d' vs ydb '%s': %w", mySQLType, ydbTypeId.String(), common.ErrDataTypeNotSupported)
 		}
@@ -281,6 +300,12 @@ func addAcceptorAppender(
 
 				return utils.MakeAppenderNullable[time.Time, uint64, *array.Uint64Builder](cc.Timestamp())(cast, builder)
 			})
+		case Ydb.Type_TIMESTAMP64:
+			*appenders = append(*appenders, func(acceptor any, builder array.Builder) error {
+				cast := acceptor.(**time.Time)
+
+				return utils.MakeAppenderNullable[time.Time, int64, *array.Int64Builder](cc.Timestamp64())(cast, builder)
+			})
 		default:
 			return fmt.Errorf("type mismatch: mysql '%d' vs ydb '%s': %w", mySQLType, ydbTypeId.String(), common.ErrDataTypeNotSupported)
 		}
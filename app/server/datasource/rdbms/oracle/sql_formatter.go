@@ -102,6 +102,19 @@ func (sqlFormatter) RenderBetween(value, least, greatest string) (string, error)
 	return fmt.Sprintf("%s BETWEEN %s AND %s", value, least, greatest), nil
 }
 
+// FormatLimit merges the offset into the limit, OFFSET is applied by the engine (see rdbms_utils.FormatLimitDefault)
+func (sqlFormatter) FormatLimit(limit *api_service_protos.TSelect_TLimit, _ *api_service_protos.TSplit) (string, error) {
+	return fmt.Sprintf("FETCH FIRST %d ROWS ONLY", limit.Limit+limit.Offset), nil
+}
+
+func (sqlFormatter) FormatTextCast(valueExpr string) (string, error) {
+	return fmt.Sprintf("TO_CHAR(%s)", valueExpr), nil
+}
+
+func (f sqlFormatter) FormatOrderBy(orderBy *api_service_protos.TSelect_TOrderBy) (string, error) {
+	return rdbms_utils.FormatOrderByDefault(f, orderBy)
+}
+
 func NewSQLFormatter(cfg *config.TPushdownConfig) rdbms_utils.SQLFormatter {
 	return sqlFormatter{cfg: cfg}
 }
@@ -159,6 +159,8 @@ func transformerFromSQLTypes(types []string, ydbTypes []*Ydb.Type, cc conversion
 					utils.MakeAppenderNullable[time.Time, string, *array.StringBuilder](cc.DatetimeToString()))
 			case Ydb.Type_DATETIME:
 				appenders = append(appenders, utils.MakeAppenderNullable[time.Time, uint32, *array.Uint32Builder](cc.Datetime()))
+			case Ydb.Type_DATETIME64:
+				appenders = append(appenders, utils.MakeAppenderNullable[time.Time, int64, *array.Int64Builder](cc.Datetime64()))
 			default:
 				return nil, fmt.Errorf("unexpected ydb type %v with sql type %s: %w", ydbType, typeName, common.ErrDataTypeNotSupported)
 			}
@@ -179,6 +181,8 @@ func transformerFromSQLTypes(types []string, ydbTypes []*Ydb.Type, cc conversion
 					utils.MakeAppenderNullable[time.Time, string, *array.StringBuilder](cc.TimestampToString(true)))
 			case Ydb.Type_TIMESTAMP:
 				appenders = append(appenders, utils.MakeAppenderNullable[time.Time, uint64, *array.Uint64Builder](cc.Timestamp()))
+			case Ydb.Type_TIMESTAMP64:
+				appenders = append(appenders, utils.MakeAppenderNullable[time.Time, int64, *array.Int64Builder](cc.Timestamp64()))
 			default:
 				return nil, fmt.Errorf("unexpected ydb type %v with sql type %s: %w", ydbType, typeName, common.ErrDataTypeNotSupported)
 			}
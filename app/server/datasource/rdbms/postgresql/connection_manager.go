@@ -14,6 +14,7 @@ import (
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
 	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
@@ -52,6 +53,8 @@ type connection struct {
 	queryLogger        common.QueryLogger
 	dataSourceInstance *api_common.TGenericDataSourceInstance
 	tableName          string
+	readMode           config.TPostgreSQLConfig_EReadMode
+	cc                 conversion.Collection
 }
 
 func (c *connection) Close() error {
@@ -61,6 +64,16 @@ func (c *connection) Close() error {
 func (c *connection) Query(params *rdbms_utils.QueryParams) (*rdbms_utils.QueryResult, error) {
 	c.queryLogger.Dump(params.QueryText, params.QueryArgs.Values()...)
 
+	// only the queries reading table data are served with COPY
+	if c.readMode == config.TPostgreSQLConfig_READ_MODE_COPY_BINARY && len(params.YdbColumns) > 0 {
+		// the query arguments are inlined into COPY, the string literals can be quoted safely only with standard conforming strings
+		if c.PgConn().ParameterStatus("standard_conforming_strings") == "on" {
+			return c.queryCopyBinary(params)
+		}
+
+		c.Logger().Warn("standard_conforming_strings is disabled, falling back to the regular query")
+	}
+
 	out, err := c.Conn.Query(params.Ctx, params.QueryText, params.QueryArgs.Values()...)
 	if err != nil {
 		return nil, fmt.Errorf("query error: %w", err)
@@ -89,6 +102,7 @@ type connectionManager struct {
 	rdbms_utils.ConnectionManagerBase
 	schemaGetter func(dsi *api_common.TGenericDataSourceInstance) string
 	cfg          ConnectionManagerConfig
+	cc           conversion.Collection
 }
 
 func (c *connectionManager) Make(
@@ -130,6 +144,10 @@ func (c *connectionManager) Make(
 		connCfg.TLSConfig.ServerName = dsi.GetEndpoint().GetHost()
 	}
 
+	// Every transaction of the session is read-only, so that the native queries
+	// (and the functions called by them) can't modify the data.
+	connCfg.RuntimeParams["default_transaction_read_only"] = "on"
+
 	openCtx, openCtxCancel := context.WithTimeout(ctx, common.MustDurationFromString(c.cfg.GetOpenConnectionTimeout()))
 	defer openCtxCancel()
 
@@ -148,7 +166,14 @@ func (c *connectionManager) Make(
 
 	queryLogger := c.QueryLoggerFactory.Make(logger)
 
-	return []rdbms_utils.Connection{&connection{conn, queryLogger, dsi, params.TableName}}, nil
+	return []rdbms_utils.Connection{&connection{
+		Conn:               conn,
+		queryLogger:        queryLogger,
+		dataSourceInstance: dsi,
+		tableName:          params.TableName,
+		readMode:           c.cfg.GetReadMode(),
+		cc:                 c.cc,
+	}}, nil
 }
 
 func (*connectionManager) Release(_ context.Context, logger *zap.Logger, cs []rdbms_utils.Connection) {
@@ -166,16 +191,19 @@ func (*connectionManager) Release(_ context.Context, logger *zap.Logger, cs []rd
 
 type ConnectionManagerConfig interface {
 	GetOpenConnectionTimeout() string
+	GetReadMode() config.TPostgreSQLConfig_EReadMode
 }
 
 func NewConnectionManager(
 	cfg ConnectionManagerConfig,
 	base rdbms_utils.ConnectionManagerBase,
 	schemaGetter func(*api_common.TGenericDataSourceInstance) string,
+	cc conversion.Collection,
 ) rdbms_utils.ConnectionManager {
 	return &connectionManager{
 		ConnectionManagerBase: base,
 		schemaGetter:          schemaGetter,
 		cfg:                   cfg,
+		cc:                    cc,
 	}
 }
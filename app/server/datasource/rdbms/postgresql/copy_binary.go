@@ -0,0 +1,337 @@
+package postgresql
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// copyBinarySignature starts the stream of PostgreSQL binary COPY format
+var copyBinarySignature = []byte("PGCOPY\n\377\r\n\x00")
+
+// copyBinaryRecordSize is the amount of COPY data that is collected into a single Arrow record
+const copyBinaryRecordSize = 1 << 20
+
+var _ rdbms_utils.Columns = (*columnsCopyBinary)(nil)
+
+// columnsCopyBinary decodes the stream of `COPY ... TO STDOUT (FORMAT binary)`
+// directly into Arrow builders. The values are scanned with the same acceptors
+// that are used by the row-based read path, so the type mapping stays the same.
+type columnsCopyBinary struct {
+	reader      *bufio.Reader
+	typeMap     *pgtype.Map
+	oids        []uint32
+	transformer paging.RowTransformer[any] // nil if no columns were requested
+	schema      *arrow.Schema
+	builders    []array.Builder
+	record      arrow.Record
+	buf         []byte
+	headerRead  bool
+	finished    bool
+	err         error
+
+	copyDone      <-chan error // receives the result of COPY when the stream is over
+	interruptCopy func()       // makes COPY stop before the whole stream is read
+}
+
+func (c *columnsCopyBinary) Next() bool {
+	if c.record != nil {
+		c.record.Release()
+		c.record = nil
+	}
+
+	if c.finished || c.err != nil {
+		return false
+	}
+
+	if !c.headerRead {
+		if err := c.readHeader(); err != nil {
+			c.err = fmt.Errorf("read header: %w", err)
+
+			return false
+		}
+
+		c.headerRead = true
+	}
+
+	var rows, size int
+
+	for size < copyBinaryRecordSize {
+		tupleSize, err := c.readTuple()
+		if errors.Is(err, io.EOF) {
+			c.finished = true
+
+			if err := <-c.copyDone; err != nil {
+				c.err = fmt.Errorf("copy: %w", err)
+
+				return false
+			}
+
+			break
+		}
+
+		if err != nil {
+			c.err = fmt.Errorf("read tuple: %w", err)
+
+			return false
+		}
+
+		rows++
+		size += tupleSize
+	}
+
+	if rows == 0 {
+		return false
+	}
+
+	c.record = c.makeRecord(rows)
+
+	return true
+}
+
+func (c *columnsCopyBinary) readHeader() error {
+	header := make([]byte, len(copyBinarySignature)+8)
+	if _, err := io.ReadFull(c.reader, header); err != nil {
+		return fmt.Errorf("read signature: %w", err)
+	}
+
+	if !bytes.Equal(header[:len(copyBinarySignature)], copyBinarySignature) {
+		return fmt.Errorf("unexpected signature %q", header[:len(copyBinarySignature)])
+	}
+
+	// flags field is skipped, since it has no critical bits in the current format version
+	extensionLength := binary.BigEndian.Uint32(header[len(copyBinarySignature)+4:])
+
+	if _, err := c.reader.Discard(int(extensionLength)); err != nil {
+		return fmt.Errorf("discard header extension: %w", err)
+	}
+
+	return nil
+}
+
+// readTuple reads the next tuple into the builders and returns its size in bytes;
+// io.EOF is returned when the trailer is reached.
+func (c *columnsCopyBinary) readTuple() (int, error) {
+	var sizeBuf [4]byte
+
+	if _, err := io.ReadFull(c.reader, sizeBuf[:2]); err != nil {
+		return 0, fmt.Errorf("read field count: %w", noEOF(err))
+	}
+
+	fieldCount := int16(binary.BigEndian.Uint16(sizeBuf[:2]))
+	if fieldCount == -1 {
+		return 0, io.EOF
+	}
+
+	if int(fieldCount) != len(c.oids) {
+		return 0, fmt.Errorf("tuple has %d fields, but %d fields were expected", fieldCount, len(c.oids))
+	}
+
+	size := 2
+
+	for i := 0; i < len(c.oids); i++ {
+		if _, err := io.ReadFull(c.reader, sizeBuf[:]); err != nil {
+			return 0, fmt.Errorf("read length of field #%d: %w", i, noEOF(err))
+		}
+
+		length := int32(binary.BigEndian.Uint32(sizeBuf[:]))
+		size += 4
+
+		var src []byte
+
+		if length >= 0 {
+			if cap(c.buf) < int(length) {
+				c.buf = make([]byte, length)
+			}
+
+			src = c.buf[:length]
+
+			if _, err := io.ReadFull(c.reader, src); err != nil {
+				return 0, fmt.Errorf("read field #%d: %w", i, noEOF(err))
+			}
+
+			size += int(length)
+		}
+
+		if c.transformer == nil {
+			continue
+		}
+
+		if err := c.typeMap.Scan(c.oids[i], pgtype.BinaryFormatCode, src, c.transformer.GetAcceptors()[i]); err != nil {
+			return 0, fmt.Errorf("scan field #%d: %w", i, err)
+		}
+	}
+
+	if c.transformer != nil {
+		if err := c.transformer.AppendToArrowBuilders(c.schema, c.builders); err != nil {
+			return 0, fmt.Errorf("append to arrow builders: %w", err)
+		}
+	}
+
+	return size, nil
+}
+
+func (c *columnsCopyBinary) makeRecord(rows int) arrow.Record {
+	columns := make([]arrow.Array, 0, len(c.builders))
+
+	for _, builder := range c.builders {
+		columns = append(columns, builder.NewArray())
+	}
+
+	record := array.NewRecord(c.schema, columns, int64(rows))
+
+	for _, column := range columns {
+		column.Release()
+	}
+
+	return record
+}
+
+func (c *columnsCopyBinary) Record() arrow.Record {
+	return c.record
+}
+
+func (c *columnsCopyBinary) Err() error {
+	return c.err
+}
+
+func (c *columnsCopyBinary) Close() error {
+	if c.record != nil {
+		c.record.Release()
+		c.record = nil
+	}
+
+	for _, builder := range c.builders {
+		builder.Release()
+	}
+
+	c.builders = nil
+
+	if !c.finished {
+		c.finished = true
+
+		c.interruptCopy()
+
+		// the stream is interrupted, so the error of COPY is expected
+		<-c.copyDone
+	}
+
+	return nil
+}
+
+func newColumnsCopyBinary(
+	reader io.Reader,
+	copyDone <-chan error,
+	interruptCopy func(),
+	typeMap *pgtype.Map,
+	oids []uint32,
+	params *rdbms_utils.QueryParams,
+	cc conversion.Collection,
+) (*columnsCopyBinary, error) {
+	c := &columnsCopyBinary{
+		reader:        bufio.NewReader(reader),
+		typeMap:       typeMap,
+		oids:          oids,
+		copyDone:      copyDone,
+		interruptCopy: interruptCopy,
+	}
+
+	if params.EmptyColumns {
+		c.schema = arrow.NewSchema(nil, nil)
+
+		return c, nil
+	}
+
+	var err error
+
+	ydbTypes := common.YDBColumnsToYDBTypes(params.YdbColumns)
+
+	c.transformer, err = transformerFromOIDs(oids, ydbTypes, cc)
+	if err != nil {
+		return nil, fmt.Errorf("transformer from OIDs: %w", err)
+	}
+
+	c.schema, err = common.YDBColumnsToArrowSchema(params.YdbColumns)
+	if err != nil {
+		return nil, fmt.Errorf("YDB columns to Arrow schema: %w", err)
+	}
+
+	c.builders, err = common.YdbTypesToArrowBuilders(ydbTypes, params.Allocator)
+	if err != nil {
+		return nil, fmt.Errorf("YDB types to Arrow builders: %w", err)
+	}
+
+	return c, nil
+}
+
+// noEOF turns EOF into unexpected EOF, since the stream must end with the trailer
+func noEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
+
+// queryCopyBinary streams the result of the query with `COPY (...) TO STDOUT (FORMAT binary)`.
+// COPY can't return the description of the result set, so the column types are obtained
+// by preparing the underlying SELECT query beforehand.
+func (c *connection) queryCopyBinary(params *rdbms_utils.QueryParams) (*rdbms_utils.QueryResult, error) {
+	queryText, err := inlineQueryArgs(params.QueryText, params.QueryArgs.Values())
+	if err != nil {
+		return nil, fmt.Errorf("inline query args: %w", err)
+	}
+
+	description, err := c.PgConn().Prepare(params.Ctx, "", queryText, nil)
+	if err != nil {
+		return nil, fmt.Errorf("prepare: %w", err)
+	}
+
+	oids := make([]uint32, 0, len(description.Fields))
+	for _, field := range description.Fields {
+		oids = append(oids, field.DataTypeOID)
+	}
+
+	ctx, cancel := context.WithCancel(params.Ctx)
+	pipeReader, pipeWriter := io.Pipe()
+	copyDone := make(chan error, 1)
+
+	interruptCopy := func() {
+		cancel()
+		// unblocks the writer if the stream hasn't been read till the end
+		pipeReader.Close()
+	}
+
+	columns, err := newColumnsCopyBinary(pipeReader, copyDone, interruptCopy, c.TypeMap(), oids, params, c.cc)
+	if err != nil {
+		cancel()
+
+		return nil, fmt.Errorf("new columns: %w", err)
+	}
+
+	go func() {
+		_, err := c.PgConn().CopyTo(ctx, pipeWriter, "COPY ("+queryText+") TO STDOUT (FORMAT binary)")
+		// nil error turns into EOF on the reader side
+		pipeWriter.CloseWithError(err)
+		copyDone <- err
+
+		cancel()
+	}()
+
+	return &rdbms_utils.QueryResult{
+		Columns: columns,
+	}, nil
+}
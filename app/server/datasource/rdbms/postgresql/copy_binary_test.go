@@ -0,0 +1,161 @@
+package postgresql
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// copyBinaryStream builds the stream of binary COPY format; nil field stands for NULL
+func copyBinaryStream(tuples [][][]byte, withTrailer bool) []byte {
+	var buf bytes.Buffer
+
+	buf.Write(copyBinarySignature)
+	_ = binary.Write(&buf, binary.BigEndian, uint32(0)) // flags
+	_ = binary.Write(&buf, binary.BigEndian, uint32(2)) // header extension length
+	buf.Write([]byte{0xff, 0xff})
+
+	for _, tuple := range tuples {
+		_ = binary.Write(&buf, binary.BigEndian, int16(len(tuple)))
+
+		for _, field := range tuple {
+			if field == nil {
+				_ = binary.Write(&buf, binary.BigEndian, int32(-1))
+
+				continue
+			}
+
+			_ = binary.Write(&buf, binary.BigEndian, int32(len(field)))
+			buf.Write(field)
+		}
+	}
+
+	if withTrailer {
+		_ = binary.Write(&buf, binary.BigEndian, int16(-1))
+	}
+
+	return buf.Bytes()
+}
+
+func int4Bytes(v int32) []byte {
+	return binary.BigEndian.AppendUint32(nil, uint32(v))
+}
+
+func TestColumnsCopyBinary(t *testing.T) {
+	cc := conversion.NewCollection(&config.TConversionConfig{})
+
+	ydbColumns := []*Ydb.Column{
+		{Name: "id", Type: common.MakePrimitiveType(Ydb.Type_INT32)},
+		{Name: "name", Type: common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8))},
+	}
+
+	oids := []uint32{pgtype.Int4OID, pgtype.TextOID}
+
+	newColumns := func(stream []byte, params *rdbms_utils.QueryParams) (*columnsCopyBinary, chan error) {
+		copyDone := make(chan error, 1)
+		copyDone <- nil
+
+		if params.Allocator == nil {
+			params.Allocator = memory.DefaultAllocator
+		}
+
+		columns, err := newColumnsCopyBinary(bytes.NewReader(stream), copyDone, func() {}, pgtype.NewMap(), oids, params, cc)
+		require.NoError(t, err)
+
+		return columns, copyDone
+	}
+
+	t.Run("values", func(t *testing.T) {
+		stream := copyBinaryStream([][][]byte{
+			{int4Bytes(1), []byte("a")},
+			{int4Bytes(-2), nil},
+		}, true)
+
+		// the records are built with the allocator of the request
+		allocator := memory.NewCheckedAllocator(memory.NewGoAllocator())
+		defer allocator.AssertSize(t, 0)
+
+		columns, _ := newColumns(stream, &rdbms_utils.QueryParams{YdbColumns: ydbColumns, Allocator: allocator})
+		defer columns.Close()
+
+		require.True(t, columns.Next())
+		require.Positive(t, allocator.CurrentAlloc())
+
+		record := columns.Record()
+		require.Equal(t, int64(2), record.NumRows())
+		require.Equal(t, []int32{1, -2}, record.Column(0).(*array.Int32).Int32Values())
+
+		names := record.Column(1).(*array.String)
+		require.Equal(t, "a", names.Value(0))
+		require.True(t, names.IsNull(1))
+
+		require.False(t, columns.Next())
+		require.NoError(t, columns.Err())
+	})
+
+	t.Run("empty_columns", func(t *testing.T) {
+		stream := copyBinaryStream([][][]byte{{int4Bytes(1), nil}, {int4Bytes(2), nil}, {int4Bytes(3), nil}}, true)
+
+		columns, _ := newColumns(stream, &rdbms_utils.QueryParams{EmptyColumns: true})
+		defer columns.Close()
+
+		require.True(t, columns.Next())
+		require.Equal(t, int64(3), columns.Record().NumRows())
+		require.Equal(t, int64(0), columns.Record().NumCols())
+		require.False(t, columns.Next())
+		require.NoError(t, columns.Err())
+	})
+
+	t.Run("no_rows", func(t *testing.T) {
+		columns, _ := newColumns(copyBinaryStream(nil, true), &rdbms_utils.QueryParams{YdbColumns: ydbColumns})
+		defer columns.Close()
+
+		require.False(t, columns.Next())
+		require.NoError(t, columns.Err())
+	})
+
+	t.Run("copy_error", func(t *testing.T) {
+		columns, copyDone := newColumns(copyBinaryStream(nil, true), &rdbms_utils.QueryParams{YdbColumns: ydbColumns})
+		defer columns.Close()
+
+		<-copyDone
+		copyDone <- io.ErrClosedPipe
+
+		require.False(t, columns.Next())
+		require.ErrorIs(t, columns.Err(), io.ErrClosedPipe)
+	})
+
+	t.Run("truncated_stream", func(t *testing.T) {
+		stream := copyBinaryStream([][][]byte{{int4Bytes(1), []byte("a")}}, false)
+
+		columns, _ := newColumns(stream, &rdbms_utils.QueryParams{YdbColumns: ydbColumns})
+		defer columns.Close()
+
+		require.False(t, columns.Next())
+		require.ErrorIs(t, columns.Err(), io.ErrUnexpectedEOF)
+	})
+
+	t.Run("bad_signature", func(t *testing.T) {
+		stream := copyBinaryStream(nil, true)
+		stream[0] = 'X'
+
+		columns, _ := newColumns(stream, &rdbms_utils.QueryParams{YdbColumns: ydbColumns})
+		defer columns.Close()
+
+		require.False(t, columns.Next())
+		require.Error(t, columns.Err())
+	})
+}
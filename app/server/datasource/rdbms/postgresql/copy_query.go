@@ -0,0 +1,141 @@
+package postgresql
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// inlineQueryArgs substitutes `$N` placeholders with the query arguments rendered as PostgreSQL literals.
+// COPY statement doesn't accept parameters, so the arguments are rendered on the client side
+// in the same way as pgx does it in the simple protocol mode.
+func inlineQueryArgs(query string, args []any) (string, error) {
+	if len(args) == 0 {
+		return query, nil
+	}
+
+	var (
+		sb    strings.Builder
+		used  = make([]bool, len(args))
+		start int
+	)
+
+	for i := 0; i < len(query); i++ {
+		switch query[i] {
+		case '\'', '"':
+			// skip string literals and quoted identifiers; doubled quotes are handled
+			// as two adjacent literals, which is fine for scanning purposes
+			end := strings.IndexByte(query[i+1:], query[i])
+			if end < 0 {
+				return "", fmt.Errorf("unterminated quote at position %d", i)
+			}
+
+			i += end + 1
+		case '$':
+			j := i + 1
+			for j < len(query) && query[j] >= '0' && query[j] <= '9' {
+				j++
+			}
+
+			if j == i+1 {
+				continue
+			}
+
+			n, err := strconv.Atoi(query[i+1 : j])
+			if err != nil {
+				return "", fmt.Errorf("parse placeholder '%s': %w", query[i:j], err)
+			}
+
+			if n < 1 || n > len(args) {
+				return "", fmt.Errorf("no argument for placeholder '%s'", query[i:j])
+			}
+
+			value, err := formatQueryArg(args[n-1])
+			if err != nil {
+				return "", fmt.Errorf("format argument #%d: %w", n-1, err)
+			}
+
+			sb.WriteString(query[start:i])
+			// spaces prevent the literal from merging with the surrounding tokens, e.g. `-$1` with negative value
+			sb.WriteString(" " + value + " ")
+
+			used[n-1] = true
+			start = j
+			i = j - 1
+		}
+	}
+
+	for i, ok := range used {
+		if !ok {
+			return "", fmt.Errorf("argument #%d is not used in the query", i)
+		}
+	}
+
+	sb.WriteString(query[start:])
+
+	return sb.String(), nil
+}
+
+func formatQueryArg(arg any) (string, error) {
+	// optional values are passed by pointers
+	value := reflect.ValueOf(arg)
+	if value.Kind() == reflect.Ptr && value.IsNil() {
+		return "null", nil
+	}
+
+	switch v := arg.(type) {
+	case nil:
+		return "null", nil
+	case string:
+		return quoteString(v), nil
+	case []byte:
+		return `'\x` + hex.EncodeToString(v) + `'`, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case time.Time:
+		return v.Truncate(time.Microsecond).Format("'2006-01-02 15:04:05.999999999Z07:00:00'"), nil
+	case int8, int16, int32, int64, uint8, uint16, uint32:
+		return fmt.Sprint(v), nil
+	case uint64:
+		if v > math.MaxInt64 {
+			return "", fmt.Errorf("value %d is greater than max int64", v)
+		}
+
+		return strconv.FormatUint(v, 10), nil
+	case float32:
+		return formatFloat(float64(v), 32, "float4"), nil
+	case float64:
+		return formatFloat(v, 64, "float8"), nil
+	case fmt.Stringer:
+		return quoteString(v.String()), nil
+	}
+
+	if value.Kind() == reflect.Ptr {
+		return formatQueryArg(value.Elem().Interface())
+	}
+
+	return "", fmt.Errorf("unsupported argument type %T", arg)
+}
+
+// formatFloat renders the special values as the typed string literals, since there are no numeric literals for them
+func formatFloat(v float64, bitSize int, typeName string) string {
+	switch {
+	case math.IsNaN(v):
+		return "'NaN'::" + typeName
+	case math.IsInf(v, 1):
+		return "'Infinity'::" + typeName
+	case math.IsInf(v, -1):
+		return "'-Infinity'::" + typeName
+	}
+
+	return strconv.FormatFloat(v, 'f', -1, bitSize)
+}
+
+// quoteString relies on `standard_conforming_strings`, otherwise backslashes would be treated as escape characters
+func quoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,101 @@
+package postgresql
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInlineQueryArgs(t *testing.T) {
+	type testCase struct {
+		testName string
+		query    string
+		args     []any
+		output   string
+		err      bool
+	}
+
+	text := "abc"
+	dec := decimal.RequireFromString("-12.345")
+
+	tcs := []testCase{
+		{
+			testName: "no_args",
+			query:    `SELECT "col" FROM "tab"`,
+			output:   `SELECT "col" FROM "tab"`,
+		},
+		{
+			testName: "numbers_and_bool",
+			query:    `SELECT "col" FROM "tab" WHERE ("a" = $1) AND ("b" > -$2) AND ("c" = $3)`,
+			args:     []any{int32(-1), float64(0.5), true},
+			output:   `SELECT "col" FROM "tab" WHERE ("a" =  -1 ) AND ("b" > - 0.5 ) AND ("c" =  true )`,
+		},
+		{
+			testName: "special_floats",
+			query:    `SELECT "col" FROM "tab" WHERE "a" IN ($1, $2, $3)`,
+			args:     []any{math.NaN(), float32(math.Inf(1)), math.Inf(-1)},
+			output:   `SELECT "col" FROM "tab" WHERE "a" IN ( 'NaN'::float8 ,  'Infinity'::float4 ,  '-Infinity'::float8 )`,
+		},
+		{
+			testName: "strings",
+			query:    `SELECT "col" FROM "tab" WHERE ("a" = $1) AND ("b" = $2)`,
+			args:     []any{`it's \ quoted`, []byte("ab")},
+			output:   `SELECT "col" FROM "tab" WHERE ("a" =  'it''s \ quoted' ) AND ("b" =  '\x6162' )`,
+		},
+		{
+			testName: "optional_values",
+			query:    `SELECT "col" FROM "tab" WHERE ("a" = $1) AND ("b" = $2) AND ("c" = $3)`,
+			args:     []any{&text, (*int64)(nil), &dec},
+			output:   `SELECT "col" FROM "tab" WHERE ("a" =  'abc' ) AND ("b" =  null ) AND ("c" =  '-12.345' )`,
+		},
+		{
+			testName: "time",
+			query:    `SELECT "col" FROM "tab" WHERE "a" < $1`,
+			args:     []any{time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC)},
+			output:   `SELECT "col" FROM "tab" WHERE "a" <  '2024-01-02 03:04:05.000006Z' `,
+		},
+		{
+			testName: "placeholders_in_quotes",
+			query:    `SELECT "$1" FROM "tab" WHERE ("a" = '$1') AND ("b" = $1 OR "c" = $1)`,
+			args:     []any{int64(1)},
+			output:   `SELECT "$1" FROM "tab" WHERE ("a" = '$1') AND ("b" =  1  OR "c" =  1 )`,
+		},
+		{
+			testName: "not_enough_args",
+			query:    `SELECT "col" FROM "tab" WHERE ("a" = $1) AND ("b" = $2)`,
+			args:     []any{int64(1)},
+			err:      true,
+		},
+		{
+			testName: "unused_arg",
+			query:    `SELECT "col" FROM "tab" WHERE "a" = $1`,
+			args:     []any{int64(1), int64(2)},
+			err:      true,
+		},
+		{
+			testName: "uint64_overflow",
+			query:    `SELECT "col" FROM "tab" WHERE "a" = $1`,
+			args:     []any{uint64(1 << 63)},
+			err:      true,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			output, err := inlineQueryArgs(tc.query, tc.args)
+			if tc.err {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
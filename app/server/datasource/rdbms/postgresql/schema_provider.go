@@ -0,0 +1,244 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+var (
+	_ rdbms_utils.SchemaProvider          = (*schemaProvider)(nil)
+	_ rdbms_utils.TextCastColumnsProvider = (*schemaProvider)(nil)
+)
+
+// schemaProvider describes the tables with the help of the information schema,
+// while the results of the native queries are described by their metadata.
+type schemaProvider struct {
+	tableSchemaProvider rdbms_utils.SchemaProvider
+	typeMapper          datasource.TypeMapper
+}
+
+func (p *schemaProvider) GetSchema(
+	ctx context.Context,
+	logger *zap.Logger,
+	connMgr rdbms_utils.ConnectionManager,
+	request *api_service_protos.TDescribeTableRequest,
+) (*api_service_protos.TSchema, error) {
+	if request.Query == "" {
+		return p.tableSchemaProvider.GetSchema(ctx, logger, connMgr, request)
+	}
+
+	sb, err := p.makeNativeQuerySchemaBuilder(ctx, logger, connMgr, request)
+	if err != nil {
+		return nil, fmt.Errorf("make schema builder: %w", err)
+	}
+
+	schema, err := sb.Build(logger)
+	if err != nil {
+		return nil, fmt.Errorf("build schema for native query: %w", err)
+	}
+
+	return schema, nil
+}
+
+func (p *schemaProvider) GetTextCastColumns(
+	ctx context.Context,
+	logger *zap.Logger,
+	connMgr rdbms_utils.ConnectionManager,
+	request *api_service_protos.TDescribeTableRequest,
+) (map[string]struct{}, error) {
+	if request.Query == "" {
+		provider, ok := p.tableSchemaProvider.(rdbms_utils.TextCastColumnsProvider)
+		if !ok {
+			return nil, nil
+		}
+
+		return provider.GetTextCastColumns(ctx, logger, connMgr, request)
+	}
+
+	sb, err := p.makeNativeQuerySchemaBuilder(ctx, logger, connMgr, request)
+	if err != nil {
+		return nil, fmt.Errorf("make schema builder: %w", err)
+	}
+
+	return sb.TextCastColumns(), nil
+}
+
+func (p *schemaProvider) makeNativeQuerySchemaBuilder(
+	ctx context.Context,
+	logger *zap.Logger,
+	connMgr rdbms_utils.ConnectionManager,
+	request *api_service_protos.TDescribeTableRequest,
+) (*rdbms_utils.SchemaBuilder, error) {
+	params := &rdbms_utils.ConnectionParams{
+		Ctx:                ctx,
+		Logger:             logger,
+		DataSourceInstance: request.DataSourceInstance,
+		QueryPhase:         rdbms_utils.QueryPhaseDescribeTable,
+	}
+
+	cs, err := connMgr.Make(params)
+	if err != nil {
+		return nil, fmt.Errorf("make connection: %w", err)
+	}
+
+	defer connMgr.Release(ctx, logger, cs)
+
+	// We asked for a single connection
+	conn := cs[0]
+
+	fields, err := describeNativeQueryFields(ctx, logger, conn, request.Query)
+	if err != nil {
+		return nil, fmt.Errorf("describe native query fields: %w", err)
+	}
+
+	typeNames, err := getTypeNames(ctx, logger, conn, fields)
+	if err != nil {
+		return nil, fmt.Errorf("get type names: %w", err)
+	}
+
+	sb := rdbms_utils.NewSchemaBuilder(p.typeMapper, request.TypeMappingSettings, request.DataSourceInstance.GetKind())
+
+	for i, field := range fields {
+		cd := &datasource.ColumnDescription{
+			Name: field.Name,
+			Type: typeNames[i],
+		}
+
+		if field.DataTypeOID == pgtype.NumericOID {
+			cd.Precision, cd.Scale = numericPrecisionAndScale(field.TypeModifier)
+		}
+
+		if err = sb.AddColumn(cd); err != nil {
+			return nil, fmt.Errorf("add column `%s` to schema builder: %w", cd.Name, err)
+		}
+	}
+
+	return sb, nil
+}
+
+// describeNativeQueryFields runs the native query without reading any rows to obtain the result metadata
+func describeNativeQueryFields(
+	ctx context.Context,
+	logger *zap.Logger,
+	conn rdbms_utils.Connection,
+	query string,
+) ([]pgconn.FieldDescription, error) {
+	queryText, err := NativeQueryMetadataQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("make native query metadata query: %w", err)
+	}
+
+	queryResult, err := conn.Query(&rdbms_utils.QueryParams{
+		Ctx:       ctx,
+		Logger:    logger,
+		QueryText: queryText,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	pgRows, ok := queryResult.Rows.(rows)
+	if !ok {
+		common.LogCloserError(logger, queryResult, "close query result")
+
+		return nil, fmt.Errorf("unexpected rows type %T", queryResult.Rows)
+	}
+
+	// copy metadata, because it belongs to the rows that are going to be closed
+	fields := append([]pgconn.FieldDescription(nil), pgRows.FieldDescriptions()...)
+
+	// the connection must be released before the next query
+	pgRows.Rows.Close()
+
+	if err := pgRows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return fields, nil
+}
+
+func getTypeNames(
+	ctx context.Context,
+	logger *zap.Logger,
+	conn rdbms_utils.Connection,
+	fields []pgconn.FieldDescription,
+) ([]string, error) {
+	oids := make([]uint32, 0, len(fields))
+	for _, field := range fields {
+		oids = append(oids, field.DataTypeOID)
+	}
+
+	queryText, queryArgs := NativeQueryTypeNamesQuery(oids)
+
+	queryResult, err := conn.Query(&rdbms_utils.QueryParams{
+		Ctx:       ctx,
+		Logger:    logger,
+		QueryText: queryText,
+		QueryArgs: queryArgs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	defer common.LogCloserError(logger, queryResult, "close query result")
+
+	typeNames := make([]string, 0, len(fields))
+
+	resultRows := queryResult.Rows
+
+	for resultRows.Next() {
+		var typeName string
+
+		if err := resultRows.Scan(&typeName); err != nil {
+			return nil, fmt.Errorf("rows scan: %w", err)
+		}
+
+		typeNames = append(typeNames, typeName)
+	}
+
+	if err := resultRows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration: %w", err)
+	}
+
+	if len(typeNames) != len(fields) {
+		return nil, fmt.Errorf("resolved %d type names for %d fields", len(typeNames), len(fields))
+	}
+
+	return typeNames, nil
+}
+
+// numericTypeModifierOffset is the size of the header added by PostgreSQL to the type modifiers
+const numericTypeModifierOffset = 4
+
+// numericPrecisionAndScale decodes the type modifier of the numeric type.
+// Unconstrained numerics have no type modifier.
+func numericPrecisionAndScale(typeModifier int32) (*uint8, *int8) {
+	if typeModifier < numericTypeModifierOffset {
+		return nil, nil
+	}
+
+	value := typeModifier - numericTypeModifierOffset
+	precision := uint8((value >> 16) & 0xffff)
+	scale := int8(value & 0xffff)
+
+	return &precision, &scale
+}
+
+func NewSchemaProvider(
+	typeMapper datasource.TypeMapper,
+	tableSchemaProvider rdbms_utils.SchemaProvider,
+) rdbms_utils.SchemaProvider {
+	return &schemaProvider{
+		tableSchemaProvider: tableSchemaProvider,
+		typeMapper:          typeMapper,
+	}
+}
@@ -0,0 +1,19 @@
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumericPrecisionAndScale(t *testing.T) {
+	// unconstrained numeric
+	precision, scale := numericPrecisionAndScale(-1)
+	require.Nil(t, precision)
+	require.Nil(t, scale)
+
+	// numeric(10, 2)
+	precision, scale = numericPrecisionAndScale((10<<16 | 2) + numericTypeModifierOffset)
+	require.Equal(t, uint8(10), *precision)
+	require.Equal(t, int8(2), *scale)
+}
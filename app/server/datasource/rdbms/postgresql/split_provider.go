@@ -12,6 +12,7 @@ import (
 	"github.com/ydb-platform/fq-connector-go/app/config"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
 )
 
 var _ rdbms_utils.SplitProvider = (*splitProviderImpl)(nil)
@@ -27,8 +28,17 @@ func (s *splitProviderImpl) ListSplits(
 	resultChan, slct, ctx, logger := params.ResultChan, params.Select, params.Ctx, params.Logger
 	schemaName, tableName := slct.DataSourceInstance.GetPgOptions().Schema, slct.From.Table
 
-	// If splitting is disabled, return single split for any table
-	if !s.cfg.Enabled {
+	// If splitting is disabled, return single split for any table.
+	// Aggregations and groupings must be computed over the whole table, so they also require single split.
+	// Ordering would be lost when the splits are read in parallel, so it also can't be split.
+	// The same is true for joins, because the other tables must be read in full,
+	// and for native queries, because there is no table to obtain the statistics from.
+	singleSplit := !s.cfg.Enabled ||
+		common.SelectWhatHasAggregations(slct.GetWhat()) || len(slct.GetGroupBy().GetColumns()) > 0 ||
+		len(slct.GetOrderBy().GetKeys()) > 0 ||
+		len(slct.GetFrom().GetJoins()) > 0 || slct.GetFrom().GetQuery() != ""
+
+	if singleSplit {
 		if err := s.listSingleSplit(ctx, slct, resultChan); err != nil {
 			return fmt.Errorf("list single split: %w", err)
 		}
@@ -456,6 +466,10 @@ func makeSplit(
 	}
 }
 
+func (s *splitProviderImpl) SplittingEnabled() bool {
+	return s.cfg.Enabled
+}
+
 func NewSplitProvider(cfg *config.TPostgreSQLConfig_TSplitting) rdbms_utils.SplitProvider {
 	return &splitProviderImpl{
 		cfg: cfg,
@@ -0,0 +1,72 @@
+package postgresql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestListSplitsSingleSplit(t *testing.T) {
+	type testCase struct {
+		testName string
+		slct     *api_service_protos.TSelect
+	}
+
+	tcs := []testCase{
+		{
+			testName: "group_by",
+			slct: &api_service_protos.TSelect{
+				GroupBy: &api_service_protos.TSelect_TGroupBy{Columns: []string{"col0"}},
+			},
+		},
+		{
+			testName: "order_by",
+			slct: &api_service_protos.TSelect{
+				OrderBy: &api_service_protos.TSelect_TOrderBy{
+					Keys: []*api_service_protos.TSelect_TOrderBy_TSortKey{{Column: "col0"}},
+				},
+			},
+		},
+	}
+
+	logger := common.NewTestLogger(t)
+	splitProvider := NewSplitProvider(&config.TPostgreSQLConfig_TSplitting{Enabled: true})
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			tc.slct.From = &api_service_protos.TSelect_TFrom{Table: "tab"}
+			tc.slct.What = rdbms_utils.NewDefaultWhat()
+			tc.slct.DataSourceInstance = &api_common.TGenericDataSourceInstance{
+				Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+				Options: &api_common.TGenericDataSourceInstance_PgOptions{
+					PgOptions: &api_common.TPostgreSQLDataSourceOptions{Schema: "public"},
+				},
+			}
+
+			resultChan := make(chan *datasource.ListSplitResult, 1)
+
+			// the table statistics must not be requested, so there's no need in connection manager
+			err := splitProvider.ListSplits(&rdbms_utils.ListSplitsParams{
+				Ctx:        context.Background(),
+				Logger:     logger,
+				Select:     tc.slct,
+				ResultChan: resultChan,
+			})
+			require.NoError(t, err)
+			require.Len(t, resultChan, 1)
+
+			result := <-resultChan
+			require.IsType(t, &TSplitDescription_Single{}, result.Description.(*TSplitDescription).Payload)
+		})
+	}
+}
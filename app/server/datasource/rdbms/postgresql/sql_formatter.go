@@ -133,12 +133,13 @@ func (f sqlFormatter) RenderSelectQueryText(
 	case *TSplitDescription_Single:
 		return f.renderSelectQueryTextSingle(sb, parts), nil
 	case *TSplitDescription_HistogramBounds:
-		out, err := f.renderSelectQueryTextWithHistogramBounds(sb, parts, t.HistogramBounds)
-		if err != nil {
+		if _, err := f.renderSelectQueryTextWithHistogramBounds(sb, parts, t.HistogramBounds); err != nil {
 			return "", fmt.Errorf("render select query text with histogram bounds: %w", err)
 		}
 
-		return out, nil
+		rdbms_utils.WriteTailClauses(sb, parts)
+
+		return sb.String(), nil
 	default:
 		return "", fmt.Errorf("unknown splitting mode: %v", t)
 	}
@@ -153,6 +154,8 @@ func (sqlFormatter) renderSelectQueryTextSingle(
 		sb.WriteString(parts.WhereClause)
 	}
 
+	rdbms_utils.WriteTailClauses(sb, parts)
+
 	return sb.String()
 }
 
@@ -252,6 +255,40 @@ func (sqlFormatter) RenderBetween(value, least, greatest string) (string, error)
 	return fmt.Sprintf("%s BETWEEN %s AND %s", value, least, greatest), nil
 }
 
+func (sqlFormatter) FormatTextCast(valueExpr string) (string, error) {
+	return fmt.Sprintf("CAST(%s AS TEXT)", valueExpr), nil
+}
+
+// SUM returns NUMERIC for BIGINT arguments, AVG returns NUMERIC for all integer arguments
+var aggregationCastTemplates = map[Ydb.Type_PrimitiveTypeId]string{
+	Ydb.Type_INT64:  "CAST(%s AS BIGINT)",
+	Ydb.Type_DOUBLE: "CAST(%s AS DOUBLE PRECISION)",
+}
+
+func (sqlFormatter) SupportedAggregations() []api_service_protos.TSelect_TAggregation_EFunction {
+	return rdbms_utils.AllAggregations
+}
+
+func (sqlFormatter) FormatAggregation(
+	function api_service_protos.TSelect_TAggregation_EFunction,
+	argExpr string,
+	resultType *Ydb.Type,
+) (string, error) {
+	return rdbms_utils.FormatAggregationDefault(function, argExpr, resultType, aggregationCastTemplates)
+}
+
+func (sqlFormatter) SupportsJoins() bool {
+	return true
+}
+
+func (sqlFormatter) SupportsNativeQuery() bool {
+	return true
+}
+
+func (f sqlFormatter) FormatOrderBy(orderBy *api_service_protos.TSelect_TOrderBy) (string, error) {
+	return rdbms_utils.FormatOrderByDefault(f, orderBy)
+}
+
 func NewSQLFormatter(cfg *config.TPushdownConfig) rdbms_utils.SQLFormatter {
 	return sqlFormatter{cfg: cfg}
 }
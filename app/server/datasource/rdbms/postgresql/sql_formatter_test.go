@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -15,6 +16,7 @@ import (
 
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
@@ -94,6 +96,353 @@ func TestMakeSelectQuery(t *testing.T) {
 			outputYdbTypes:   []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32)},
 			err:              nil,
 		},
+		{
+			testName: "limit_offset",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: &api_service_protos.TSelect_TWhat{
+					Items: []*api_service_protos.TSelect_TWhat_TItem{
+						{
+							Payload: &api_service_protos.TSelect_TWhat_TItem_Column{
+								Column: &ydb.Column{
+									Name: "col",
+									Type: common.MakePrimitiveType(ydb.Type_INT32),
+								},
+							},
+						},
+					},
+				},
+				Limit: &api_service_protos.TSelect_TLimit{Limit: 10, Offset: 5},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+				},
+			},
+			splitDescription: singleSplit,
+			outputQuery:      `SELECT "col" FROM "tab" LIMIT 15`, // OFFSET is applied by the engine
+			outputArgs:       []any{},
+			outputYdbTypes:   []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32)},
+			err:              nil,
+		},
+		{
+			testName: "limit_offset_histogram_bounds",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: &api_service_protos.TSelect_TWhat{
+					Items: []*api_service_protos.TSelect_TWhat_TItem{
+						{
+							Payload: &api_service_protos.TSelect_TWhat_TItem_Column{
+								Column: &ydb.Column{
+									Name: "col",
+									Type: common.MakePrimitiveType(ydb.Type_INT32),
+								},
+							},
+						},
+					},
+				},
+				Limit: &api_service_protos.TSelect_TLimit{Limit: 10, Offset: 5},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+				},
+			},
+			splitDescription: &TSplitDescription{
+				Payload: &TSplitDescription_HistogramBounds{
+					HistogramBounds: &TSplitDescription_THistogramBounds{
+						ColumnName: "col",
+						Payload: &TSplitDescription_THistogramBounds_Int64Bounds{
+							Int64Bounds: &TInt64Bounds{
+								Upper: &wrapperspb.Int64Value{Value: 100},
+							},
+						},
+					},
+				},
+			},
+			outputQuery:    `SELECT "col" FROM "tab" WHERE "col" < 100 LIMIT 15`,
+			outputArgs:     []any{},
+			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32)},
+			err:            nil,
+		},
+		{
+			testName: "order_by_limit",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: &api_service_protos.TSelect_TWhat{
+					Items: []*api_service_protos.TSelect_TWhat_TItem{
+						{
+							Payload: &api_service_protos.TSelect_TWhat_TItem_Column{
+								Column: &ydb.Column{
+									Name: "col",
+									Type: common.MakePrimitiveType(ydb.Type_INT32),
+								},
+							},
+						},
+					},
+				},
+				OrderBy: &api_service_protos.TSelect_TOrderBy{
+					Keys: []*api_service_protos.TSelect_TOrderBy_TSortKey{
+						{Column: "ts", Direction: api_service_protos.TSelect_TOrderBy_DESC},
+						{Column: "col"},
+					},
+				},
+				Limit: &api_service_protos.TSelect_TLimit{Limit: 100},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+				},
+			},
+			splitDescription: singleSplit,
+			outputQuery:      `SELECT "col" FROM "tab" ORDER BY "ts" DESC, "col" ASC LIMIT 100`,
+			outputArgs:       []any{},
+			outputYdbTypes:   []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32)},
+			err:              nil,
+		},
+		{
+			testName: "left_join",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "orders",
+					Joins: []*api_service_protos.TSelect_TFrom_TJoin{
+						{
+							Kind:  api_service_protos.TSelect_TFrom_TJoin_LEFT,
+							Table: "users",
+							Keys: []*api_service_protos.TSelect_TFrom_TJoin_TKey{
+								{LeftColumn: "orders.user_id", RightColumn: "users.id"},
+							},
+						},
+					},
+				},
+				What: &api_service_protos.TSelect_TWhat{
+					Items: []*api_service_protos.TSelect_TWhat_TItem{
+						{
+							Payload: &api_service_protos.TSelect_TWhat_TItem_Column{
+								Column: &ydb.Column{
+									Name: "orders.id",
+									Type: common.MakePrimitiveType(ydb.Type_INT32),
+								},
+							},
+						},
+						{
+							Payload: &api_service_protos.TSelect_TWhat_TItem_Column{
+								Column: &ydb.Column{
+									Name: "users.name",
+									Type: common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_UTF8)),
+								},
+							},
+						},
+					},
+				},
+				Where: &api_service_protos.TSelect_TWhere{
+					FilterTyped: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_IsNotNull{
+							IsNotNull: &api_service_protos.TPredicate_TIsNotNull{
+								Value: rdbms_utils.NewColumnExpression("users.name"),
+							},
+						},
+					},
+				},
+				OrderBy: &api_service_protos.TSelect_TOrderBy{
+					Keys: []*api_service_protos.TSelect_TOrderBy_TSortKey{
+						{Column: "orders.id"},
+					},
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+				},
+			},
+			splitDescription: singleSplit,
+			outputQuery: `SELECT "orders"."id", "users"."name" FROM "orders" LEFT JOIN "users" ` +
+				`ON "orders"."user_id" = "users"."id" WHERE ("users"."name" IS NOT NULL) ORDER BY "orders"."id" ASC`,
+			outputArgs: []any{},
+			outputYdbTypes: []*ydb.Type{
+				common.MakePrimitiveType(ydb.Type_INT32),
+				common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_UTF8)),
+			},
+			err: nil,
+		},
+		{
+			testName: "join_key_of_unknown_table",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "orders",
+					Joins: []*api_service_protos.TSelect_TFrom_TJoin{
+						{
+							Table: "users",
+							Keys: []*api_service_protos.TSelect_TFrom_TJoin_TKey{
+								{LeftColumn: "items.user_id", RightColumn: "users.id"},
+							},
+						},
+					},
+				},
+				What: &api_service_protos.TSelect_TWhat{
+					Items: []*api_service_protos.TSelect_TWhat_TItem{
+						{
+							Payload: &api_service_protos.TSelect_TWhat_TItem_Column{
+								Column: &ydb.Column{
+									Name: "orders.id",
+									Type: common.MakePrimitiveType(ydb.Type_INT32),
+								},
+							},
+						},
+					},
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+				},
+			},
+			splitDescription: singleSplit,
+			err:              common.ErrInvalidRequest,
+		},
+		{
+			testName: "native_query",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Query: "SELECT id, name FROM users WHERE active -- only active users\n; ",
+				},
+				What: &api_service_protos.TSelect_TWhat{
+					Items: []*api_service_protos.TSelect_TWhat_TItem{
+						{
+							Payload: &api_service_protos.TSelect_TWhat_TItem_Column{
+								Column: &ydb.Column{
+									Name: "name",
+									Type: common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_UTF8)),
+								},
+							},
+						},
+					},
+				},
+				Where: &api_service_protos.TSelect_TWhere{
+					FilterTyped: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_Comparison{
+							Comparison: &api_service_protos.TPredicate_TComparison{
+								LeftValue:  rdbms_utils.NewColumnExpression("id"),
+								Operation:  api_service_protos.TPredicate_TComparison_G,
+								RightValue: rdbms_utils.NewInt32ValueExpression(42),
+							},
+						},
+					},
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+				},
+			},
+			splitDescription: singleSplit,
+			outputQuery: "SELECT \"name\" FROM (\nSELECT id, name FROM users WHERE active -- only active users\n) AS \"q\" " +
+				"WHERE (\"id\" > $1)",
+			outputArgs: []any{int32(42)},
+			outputYdbTypes: []*ydb.Type{
+				common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_UTF8)),
+			},
+			err: nil,
+		},
+		{
+			testName: "aggregations_group_by",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: &api_service_protos.TSelect_TWhat{
+					Items: []*api_service_protos.TSelect_TWhat_TItem{
+						{
+							Payload: &api_service_protos.TSelect_TWhat_TItem_Column{
+								Column: &ydb.Column{
+									Name: "key",
+									Type: common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_UTF8)),
+								},
+							},
+						},
+						{
+							Payload: &api_service_protos.TSelect_TWhat_TItem_Aggregation{
+								Aggregation: &api_service_protos.TSelect_TAggregation{
+									Function: api_service_protos.TSelect_TAggregation_COUNT,
+									Alias:    "cnt",
+								},
+							},
+						},
+						{
+							Payload: &api_service_protos.TSelect_TWhat_TItem_Aggregation{
+								Aggregation: &api_service_protos.TSelect_TAggregation{
+									Function: api_service_protos.TSelect_TAggregation_SUM,
+									Argument: &ydb.Column{
+										Name: "col",
+										Type: common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_INT32)),
+									},
+									Alias: "total",
+								},
+							},
+						},
+						{
+							Payload: &api_service_protos.TSelect_TWhat_TItem_Aggregation{
+								Aggregation: &api_service_protos.TSelect_TAggregation{
+									Function: api_service_protos.TSelect_TAggregation_MAX,
+									Argument: &ydb.Column{
+										Name: "col",
+										Type: common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_INT32)),
+									},
+									Alias: "greatest",
+								},
+							},
+						},
+					},
+				},
+				GroupBy: &api_service_protos.TSelect_TGroupBy{Columns: []string{"key"}},
+				Limit:   &api_service_protos.TSelect_TLimit{Limit: 10},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+				},
+			},
+			splitDescription: singleSplit,
+			outputQuery: `SELECT "key", CAST(COUNT(*) AS BIGINT) AS "cnt", CAST(SUM("col") AS BIGINT) AS "total", ` +
+				`MAX("col") AS "greatest" FROM "tab" GROUP BY "key" LIMIT 10`,
+			outputArgs: []any{},
+			outputYdbTypes: []*ydb.Type{
+				common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_UTF8)),
+				common.MakePrimitiveType(ydb.Type_INT64),
+				common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_INT64)),
+				common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_INT32)),
+			},
+			err: nil,
+		},
+		{
+			testName: "aggregations_column_not_in_group_by",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: &api_service_protos.TSelect_TWhat{
+					Items: []*api_service_protos.TSelect_TWhat_TItem{
+						{
+							Payload: &api_service_protos.TSelect_TWhat_TItem_Column{
+								Column: &ydb.Column{
+									Name: "key",
+									Type: common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_UTF8)),
+								},
+							},
+						},
+						{
+							Payload: &api_service_protos.TSelect_TWhat_TItem_Aggregation{
+								Aggregation: &api_service_protos.TSelect_TAggregation{
+									Function: api_service_protos.TSelect_TAggregation_AVG,
+									Argument: &ydb.Column{
+										Name: "col",
+										Type: common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_INT32)),
+									},
+									Alias: "average",
+								},
+							},
+						},
+					},
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+				},
+			},
+			splitDescription: singleSplit,
+			err:              common.ErrInvalidRequest,
+		},
 		{
 			testName: "is_null",
 			selectReq: &api_service_protos.TSelect{
@@ -174,6 +523,36 @@ func TestMakeSelectQuery(t *testing.T) {
 			outputYdbTypes:   []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
 			err:              nil,
 		},
+		{
+			testName: "in",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Where: &api_service_protos.TSelect_TWhere{
+					FilterTyped: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_In{
+							In: &api_service_protos.TPredicate_TIn{
+								Value: rdbms_utils.NewColumnExpression("col1"),
+								Set: []*api_service_protos.TExpression{
+									rdbms_utils.NewInt32ValueExpression(1),
+									rdbms_utils.NewInt32ValueExpression(2),
+								},
+							},
+						},
+					},
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+				},
+			},
+			splitDescription: singleSplit,
+			outputQuery:      `SELECT "col0", "col1" FROM "tab" WHERE (("col1" = $1) OR ("col1" = $2))`,
+			outputArgs:       []any{int32(1), int32(2)},
+			outputYdbTypes:   []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
+			err:              nil,
+		},
 		{
 			testName: "bool_column",
 			selectReq: &api_service_protos.TSelect{
@@ -576,6 +955,7 @@ func TestMakeSelectQuery(t *testing.T) {
 				context.Background(),
 				logger,
 				formatter,
+				NewTypeMapper(),
 				&api_service_protos.TSplit{
 					Select: tc.selectReq,
 					Payload: &api_service_protos.TSplit_Description{
@@ -609,3 +989,166 @@ func TestMakeSelectQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeSelectQueryResidualPredicate(t *testing.T) {
+	supported := &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_Comparison{
+			Comparison: &api_service_protos.TPredicate_TComparison{
+				Operation:  api_service_protos.TPredicate_TComparison_EQ,
+				LeftValue:  rdbms_utils.NewColumnExpression("col1"),
+				RightValue: rdbms_utils.NewInt32ValueExpression(32),
+			},
+		},
+	}
+
+	unsupported := &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_Comparison{
+			Comparison: &api_service_protos.TPredicate_TComparison{
+				Operation:  api_service_protos.TPredicate_TComparison_EQ,
+				LeftValue:  rdbms_utils.NewColumnExpression("col2"),
+				RightValue: rdbms_utils.NewTextValueExpression("text"),
+			},
+		},
+	}
+
+	type testCase struct {
+		testName          string
+		predicate         *api_service_protos.TPredicate
+		residualPredicate *api_service_protos.TPredicate
+		limitPushedDown   bool
+	}
+
+	tcs := []testCase{
+		{
+			testName:          "pushed_down",
+			predicate:         supported,
+			residualPredicate: nil,
+			limitPushedDown:   true,
+		},
+		{
+			testName:          "not_pushed_down",
+			predicate:         unsupported,
+			residualPredicate: unsupported,
+		},
+		{
+			testName: "partially_pushed_down",
+			predicate: &api_service_protos.TPredicate{
+				Payload: &api_service_protos.TPredicate_Conjunction{
+					Conjunction: &api_service_protos.TPredicate_TConjunction{
+						Operands: []*api_service_protos.TPredicate{supported, unsupported},
+					},
+				},
+			},
+			residualPredicate: unsupported,
+		},
+	}
+
+	logger := common.NewTestLogger(t)
+	formatter := NewSQLFormatter(nil)
+
+	splitDescriptionBytes, err := protojson.Marshal(&TSplitDescription{Payload: &TSplitDescription_Single{}})
+	require.NoError(t, err)
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			split := &api_service_protos.TSplit{
+				Select: &api_service_protos.TSelect{
+					From:  &api_service_protos.TSelect_TFrom{Table: "tab"},
+					What:  rdbms_utils.NewDefaultWhat(),
+					Where: &api_service_protos.TSelect_TWhere{FilterTyped: tc.predicate},
+					Limit: &api_service_protos.TSelect_TLimit{Limit: 10},
+					DataSourceInstance: &api_common.TGenericDataSourceInstance{
+						Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+					},
+				},
+				Payload: &api_service_protos.TSplit_Description{
+					Description: splitDescriptionBytes,
+				},
+			}
+
+			query, err := rdbms_utils.MakeSelectQuery(
+				context.Background(),
+				logger,
+				formatter,
+				NewTypeMapper(),
+				split,
+				api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+				"tab",
+			)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tc.residualPredicate, query.ResidualPredicate), query.ResidualPredicate)
+
+			// the rows filtered out after reading would make the result shorter than LIMIT
+			require.Equal(t, tc.limitPushedDown, strings.Contains(query.QueryText, "LIMIT"), query.QueryText)
+		})
+	}
+
+	t.Run("aggregation_not_pushed_down", func(t *testing.T) {
+		// the source would aggregate the rows that must have been filtered out
+		split := &api_service_protos.TSplit{
+			Select: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{Table: "tab"},
+				What: &api_service_protos.TSelect_TWhat{
+					Items: []*api_service_protos.TSelect_TWhat_TItem{
+						{
+							Payload: &api_service_protos.TSelect_TWhat_TItem_Aggregation{
+								Aggregation: &api_service_protos.TSelect_TAggregation{
+									Function: api_service_protos.TSelect_TAggregation_COUNT,
+									Alias:    "cnt",
+								},
+							},
+						},
+					},
+				},
+				Where: &api_service_protos.TSelect_TWhere{FilterTyped: unsupported},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+				},
+			},
+			Payload: &api_service_protos.TSplit_Description{
+				Description: splitDescriptionBytes,
+			},
+		}
+
+		_, err := rdbms_utils.MakeSelectQuery(
+			context.Background(),
+			logger,
+			formatter,
+			NewTypeMapper(),
+			split,
+			api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+			"tab",
+		)
+		require.ErrorIs(t, err, common.ErrUnimplementedOperation)
+	})
+}
+
+func TestDescribeCapabilities(t *testing.T) {
+	formatter := NewSQLFormatter(&config.TPushdownConfig{EnableTimestampPushdown: true})
+	splitProvider := NewSplitProvider(&config.TPostgreSQLConfig_TSplitting{Enabled: true})
+
+	capabilities := rdbms_utils.DescribeCapabilities(formatter, splitProvider)
+
+	require.Contains(t, capabilities.Predicates, api_service_protos.TCapabilities_PREDICATE_BETWEEN)
+	require.Contains(t, capabilities.Predicates, api_service_protos.TCapabilities_PREDICATE_IN)
+	require.NotContains(t, capabilities.Predicates, api_service_protos.TCapabilities_PREDICATE_REGEXP)
+	require.Contains(t, capabilities.Expressions, api_service_protos.TCapabilities_EXPRESSION_COLUMN)
+	require.Contains(t, capabilities.Types, ydb.Type_INT32)
+	require.Contains(t, capabilities.Types, ydb.Type_TIMESTAMP)
+	require.Equal(t, rdbms_utils.AllAggregations, capabilities.Aggregations)
+	require.True(t, capabilities.Limit)
+	require.True(t, capabilities.OrderBy)
+	require.True(t, capabilities.Splitting)
+	require.Equal(
+		t,
+		[]api_service_protos.TReadSplitsRequest_EFormat{api_service_protos.TReadSplitsRequest_ARROW_IPC_STREAMING},
+		capabilities.Formats,
+	)
+
+	capabilities = rdbms_utils.DescribeCapabilities(NewSQLFormatter(&config.TPushdownConfig{}), NewSplitProvider(&config.TPostgreSQLConfig_TSplitting{}))
+
+	require.NotContains(t, capabilities.Types, ydb.Type_TIMESTAMP)
+	require.False(t, capabilities.Splitting)
+}
@@ -1,6 +1,8 @@
 package postgresql
 
 import (
+	"fmt"
+
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
 )
@@ -19,3 +21,32 @@ func TableMetadataQuery(
 
 	return query, &args
 }
+
+// NativeQueryMetadataQuery returns the query that describes the result of the native query without reading any rows.
+// The columns are obtained from the result metadata, so their type names must be resolved with NativeQueryTypeNamesQuery.
+func NativeQueryMetadataQuery(query string) (string, error) {
+	from, err := rdbms_utils.FormatNativeQuery(sqlFormatter{}, query)
+	if err != nil {
+		return "", fmt.Errorf("format native query: %w", err)
+	}
+
+	return "SELECT * FROM " + from + " LIMIT 0", nil
+}
+
+// NativeQueryTypeNamesQuery resolves the OIDs of the types into the type names
+// in the same notation as `information_schema.columns.data_type`.
+func NativeQueryTypeNamesQuery(oids []uint32) (string, *rdbms_utils.QueryArgs) {
+	query := "SELECT format_type(t.oid::oid, NULL) " +
+		"FROM unnest($1::bigint[]) WITH ORDINALITY AS t(oid, n) ORDER BY t.n"
+
+	values := make([]int64, 0, len(oids))
+	for _, oid := range oids {
+		values = append(values, int64(oid))
+	}
+
+	var args rdbms_utils.QueryArgs
+
+	args.AddUntyped(values)
+
+	return query, &args
+}
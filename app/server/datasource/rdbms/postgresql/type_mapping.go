@@ -15,6 +15,7 @@ import (
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils/decimal"
@@ -22,6 +23,7 @@ import (
 )
 
 var _ datasource.TypeMapper = typeMapper{}
+var _ datasource.AggregationTypeMapper = typeMapper{}
 
 type typeMapper struct{}
 
@@ -241,6 +243,13 @@ func transformerFromOIDs(oids []uint32, ydbTypes []*Ydb.Type, cc conversion.Coll
 					return appendValuePtrToArrowBuilder[time.Time, uint16, *array.Uint16Builder](
 						&cast.Time, builder, cast.Valid, cc.Date())
 				})
+			case Ydb.Type_DATE32:
+				appenders = append(appenders, func(acceptor any, builder array.Builder) error {
+					cast := acceptor.(*pgtype.Date)
+
+					return appendValuePtrToArrowBuilder[time.Time, int32, *array.Int32Builder](
+						&cast.Time, builder, cast.Valid, cc.Date32())
+				})
 			default:
 				return nil, fmt.Errorf("unexpected ydb type %v with type oid %d: %w", ydbTypes[i], oid, common.ErrDataTypeNotSupported)
 			}
@@ -267,6 +276,13 @@ func transformerFromOIDs(oids []uint32, ydbTypes []*Ydb.Type, cc conversion.Coll
 					return appendValuePtrToArrowBuilder[time.Time, uint64, *array.Uint64Builder](
 						&cast.Time, builder, cast.Valid, cc.Timestamp())
 				})
+			case Ydb.Type_TIMESTAMP64:
+				appenders = append(appenders, func(acceptor any, builder array.Builder) error {
+					cast := acceptor.(*pgtype.Timestamp)
+
+					return appendValuePtrToArrowBuilder[time.Time, int64, *array.Int64Builder](
+						&cast.Time, builder, cast.Valid, cc.Timestamp64())
+				})
 			default:
 				return nil, fmt.Errorf("unexpected ydb type %v with type oid %d: %w", ydbTypes[i], oid, common.ErrDataTypeNotSupported)
 			}
@@ -326,6 +342,16 @@ func appendValuePtrToArrowBuilder[
 	return utils.AppendValueToArrowBuilder[IN, OUT, AB](value, builder, conv)
 }
 
+// The results are converted to these types with aggregationCastTemplates
+var aggregationResultTypeNames = map[Ydb.Type_PrimitiveTypeId]string{
+	Ydb.Type_INT64:  "bigint",
+	Ydb.Type_DOUBLE: "double precision",
+}
+
+func (tm typeMapper) AggregationToYDBColumn(agg *api_service_protos.TSelect_TAggregation) (*Ydb.Column, error) {
+	return rdbms_utils.AggregationToYDBColumnDefault(tm, agg, aggregationResultTypeNames)
+}
+
 func NewTypeMapper() datasource.TypeMapper {
 	return typeMapper{}
 }
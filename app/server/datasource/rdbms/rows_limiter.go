@@ -0,0 +1,43 @@
+package rdbms
+
+import (
+	"sync/atomic"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+// rowsLimiter is shared between all the connections reading the same split.
+// It allows to stop reading as soon as the number of rows requested in LIMIT clause is obtained.
+type rowsLimiter struct {
+	enabled   bool
+	remaining atomic.Int64
+}
+
+// take reserves n rows and returns false if the limit was already reached before the call
+func (rl *rowsLimiter) take(n int64) bool {
+	if !rl.enabled {
+		return true
+	}
+
+	return rl.remaining.Add(-n)+n > 0
+}
+
+// exhausted returns true if there is no need to read rows anymore
+func (rl *rowsLimiter) exhausted() bool {
+	return rl.enabled && rl.remaining.Load() <= 0
+}
+
+func newRowsLimiter(limit *api_service_protos.TSelect_TLimit) *rowsLimiter {
+	rl := &rowsLimiter{}
+
+	if limit.GetLimit() == 0 {
+		return rl
+	}
+
+	// Data source returns `offset + limit` rows so that the engine could apply OFFSET by itself
+	// (see rdbms_utils.FormatLimitDefault).
+	rl.enabled = true
+	rl.remaining.Store(int64(limit.GetLimit() + limit.GetOffset()))
+
+	return rl
+}
@@ -0,0 +1,34 @@
+package rdbms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+func TestRowsLimiter(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		rl := newRowsLimiter(nil)
+		require.True(t, rl.take(1000))
+		require.False(t, rl.exhausted())
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		rl := newRowsLimiter(&api_service_protos.TSelect_TLimit{Limit: 2})
+		require.True(t, rl.take(1))
+		require.False(t, rl.exhausted())
+		require.True(t, rl.take(1))
+		require.True(t, rl.exhausted())
+		require.False(t, rl.take(1))
+	})
+
+	t.Run("LimitWithOffset", func(t *testing.T) {
+		rl := newRowsLimiter(&api_service_protos.TSelect_TLimit{Limit: 2, Offset: 3})
+		require.True(t, rl.take(4))
+		require.True(t, rl.take(10))
+		require.True(t, rl.exhausted())
+		require.False(t, rl.take(1))
+	})
+}
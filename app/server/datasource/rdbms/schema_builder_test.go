@@ -9,6 +9,7 @@ import (
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/clickhouse"
@@ -25,6 +26,7 @@ func TestSchemaBuilder(t *testing.T) {
 
 	type testCase struct {
 		name                string
+		kind                api_common.EGenericDataSourceKind
 		typeMapper          datasource.TypeMapper
 		supportedTypesMatch []nameToType
 		unsupportedTypes    []nameToType
@@ -33,6 +35,7 @@ func TestSchemaBuilder(t *testing.T) {
 	testCases := []testCase{
 		{
 			name:       "PostgreSQL",
+			kind:       api_common.EGenericDataSourceKind_POSTGRESQL,
 			typeMapper: postgresql.NewTypeMapper(),
 			supportedTypesMatch: []nameToType{
 				{
@@ -62,6 +65,7 @@ func TestSchemaBuilder(t *testing.T) {
 		},
 		{
 			name:       "ClickHouse",
+			kind:       api_common.EGenericDataSourceKind_CLICKHOUSE,
 			typeMapper: clickhouse.NewTypeMapper(),
 			supportedTypesMatch: []nameToType{
 				{"Int32", &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_INT32}}},
@@ -76,7 +80,7 @@ func TestSchemaBuilder(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(fmt.Sprintf("Positive_%s", tc.name), func(t *testing.T) {
 			tc := tc
-			sb := rdbms_utils.NewSchemaBuilder(tc.typeMapper, &api_service_protos.TTypeMappingSettings{})
+			sb := rdbms_utils.NewSchemaBuilder(tc.typeMapper, &api_service_protos.TTypeMappingSettings{}, tc.kind)
 
 			for num, supportedType := range tc.supportedTypesMatch {
 				desc := &datasource.ColumnDescription{
@@ -117,7 +121,7 @@ func TestSchemaBuilder(t *testing.T) {
 
 		t.Run(fmt.Sprintf("EmptyTable_%s", tc.name), func(t *testing.T) {
 			tc := tc
-			sb := rdbms_utils.NewSchemaBuilder(tc.typeMapper, &api_service_protos.TTypeMappingSettings{})
+			sb := rdbms_utils.NewSchemaBuilder(tc.typeMapper, &api_service_protos.TTypeMappingSettings{}, tc.kind)
 
 			for num, unsuppType := range tc.unsupportedTypes {
 				desc := &datasource.ColumnDescription{
@@ -141,4 +145,73 @@ func TestSchemaBuilder(t *testing.T) {
 		require.ErrorIs(t, err, common.ErrTableDoesNotExist)
 		require.Nil(t, schema)
 	})
+	t.Run("TypeMappingRules", func(t *testing.T) {
+		textType := common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8))
+
+		settings := &api_service_protos.TTypeMappingSettings{
+			Rules: []*api_service_protos.TTypeMappingRule{
+				{
+					Kind:       api_common.EGenericDataSourceKind_POSTGRESQL,
+					SourceType: "TIME",
+					YdbType:    common.MakePrimitiveType(Ydb.Type_UTF8),
+				},
+				{
+					Kind:       api_common.EGenericDataSourceKind_CLICKHOUSE,
+					SourceType: "interval",
+				},
+				{
+					SourceType: "point*",
+				},
+			},
+		}
+
+		sb := rdbms_utils.NewSchemaBuilder(postgresql.NewTypeMapper(), settings, api_common.EGenericDataSourceKind_POSTGRESQL)
+
+		for _, desc := range []*datasource.ColumnDescription{
+			{Name: "col_bigint", Type: "bigint"},
+			{Name: "col_time", Type: "time"},
+			{Name: "col_interval", Type: "interval"},
+			{Name: "col_point", Type: "point3d"},
+		} {
+			require.NoError(t, sb.AddColumn(desc))
+		}
+
+		schema, err := sb.Build(common.NewTestLogger(t))
+		require.NoError(t, err)
+		require.Len(t, schema.Columns, 3)
+		require.Equal(t, "col_bigint", schema.Columns[0].Name)
+		require.Equal(t, "col_time", schema.Columns[1].Name)
+		require.True(t, proto.Equal(textType, schema.Columns[1].Type), schema.Columns[1].Type)
+		require.Equal(t, "col_point", schema.Columns[2].Name)
+		require.True(t, proto.Equal(textType, schema.Columns[2].Type), schema.Columns[2].Type)
+
+		require.Equal(t, map[string]struct{}{"col_time": {}, "col_point": {}}, sb.TextCastColumns())
+	})
+
+	t.Run("UnsupportedTypesAsText", func(t *testing.T) {
+		settings := &api_service_protos.TTypeMappingSettings{UnsupportedTypesAsText: true}
+
+		sb := rdbms_utils.NewSchemaBuilder(clickhouse.NewTypeMapper(), settings, api_common.EGenericDataSourceKind_CLICKHOUSE)
+		require.NoError(t, sb.AddColumn(&datasource.ColumnDescription{Name: "col_uuid", Type: "UUID"}))
+
+		schema, err := sb.Build(common.NewTestLogger(t))
+		require.NoError(t, err)
+		require.Len(t, schema.Columns, 1)
+		require.True(
+			t,
+			proto.Equal(common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8)), schema.Columns[0].Type),
+			schema.Columns[0].Type,
+		)
+	})
+
+	t.Run("InvalidTypeMappingRule", func(t *testing.T) {
+		settings := &api_service_protos.TTypeMappingSettings{
+			Rules: []*api_service_protos.TTypeMappingRule{
+				{SourceType: "time", YdbType: common.MakePrimitiveType(Ydb.Type_INT64)},
+			},
+		}
+
+		sb := rdbms_utils.NewSchemaBuilder(postgresql.NewTypeMapper(), settings, api_common.EGenericDataSourceKind_POSTGRESQL)
+		require.ErrorIs(t, sb.AddColumn(&datasource.ColumnDescription{Name: "col_time", Type: "time"}), common.ErrInvalidRequest)
+	})
 }
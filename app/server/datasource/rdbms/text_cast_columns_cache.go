@@ -0,0 +1,76 @@
+package rdbms
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"google.golang.org/protobuf/proto"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+const (
+	textCastColumnsCacheMaxKeys = 4096
+	// the table may be altered, so the columns shouldn't be kept for too long
+	textCastColumnsCacheTTL = 5 * time.Minute
+)
+
+// TextCastColumnsCache keeps the names of the columns that must be converted into text
+// according to the user-defined type mapping rules (see rdbms_utils.TextCastColumnsProvider),
+// so that every ReadSplit request doesn't need to fetch the table schema once again.
+// It should be instantiated once per server.
+// The column names don't give access to the data: the tables are always read with the credentials of the request,
+// so the credentials are not a part of the key.
+type TextCastColumnsCache struct {
+	cache *ristretto.Cache[string, map[string]struct{}]
+}
+
+func (c *TextCastColumnsCache) get(request *api_service_protos.TDescribeTableRequest) (map[string]struct{}, bool, error) {
+	key, err := makeTextCastColumnsCacheKey(request)
+	if err != nil {
+		return nil, false, fmt.Errorf("make text cast columns cache key: %w", err)
+	}
+
+	columns, found := c.cache.Get(key)
+
+	return columns, found, nil
+}
+
+func (c *TextCastColumnsCache) put(request *api_service_protos.TDescribeTableRequest, columns map[string]struct{}) error {
+	key, err := makeTextCastColumnsCacheKey(request)
+	if err != nil {
+		return fmt.Errorf("make text cast columns cache key: %w", err)
+	}
+
+	c.cache.SetWithTTL(key, columns, 1, textCastColumnsCacheTTL)
+
+	return nil
+}
+
+func makeTextCastColumnsCacheKey(request *api_service_protos.TDescribeTableRequest) (string, error) {
+	request = proto.Clone(request).(*api_service_protos.TDescribeTableRequest)
+	if request.DataSourceInstance != nil {
+		request.DataSourceInstance.Credentials = nil
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("marshal request: %w", err)
+	}
+
+	return string(data), nil
+}
+
+func NewTextCastColumnsCache() (*TextCastColumnsCache, error) {
+	cache, err := ristretto.NewCache(&ristretto.Config[string, map[string]struct{}]{
+		NumCounters: 10 * textCastColumnsCacheMaxKeys,
+		MaxCost:     textCastColumnsCacheMaxKeys, // every table costs 1
+		BufferItems: 64,                          // reasonable default
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ristretto new cache: %w", err)
+	}
+
+	return &TextCastColumnsCache{cache: cache}, nil
+}
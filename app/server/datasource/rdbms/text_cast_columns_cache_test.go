@@ -0,0 +1,78 @@
+package rdbms
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+type textCastColumnsProviderStub struct {
+	columns map[string]struct{}
+	calls   int
+}
+
+func (p *textCastColumnsProviderStub) GetTextCastColumns(
+	_ context.Context,
+	_ *zap.Logger,
+	_ rdbms_utils.ConnectionManager,
+	_ *api_service_protos.TDescribeTableRequest,
+) (map[string]struct{}, error) {
+	p.calls++
+
+	return p.columns, nil
+}
+
+func TestTextCastColumnsCache(t *testing.T) {
+	logger := common.NewTestLogger(t)
+
+	cache, err := NewTextCastColumnsCache()
+	require.NoError(t, err)
+
+	ds := &dataSourceImpl{textCastColumnsCache: cache}
+	provider := &textCastColumnsProviderStub{columns: map[string]struct{}{"col_point": {}}}
+
+	makeRequest := func(table, password string) *api_service_protos.TDescribeTableRequest {
+		return &api_service_protos.TDescribeTableRequest{
+			DataSourceInstance: &api_common.TGenericDataSourceInstance{
+				Kind:     api_common.EGenericDataSourceKind_POSTGRESQL,
+				Endpoint: &api_common.TGenericEndpoint{Host: "localhost", Port: 5432},
+				Database: "db",
+				Credentials: &api_common.TGenericCredentials{
+					Payload: &api_common.TGenericCredentials_Basic{
+						Basic: &api_common.TGenericCredentials_TBasic{Username: "user", Password: password},
+					},
+				},
+			},
+			Table: table,
+			TypeMappingSettings: &api_service_protos.TTypeMappingSettings{
+				UnsupportedTypesAsText: true,
+			},
+		}
+	}
+
+	getColumns := func(request *api_service_protos.TDescribeTableRequest) {
+		columns, err := ds.getTextCastColumns(context.Background(), logger, provider, request)
+		require.NoError(t, err)
+		require.Equal(t, provider.columns, columns)
+
+		cache.cache.Wait()
+	}
+
+	getColumns(makeRequest("tab", "password"))
+	require.Equal(t, 1, provider.calls)
+
+	// the schema is requested only once per table
+	getColumns(makeRequest("tab", "password"))
+	getColumns(makeRequest("tab", "another_password"))
+	require.Equal(t, 1, provider.calls)
+
+	getColumns(makeRequest("another_tab", "password"))
+	require.Equal(t, 2, provider.calls)
+}
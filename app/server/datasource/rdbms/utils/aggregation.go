@@ -0,0 +1,261 @@
+package utils //nolint:revive
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// AllAggregations is the list of the aggregate functions that the most of SQL dialects support
+var AllAggregations = []api_service_protos.TSelect_TAggregation_EFunction{
+	api_service_protos.TSelect_TAggregation_COUNT,
+	api_service_protos.TSelect_TAggregation_SUM,
+	api_service_protos.TSelect_TAggregation_MIN,
+	api_service_protos.TSelect_TAggregation_MAX,
+	api_service_protos.TSelect_TAggregation_AVG,
+}
+
+// FormatAggregationFunction renders `FUNCTION(argExpr)`, or `FUNCTION(*)` if there is no argument
+func FormatAggregationFunction(function api_service_protos.TSelect_TAggregation_EFunction, argExpr string) string {
+	if argExpr == "" {
+		argExpr = "*"
+	}
+
+	return fmt.Sprintf("%s(%s)", function.String(), argExpr)
+}
+
+// FormatAggregationDefault renders the aggregate function. The results of MIN and MAX have the type of the argument,
+// while the results of the other functions are converted with the dialect-specific template
+// (like `CAST(%s AS BIGINT)`) chosen by the result type.
+func FormatAggregationDefault(
+	function api_service_protos.TSelect_TAggregation_EFunction,
+	argExpr string,
+	resultType *Ydb.Type,
+	castTemplates map[Ydb.Type_PrimitiveTypeId]string,
+) (string, error) {
+	out := FormatAggregationFunction(function, argExpr)
+
+	switch function {
+	case api_service_protos.TSelect_TAggregation_MIN, api_service_protos.TSelect_TAggregation_MAX:
+		return out, nil
+	case api_service_protos.TSelect_TAggregation_COUNT,
+		api_service_protos.TSelect_TAggregation_SUM,
+		api_service_protos.TSelect_TAggregation_AVG:
+		return FormatAggregationCast(out, resultType, castTemplates)
+	default:
+		return "", fmt.Errorf("unexpected function %s: %w", function, common.ErrUnimplementedOperation)
+	}
+}
+
+// FormatAggregationCast converts expression into the type corresponding to resultType
+func FormatAggregationCast(
+	expr string,
+	resultType *Ydb.Type,
+	castTemplates map[Ydb.Type_PrimitiveTypeId]string,
+) (string, error) {
+	typeID, err := common.YdbTypeToYdbPrimitiveTypeID(resultType)
+	if err != nil {
+		return "", fmt.Errorf("get result type: %w", err)
+	}
+
+	template, ok := castTemplates[typeID]
+	if !ok {
+		return "", fmt.Errorf("aggregation result type %s: %w", typeID, common.ErrDataTypeNotSupported)
+	}
+
+	return fmt.Sprintf(template, expr), nil
+}
+
+// AggregationToYDBColumnDefault derives the type of the aggregate function result with the type mapper of the data source.
+// The results of COUNT, SUM and AVG are converted to the source types listed in resultTypeNames
+// (keyed by the generic result type, see common.AggregationToYDBColumn), which are then mapped like any other column.
+// The results of MIN and MAX have the type of the argument, that has already been derived by the type mapper.
+func AggregationToYDBColumnDefault(
+	typeMapper datasource.TypeMapper,
+	agg *api_service_protos.TSelect_TAggregation,
+	resultTypeNames map[Ydb.Type_PrimitiveTypeId]string,
+) (*Ydb.Column, error) {
+	column, err := common.AggregationToYDBColumn(agg)
+	if err != nil {
+		return nil, err
+	}
+
+	if agg.Function == api_service_protos.TSelect_TAggregation_MIN || agg.Function == api_service_protos.TSelect_TAggregation_MAX {
+		return column, nil
+	}
+
+	typeID, err := common.YdbTypeToYdbPrimitiveTypeID(column.Type)
+	if err != nil {
+		return nil, fmt.Errorf("get result type: %w", err)
+	}
+
+	typeName, ok := resultTypeNames[typeID]
+	if !ok {
+		return nil, fmt.Errorf("aggregation result type %s: %w", typeID, common.ErrDataTypeNotSupported)
+	}
+
+	mapped, err := typeMapper.SQLTypeToYDBColumn(&datasource.ColumnDescription{Name: agg.Alias, Type: typeName}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("map result type '%s': %w", typeName, err)
+	}
+
+	// Nullability depends on the function rather than on the source type: COUNT never returns NULL,
+	// while the other functions do it for the empty input.
+	resultType := mapped.Type
+	if optional := resultType.GetOptionalType(); optional != nil {
+		resultType = optional.Item
+	}
+
+	if column.Type.GetOptionalType() != nil {
+		resultType = common.MakeOptionalType(resultType)
+	}
+
+	return &Ydb.Column{Name: agg.Alias, Type: resultType}, nil
+}
+
+// aggregationToYDBColumn prefers the type mapper of the data source over the generic type derivation
+func aggregationToYDBColumn(typeMapper datasource.TypeMapper, agg *api_service_protos.TSelect_TAggregation) (*Ydb.Column, error) {
+	if aggregationTypeMapper, ok := typeMapper.(datasource.AggregationTypeMapper); ok {
+		return aggregationTypeMapper.AggregationToYDBColumn(agg)
+	}
+
+	return common.AggregationToYDBColumn(agg)
+}
+
+// selectWhatToYDBColumns returns the columns that will be returned by the data source for the given items
+func selectWhatToYDBColumns(typeMapper datasource.TypeMapper, selectWhat *api_service_protos.TSelect_TWhat) ([]*Ydb.Column, error) {
+	ydbColumns := make([]*Ydb.Column, 0, len(selectWhat.GetItems()))
+
+	for i, item := range selectWhat.GetItems() {
+		var (
+			column *Ydb.Column
+			err    error
+		)
+
+		if agg := item.GetAggregation(); agg != nil {
+			column, err = aggregationToYDBColumn(typeMapper, agg)
+		} else {
+			column, err = common.SelectWhatItemToYDBColumn(item)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("item #%d (%v): %w", i, item, err)
+		}
+
+		ydbColumns = append(ydbColumns, column)
+	}
+
+	return ydbColumns, nil
+}
+
+func formatWhatWithAggregations(
+	formatter SQLFormatter,
+	typeMapper datasource.TypeMapper,
+	src *api_service_protos.TSelect_TWhat,
+	tableName string,
+) (string, error) {
+	var sb strings.Builder
+
+	for i, item := range src.Items {
+		switch t := item.GetPayload().(type) {
+		case *api_service_protos.TSelect_TWhat_TItem_Column:
+			// Format single column in order to keep formatter-specific conversions
+			out, err := formatter.FormatWhat(
+				&api_service_protos.TSelect_TWhat{Items: []*api_service_protos.TSelect_TWhat_TItem{item}},
+				tableName,
+			)
+			if err != nil {
+				return "", fmt.Errorf("format column '%s': %w", t.Column.GetName(), err)
+			}
+
+			sb.WriteString(out)
+		case *api_service_protos.TSelect_TWhat_TItem_Aggregation:
+			out, err := formatAggregation(formatter, typeMapper, t.Aggregation)
+			if err != nil {
+				return "", fmt.Errorf("format aggregation '%s': %w", t.Aggregation.GetAlias(), err)
+			}
+
+			sb.WriteString(out)
+		default:
+			return "", fmt.Errorf("unexpected item type %T: %w", t, common.ErrInvalidRequest)
+		}
+
+		if i != len(src.Items)-1 {
+			sb.WriteString(", ")
+		}
+	}
+
+	return sb.String(), nil
+}
+
+func formatAggregation(
+	formatter SQLFormatter,
+	typeMapper datasource.TypeMapper,
+	agg *api_service_protos.TSelect_TAggregation,
+) (string, error) {
+	if !slices.Contains(formatter.SupportedAggregations(), agg.Function) {
+		return "", fmt.Errorf("function %s: %w", agg.Function, common.ErrUnimplementedOperation)
+	}
+
+	resultColumn, err := aggregationToYDBColumn(typeMapper, agg)
+	if err != nil {
+		return "", fmt.Errorf("aggregation to YDB column: %w", err)
+	}
+
+	var argExpr string
+
+	if agg.Argument != nil {
+		argExpr = formatter.SanitiseIdentifier(agg.Argument.GetName())
+	}
+
+	out, err := formatter.FormatAggregation(agg.Function, argExpr, resultColumn.Type)
+	if err != nil {
+		return "", fmt.Errorf("format aggregation: %w", err)
+	}
+
+	return fmt.Sprintf("%s AS %s", out, formatter.SanitiseIdentifier(agg.Alias)), nil
+}
+
+// formatGroupBy renders the list of grouping keys. When aggregations are requested,
+// every column in the SELECT clause must be a grouping key.
+func formatGroupBy(formatter SQLFormatter, slct *api_service_protos.TSelect) (string, error) {
+	keys := slct.GetGroupBy().GetColumns()
+
+	if common.SelectWhatHasAggregations(slct.GetWhat()) || len(keys) > 0 {
+		for _, item := range slct.GetWhat().GetItems() {
+			column := item.GetColumn()
+			if column == nil {
+				continue
+			}
+
+			if !slices.Contains(keys, column.Name) {
+				return "", fmt.Errorf(
+					"column '%s' is neither a grouping key nor an aggregation: %w",
+					column.Name, common.ErrInvalidRequest,
+				)
+			}
+		}
+	}
+
+	var sb strings.Builder
+
+	for i, key := range keys {
+		if key == "" {
+			return "", fmt.Errorf("empty grouping key: %w", common.ErrInvalidRequest)
+		}
+
+		sb.WriteString(formatter.SanitiseIdentifier(key))
+
+		if i != len(keys)-1 {
+			sb.WriteString(", ")
+		}
+	}
+
+	return sb.String(), nil
+}
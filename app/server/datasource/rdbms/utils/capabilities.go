@@ -0,0 +1,171 @@
+package utils //nolint:revive
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// DescribeCapabilities probes the formatter and the split provider in order to find out
+// which parts of the query can be pushed down into the data source.
+// Since the formatters take the pushdown config into account, so do the capabilities.
+func DescribeCapabilities(formatter SQLFormatter, splitProvider SplitProvider) *api_service_protos.TCapabilities {
+	out := datasource.NewCapabilities()
+
+	// These predicates are rendered by predicateBuilder in the same way for every dialect
+	out.Predicates = []api_service_protos.TCapabilities_EPredicate{
+		api_service_protos.TCapabilities_PREDICATE_NEGATION,
+		api_service_protos.TCapabilities_PREDICATE_CONJUNCTION,
+		api_service_protos.TCapabilities_PREDICATE_DISJUNCTION,
+		api_service_protos.TCapabilities_PREDICATE_IS_NULL,
+		api_service_protos.TCapabilities_PREDICATE_IS_NOT_NULL,
+		api_service_protos.TCapabilities_PREDICATE_COMPARISON,
+		api_service_protos.TCapabilities_PREDICATE_BOOL_EXPRESSION,
+		api_service_protos.TCapabilities_PREDICATE_COALESCE,
+		api_service_protos.TCapabilities_PREDICATE_IN,
+	}
+
+	if between, err := formatter.RenderBetween("a", "b", "c"); err == nil && between != "" {
+		out.Predicates = append(out.Predicates, api_service_protos.TCapabilities_PREDICATE_BETWEEN)
+	}
+
+	if _, err := formatter.FormatRegexp("a", "b"); err == nil {
+		out.Predicates = append(out.Predicates, api_service_protos.TCapabilities_PREDICATE_REGEXP)
+	}
+
+	if _, err := formatter.FormatStartsWith("a", "b"); err == nil {
+		out.Predicates = append(out.Predicates, api_service_protos.TCapabilities_PREDICATE_LIKE)
+	}
+
+	out.Types = describeSupportedTypes(formatter)
+	out.Expressions = describeSupportedExpressions(formatter, len(out.Types) > 0)
+
+	_, err := formatter.FormatLimit(&api_service_protos.TSelect_TLimit{Limit: 1}, &api_service_protos.TSplit{})
+	out.Limit = !errors.Is(err, common.ErrUnimplementedOperation)
+
+	_, err = formatter.FormatOrderBy(&api_service_protos.TSelect_TOrderBy{})
+	out.OrderBy = !errors.Is(err, common.ErrUnimplementedOperation)
+
+	out.Aggregations = formatter.SupportedAggregations()
+	out.Splitting = splitProvider.SplittingEnabled()
+	out.Joins = formatter.SupportsJoins()
+
+	return out
+}
+
+func describeSupportedTypes(formatter SQLFormatter) []Ydb.Type_PrimitiveTypeId {
+	typeIDs := make([]Ydb.Type_PrimitiveTypeId, 0, len(Ydb.Type_PrimitiveTypeId_name))
+
+	for value := range Ydb.Type_PrimitiveTypeId_name {
+		typeID := Ydb.Type_PrimitiveTypeId(value)
+		if typeID == Ydb.Type_PRIMITIVE_TYPE_ID_UNSPECIFIED {
+			continue
+		}
+
+		expression := &api_service_protos.TExpression{
+			Payload: &api_service_protos.TExpression_TypedValue{
+				TypedValue: &Ydb.TypedValue{Type: common.MakePrimitiveType(typeID)},
+			},
+		}
+
+		if formatter.SupportsExpression(expression) {
+			typeIDs = append(typeIDs, typeID)
+		}
+	}
+
+	slices.Sort(typeIDs)
+
+	return typeIDs
+}
+
+func describeSupportedExpressions(
+	formatter SQLFormatter,
+	typedValuesSupported bool,
+) []api_service_protos.TCapabilities_EExpression {
+	var out []api_service_protos.TCapabilities_EExpression
+
+	if typedValuesSupported {
+		out = append(out, api_service_protos.TCapabilities_EXPRESSION_TYPED_VALUE)
+	}
+
+	column := &api_service_protos.TExpression{
+		Payload: &api_service_protos.TExpression_Column{Column: "a"},
+	}
+
+	// Only the expressions implemented by predicateBuilder are probed
+	probes := []struct {
+		expression *api_service_protos.TExpression
+		kind       api_service_protos.TCapabilities_EExpression
+		render     func() error
+	}{
+		{
+			expression: column,
+			kind:       api_service_protos.TCapabilities_EXPRESSION_COLUMN,
+		},
+		{
+			expression: &api_service_protos.TExpression{
+				Payload: &api_service_protos.TExpression_ArithmeticalExpression{
+					ArithmeticalExpression: &api_service_protos.TExpression_TArithmeticalExpression{
+						LeftValue:  column,
+						RightValue: column,
+					},
+				},
+			},
+			kind: api_service_protos.TCapabilities_EXPRESSION_ARITHMETICAL_EXPRESSION,
+		},
+		{
+			expression: &api_service_protos.TExpression{
+				Payload: &api_service_protos.TExpression_Null{Null: &api_service_protos.TExpression_TNull{}},
+			},
+			kind: api_service_protos.TCapabilities_EXPRESSION_NULL,
+		},
+		{
+			expression: &api_service_protos.TExpression{
+				Payload: &api_service_protos.TExpression_If{
+					If: &api_service_protos.TExpression_TIf{ThenExpression: column, ElseExpression: column},
+				},
+			},
+			kind: api_service_protos.TCapabilities_EXPRESSION_IF,
+			render: func() error {
+				_, err := formatter.FormatIf("a", "b", "c")
+
+				return err
+			},
+		},
+		{
+			expression: &api_service_protos.TExpression{
+				Payload: &api_service_protos.TExpression_Cast{
+					Cast: &api_service_protos.TExpression_TCast{
+						Value: column,
+						Type:  common.MakePrimitiveType(Ydb.Type_INT64),
+					},
+				},
+			},
+			kind: api_service_protos.TCapabilities_EXPRESSION_CAST,
+			render: func() error {
+				_, err := formatter.FormatCast("a", common.MakePrimitiveType(Ydb.Type_INT64))
+
+				return err
+			},
+		},
+	}
+
+	for _, probe := range probes {
+		if !formatter.SupportsExpression(probe.expression) {
+			continue
+		}
+
+		if probe.render != nil && probe.render() != nil {
+			continue
+		}
+
+		out = append(out, probe.kind)
+	}
+
+	return out
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/memory"
 	"go.uber.org/zap"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
@@ -42,6 +43,16 @@ type QueryParams struct {
 	Logger    *zap.Logger
 	QueryText string
 	QueryArgs *QueryArgs
+	// Types and names of the columns that will be returned by the query in terms of YDB type system.
+	// Filled only for the queries reading table data, so that the connections
+	// producing Arrow records could build them without the row transformer.
+	YdbColumns []*Ydb.Column
+	// If no columns were requested, the query selects a constant,
+	// and only the number of rows in the result matters.
+	EmptyColumns bool
+	// Allocator accounting the memory of the request. Filled along with YdbColumns
+	// and used by the connections producing Arrow records.
+	Allocator memory.Allocator
 }
 
 type QueryResult struct {
@@ -117,6 +128,12 @@ type SelectQueryParts struct {
 	SelectClause string
 	FromClause   string
 	WhereClause  string
+	// GroupByClause contains the list of grouping keys (without GROUP BY keyword)
+	GroupByClause string
+	// OrderByClause contains the list of sort keys (without ORDER BY keyword)
+	OrderByClause string
+	// LimitClause is placed at the very end of the query
+	LimitClause string
 }
 
 type SQLFormatter interface {
@@ -154,6 +171,29 @@ type SQLFormatter interface {
 	ValidateWhere(where *api_service_protos.TSelect_TWhere) error
 	// Renders `value BETWEEN least AND greatest`
 	RenderBetween(value, least, greatest string) (string, error)
+	// Renders the expression converting value into its text representation
+	FormatTextCast(valueExpr string) (string, error)
+	// FormatLimit renders the clause restricting the number of rows (LIMIT, FETCH FIRST, etc.).
+	// The split may be only a part of a table, so OFFSET cannot be applied to it:
+	// the first `offset + limit` rows of the split must be returned, and the engine applies OFFSET by itself.
+	FormatLimit(limit *api_service_protos.TSelect_TLimit, split *api_service_protos.TSplit) (string, error)
+	// FormatOrderBy renders the list of sort keys placed after ORDER BY keyword
+	FormatOrderBy(orderBy *api_service_protos.TSelect_TOrderBy) (string, error)
+	// SupportedAggregations returns the list of aggregate functions that can be pushed down into the data source
+	SupportedAggregations() []api_service_protos.TSelect_TAggregation_EFunction
+	// FormatAggregation renders the aggregate function applied to argExpr (empty for `COUNT(*)`).
+	// The result must be converted into the type that will be mapped into resultType by the type mapper.
+	FormatAggregation(
+		function api_service_protos.TSelect_TAggregation_EFunction,
+		argExpr string,
+		resultType *Ydb.Type,
+	) (string, error)
+	// SupportsJoins tells if the tables of the same data source instance can be joined within a query.
+	// Qualified column names (`table.column`) are sanitised by splitting them into parts.
+	SupportsJoins() bool
+	// SupportsNativeQuery tells if the user-supplied query written in the native dialect
+	// of the data source can be wrapped into a subquery and read as a table.
+	SupportsNativeQuery() bool
 }
 
 type SchemaProvider interface {
@@ -165,6 +205,17 @@ type SchemaProvider interface {
 	) (*api_service_protos.TSchema, error)
 }
 
+// TextCastColumnsProvider is implemented by the schema providers supporting user-defined type mapping rules.
+// It returns the names of the columns that must be converted into text on the data source side.
+type TextCastColumnsProvider interface {
+	GetTextCastColumns(
+		ctx context.Context,
+		logger *zap.Logger,
+		connMgr ConnectionManager,
+		request *api_service_protos.TDescribeTableRequest,
+	) (map[string]struct{}, error)
+}
+
 type ListSplitsParams struct {
 	Ctx                   context.Context
 	Logger                *zap.Logger
@@ -180,4 +231,6 @@ type ListSplitsParams struct {
 // SplitProvider generates stream of splits - the description of the parts of a large external table
 type SplitProvider interface {
 	ListSplits(*ListSplitsParams) error
+	// SplittingEnabled returns true if the table may be split into several parts
+	SplittingEnabled() bool
 }
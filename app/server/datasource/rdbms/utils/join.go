@@ -0,0 +1,189 @@
+package utils //nolint:revive
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// joinFormatter renders the queries over the joined tables: all the column names
+// are qualified with the table names, so they are sanitised part by part.
+type joinFormatter struct {
+	SQLFormatter
+}
+
+func (f joinFormatter) SanitiseIdentifier(ident string) string {
+	table, column, ok := strings.Cut(ident, ".")
+	if !ok {
+		return f.SQLFormatter.SanitiseIdentifier(ident)
+	}
+
+	return f.SQLFormatter.SanitiseIdentifier(table) + "." + f.SQLFormatter.SanitiseIdentifier(column)
+}
+
+func (f joinFormatter) FormatWhat(src *api_service_protos.TSelect_TWhat, _ string) (string, error) {
+	return FormatWhatDefault(f, src), nil
+}
+
+func (f joinFormatter) FormatOrderBy(orderBy *api_service_protos.TSelect_TOrderBy) (string, error) {
+	return FormatOrderByDefault(f, orderBy)
+}
+
+// formatFrom renders the table name followed by the joined tables
+func formatFrom(formatter SQLFormatter, from *api_service_protos.TSelect_TFrom, tableName string) (string, error) {
+	var sb strings.Builder
+
+	sb.WriteString(formatter.FormatFrom(tableName))
+
+	tables := []string{tableName}
+
+	for _, join := range from.GetJoins() {
+		if err := validateJoin(join, tables); err != nil {
+			return "", fmt.Errorf("validate join of table '%s': %w", join.GetTable(), err)
+		}
+
+		switch join.Kind {
+		case api_service_protos.TSelect_TFrom_TJoin_KIND_UNSPECIFIED, api_service_protos.TSelect_TFrom_TJoin_INNER:
+			sb.WriteString(" INNER JOIN ")
+		case api_service_protos.TSelect_TFrom_TJoin_LEFT:
+			sb.WriteString(" LEFT JOIN ")
+		default:
+			return "", fmt.Errorf("unexpected join kind %v: %w", join.Kind, common.ErrInvalidRequest)
+		}
+
+		sb.WriteString(formatter.FormatFrom(join.Table))
+		sb.WriteString(" ON ")
+
+		for i, key := range join.Keys {
+			sb.WriteString(formatter.SanitiseIdentifier(key.LeftColumn))
+			sb.WriteString(" = ")
+			sb.WriteString(formatter.SanitiseIdentifier(key.RightColumn))
+
+			if i != len(join.Keys)-1 {
+				sb.WriteString(" AND ")
+			}
+		}
+
+		tables = append(tables, join.Table)
+	}
+
+	return sb.String(), nil
+}
+
+// validateJoin checks that the join keys refer to the joined tables
+func validateJoin(join *api_service_protos.TSelect_TFrom_TJoin, joinedTables []string) error {
+	if join.GetTable() == "" {
+		return common.ErrEmptyTableName
+	}
+
+	// Tables have no aliases, so self-joins are ambiguous
+	if slices.Contains(joinedTables, join.Table) {
+		return fmt.Errorf("table is joined twice: %w", common.ErrInvalidRequest)
+	}
+
+	if len(join.GetKeys()) == 0 {
+		return fmt.Errorf("no join keys: %w", common.ErrInvalidRequest)
+	}
+
+	for _, key := range join.Keys {
+		leftTable, _, err := splitQualifiedColumnName(key.LeftColumn)
+		if err != nil {
+			return fmt.Errorf("left column: %w", err)
+		}
+
+		if !slices.Contains(joinedTables, leftTable) {
+			return fmt.Errorf("left column '%s' refers to unknown table: %w", key.LeftColumn, common.ErrInvalidRequest)
+		}
+
+		rightTable, _, err := splitQualifiedColumnName(key.RightColumn)
+		if err != nil {
+			return fmt.Errorf("right column: %w", err)
+		}
+
+		if rightTable != join.Table {
+			return fmt.Errorf("right column '%s' refers to other table: %w", key.RightColumn, common.ErrInvalidRequest)
+		}
+	}
+
+	return nil
+}
+
+func splitQualifiedColumnName(name string) (string, string, error) {
+	table, column, ok := strings.Cut(name, ".")
+	if !ok || table == "" || column == "" {
+		return "", "", fmt.Errorf("column name '%s' is not qualified with table name: %w", name, common.ErrInvalidRequest)
+	}
+
+	return table, column, nil
+}
+
+// ValidateJoinColumns checks that the columns referred by the join keys and the SELECT clause
+// exist in the schemas of the joined tables.
+func ValidateJoinColumns(
+	ctx context.Context,
+	logger *zap.Logger,
+	schemaProvider SchemaProvider,
+	connMgr ConnectionManager,
+	slct *api_service_protos.TSelect,
+) error {
+	from := slct.GetFrom()
+
+	tables := make([]string, 0, len(from.GetJoins())+1)
+	tables = append(tables, from.GetTable())
+
+	for _, join := range from.GetJoins() {
+		tables = append(tables, join.GetTable())
+	}
+
+	columns := make(map[string]struct{})
+
+	for _, table := range tables {
+		schema, err := schemaProvider.GetSchema(ctx, logger, connMgr, &api_service_protos.TDescribeTableRequest{
+			DataSourceInstance: slct.DataSourceInstance,
+			Table:              table,
+		})
+		if err != nil {
+			return fmt.Errorf("get schema of table '%s': %w", table, err)
+		}
+
+		for _, column := range schema.GetColumns() {
+			columns[table+"."+column.Name] = struct{}{}
+		}
+	}
+
+	checkColumn := func(name string) error {
+		if _, ok := columns[name]; !ok {
+			return fmt.Errorf("column '%s' not found in joined tables: %w", name, common.ErrInvalidRequest)
+		}
+
+		return nil
+	}
+
+	for _, join := range from.GetJoins() {
+		for _, key := range join.GetKeys() {
+			if err := checkColumn(key.LeftColumn); err != nil {
+				return err
+			}
+
+			if err := checkColumn(key.RightColumn); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, item := range slct.GetWhat().GetItems() {
+		if column := item.GetColumn(); column != nil {
+			if err := checkColumn(column.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package utils //nolint:revive
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// NativeQueryAlias is the name of the subquery wrapping the user-supplied native query
+const NativeQueryAlias = "q"
+
+// FormatNativeQuery wraps the query written in the native dialect of the data source into a subquery,
+// so it can be placed after FROM (`SELECT ... FROM (<query>) AS q`).
+func FormatNativeQuery(formatter SQLFormatter, query string) (string, error) {
+	if !formatter.SupportsNativeQuery() {
+		return "", fmt.Errorf("native query: %w", common.ErrUnimplementedOperation)
+	}
+
+	// the trailing semicolon is allowed in a standalone query, but not in a subquery
+	query = strings.TrimRight(strings.TrimSpace(query), "; \t\n")
+	if query == "" {
+		return "", fmt.Errorf("empty native query: %w", common.ErrInvalidRequest)
+	}
+
+	// the query is put on the separate lines, so that its trailing line comment doesn't swallow the closing parenthesis
+	return "(\n" + query + "\n) AS " + formatter.SanitiseIdentifier(NativeQueryAlias), nil
+}
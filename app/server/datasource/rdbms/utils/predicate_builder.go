@@ -12,6 +12,7 @@ import (
 
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	filtering_utils "github.com/ydb-platform/fq-connector-go/app/server/filtering"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils/decimal"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
@@ -24,6 +25,8 @@ type predicateBuilder struct {
 	// In some filtering modes it's possible to suppress errors occurred during
 	// conjunction predicate construction.
 	conjunctionErrors []error
+	// The operands of the top-level conjunction that were not pushed down.
+	residualPredicates []*api_service_protos.TPredicate
 
 	// Abstraction leaked a bit.
 	// Remove this field after YQ-4191, KIKIMR-22852 is fixed.
@@ -467,6 +470,7 @@ func (pb *predicateBuilder) formatConjunction(
 
 			// For some filtering modes this kind of errors may be considered as non-fatal.
 			pb.conjunctionErrors = append(pb.conjunctionErrors, fmt.Errorf("format predicate: %w", err))
+			pb.residualPredicates = append(pb.residualPredicates, predicate)
 		} else {
 			if succeeded > 0 {
 				if succeeded == 1 {
@@ -672,6 +676,11 @@ func (pb *predicateBuilder) formatPredicate(
 		if err != nil {
 			return "", fmt.Errorf("format between: %w", err)
 		}
+	case *api_service_protos.TPredicate_In:
+		result, err = pb.formatIn(p.In)
+		if err != nil {
+			return "", fmt.Errorf("format in: %w", err)
+		}
 	default:
 		return "", fmt.Errorf("%w, type: %T", common.ErrUnimplementedPredicateType, p)
 	}
@@ -679,15 +688,17 @@ func (pb *predicateBuilder) formatPredicate(
 	return result, nil
 }
 
+// formatWhereClause renders the predicate in SQL. In the FILTERING_OPTIONAL mode it also returns
+// the part of the predicate that was not pushed down, so that the connector could apply it on its own.
 func formatWhereClause(
 	logger *zap.Logger,
 	filtering api_service_protos.TReadSplitsRequest_EFiltering,
 	formatter SQLFormatter,
 	where *api_service_protos.TSelect_TWhere,
 	dataSourceKind api_common.EGenericDataSourceKind, // remove after YQ-4191, KIKIMR-22852 is fixed
-) (string, *QueryArgs, error) {
+) (string, *QueryArgs, *api_service_protos.TPredicate, error) {
 	if where.FilterTyped == nil {
-		return "", nil, nil
+		return "", nil, nil, nil
 	}
 
 	pb := &predicateBuilder{formatter: formatter, args: &QueryArgs{}, dataSourceKind: dataSourceKind}
@@ -705,19 +716,49 @@ func formatWhereClause(
 		if common.OptionalFilteringAllowedErrors.Match(err) {
 			logger.Warn("considering pushdown error as acceptable", zap.Error(err))
 
-			return clause, pb.args, nil
+			return clause, pb.args, where.FilterTyped, nil
 		}
 
-		return clause, pb.args, err
+		if err != nil {
+			return clause, pb.args, nil, err
+		}
+
+		return clause, pb.args, filtering_utils.MakeConjunction(pb.residualPredicates), nil
 	case api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY:
 		// Pushdowning every expression is mandatory in this mode.
 		// If connector doesn't support some types or expressions, the request will fail.
-		return clause, pb.args, err
+		return clause, pb.args, nil, err
 	default:
-		return "", nil, fmt.Errorf("unknown filtering mode: %d", filtering)
+		return "", nil, nil, fmt.Errorf("unknown filtering mode: %d", filtering)
 	}
 }
 
+// formatIn renders `value IN (a, b)` as `(value = a OR value = b)`, so that the comparisons
+// get the same data source specific transformations as the standalone ones
+func (pb *predicateBuilder) formatIn(in *api_service_protos.TPredicate_TIn) (string, error) {
+	if len(in.Set) == 0 {
+		return "", fmt.Errorf("empty set: %w", common.ErrUnimplementedOperation)
+	}
+
+	disjunction := &api_service_protos.TPredicate_TDisjunction{
+		Operands: make([]*api_service_protos.TPredicate, 0, len(in.Set)),
+	}
+
+	for _, item := range in.Set {
+		disjunction.Operands = append(disjunction.Operands, &api_service_protos.TPredicate{
+			Payload: &api_service_protos.TPredicate_Comparison{
+				Comparison: &api_service_protos.TPredicate_TComparison{
+					Operation:  api_service_protos.TPredicate_TComparison_EQ,
+					LeftValue:  in.Value,
+					RightValue: item,
+				},
+			},
+		})
+	}
+
+	return pb.formatDisjunction(disjunction)
+}
+
 func (pb *predicateBuilder) FormatBetween(
 	b *api_service_protos.TPredicate_TBetween,
 	embedBool bool,
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
 	"github.com/ydb-platform/fq-connector-go/common"
@@ -17,11 +18,13 @@ type schemaItem struct {
 	columnDescription *datasource.ColumnDescription
 	ydbColumn         *Ydb.Column
 	err               error // clarifies the reason for the lack of support
+	textCast          bool  // column was mapped by user-defined rule and must be read as text
 }
 
 type SchemaBuilder struct {
 	typeMapper          datasource.TypeMapper
 	typeMappingSettings *api_service_protos.TTypeMappingSettings
+	kind                api_common.EGenericDataSourceKind
 	items               []*schemaItem
 }
 
@@ -32,6 +35,18 @@ func (sb *SchemaBuilder) AddColumn(columnDescription *datasource.ColumnDescripti
 
 	item.ydbColumn, item.err = sb.typeMapper.SQLTypeToYDBColumn(columnDescription, sb.typeMappingSettings)
 
+	// Give a chance to user-defined rules if the type is not supported natively
+	if errors.Is(item.err, common.ErrDataTypeNotSupported) && HasTypeMappingRules(sb.typeMappingSettings) {
+		ydbColumn, err := applyTypeMappingRules(sb.typeMappingSettings, sb.kind, columnDescription)
+
+		switch {
+		case err == nil:
+			item.ydbColumn, item.err, item.textCast = ydbColumn, nil, true
+		case !errors.Is(err, common.ErrDataTypeNotSupported):
+			return fmt.Errorf("apply type mapping rules (%s, %s): %w", columnDescription.Name, columnDescription.Type, err)
+		}
+	}
+
 	if item.err != nil && !errors.Is(item.err, common.ErrDataTypeNotSupported) {
 		return fmt.Errorf(
 			"sql type to ydb column (%s, %s): %w",
@@ -77,12 +92,28 @@ func (sb *SchemaBuilder) Build(logger *zap.Logger) (*api_service_protos.TSchema,
 	return &schema, nil
 }
 
+// TextCastColumns returns the names of the columns that were mapped by user-defined rules
+// and therefore must be converted into text when reading the data.
+func (sb *SchemaBuilder) TextCastColumns() map[string]struct{} {
+	result := make(map[string]struct{})
+
+	for _, item := range sb.items {
+		if item.textCast {
+			result[item.columnDescription.Name] = struct{}{}
+		}
+	}
+
+	return result
+}
+
 func NewSchemaBuilder(
 	typeMapper datasource.TypeMapper,
 	typeMappingSettings *api_service_protos.TTypeMappingSettings,
+	kind api_common.EGenericDataSourceKind,
 ) *SchemaBuilder {
 	return &SchemaBuilder{
 		typeMapper:          typeMapper,
 		typeMappingSettings: typeMappingSettings,
+		kind:                kind,
 	}
 }
@@ -16,7 +16,10 @@ type defaultSchemaProvider struct {
 	getArgsAndQuery func(request *api_service_protos.TDescribeTableRequest) (string, *QueryArgs)
 }
 
-var _ SchemaProvider = (*defaultSchemaProvider)(nil)
+var (
+	_ SchemaProvider          = (*defaultSchemaProvider)(nil)
+	_ TextCastColumnsProvider = (*defaultSchemaProvider)(nil)
+)
 
 func (f *defaultSchemaProvider) GetSchema(
 	ctx context.Context,
@@ -24,6 +27,39 @@ func (f *defaultSchemaProvider) GetSchema(
 	connMgr ConnectionManager,
 	request *api_service_protos.TDescribeTableRequest,
 ) (*api_service_protos.TSchema, error) {
+	sb, err := f.makeSchemaBuilder(ctx, logger, connMgr, request)
+	if err != nil {
+		return nil, fmt.Errorf("make schema builder: %w", err)
+	}
+
+	schema, err := sb.Build(logger)
+	if err != nil {
+		return nil, fmt.Errorf("build schema for table '%s': %w", request.GetTable(), err)
+	}
+
+	return schema, nil
+}
+
+func (f *defaultSchemaProvider) GetTextCastColumns(
+	ctx context.Context,
+	logger *zap.Logger,
+	connMgr ConnectionManager,
+	request *api_service_protos.TDescribeTableRequest,
+) (map[string]struct{}, error) {
+	sb, err := f.makeSchemaBuilder(ctx, logger, connMgr, request)
+	if err != nil {
+		return nil, fmt.Errorf("make schema builder: %w", err)
+	}
+
+	return sb.TextCastColumns(), nil
+}
+
+func (f *defaultSchemaProvider) makeSchemaBuilder(
+	ctx context.Context,
+	logger *zap.Logger,
+	connMgr ConnectionManager,
+	request *api_service_protos.TDescribeTableRequest,
+) (*SchemaBuilder, error) {
 	params := &ConnectionParams{
 		Ctx:                ctx,
 		Logger:             logger,
@@ -58,7 +94,7 @@ func (f *defaultSchemaProvider) GetSchema(
 
 	defer func() { common.LogCloserError(logger, queryResult, "close query result") }()
 
-	sb := NewSchemaBuilder(f.typeMapper, request.TypeMappingSettings)
+	sb := NewSchemaBuilder(f.typeMapper, request.TypeMappingSettings, request.DataSourceInstance.GetKind())
 
 	var (
 		columnName *string
@@ -98,12 +134,7 @@ func (f *defaultSchemaProvider) GetSchema(
 		return nil, fmt.Errorf("rows iteration: %w", err)
 	}
 
-	schema, err := sb.Build(logger)
-	if err != nil {
-		return nil, fmt.Errorf("build schema for table '%s': %w", request.GetTable(), err)
-	}
-
-	return schema, nil
+	return sb, nil
 }
 
 func NewDefaultSchemaProvider(
@@ -1,12 +1,16 @@
 package utils //nolint:revive
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
+	"go.uber.org/zap"
+
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
@@ -27,13 +31,12 @@ func makeTSelectTWhatForEmptyColumnsRequest() *api_service_protos.TSelect_TWhat
 
 func formatWhat(
 	formatter SQLFormatter,
+	typeMapper datasource.TypeMapper,
 	src *api_service_protos.TSelect_TWhat,
 	tableName string,
 ) (string, *api_service_protos.TSelect_TWhat, error) {
-	columns := common.SelectWhatToYDBColumns(src)
-
 	// If no columns were requested, select some constant to construct valid SQL statement
-	if len(columns) == 0 {
+	if len(src.GetItems()) == 0 {
 		// YQ-3314: is needed only in select COUNT(*) for ydb datasource.
 		// 		In PostgreSQL or ClickHouse type_mapper is based on typeNames that are extracted
 		// 		from column.DatabaseTypeName().
@@ -43,7 +46,17 @@ func formatWhat(
 		return "0", dst, nil
 	}
 
-	out, err := formatter.FormatWhat(src, tableName)
+	var (
+		out string
+		err error
+	)
+
+	if common.SelectWhatHasAggregations(src) {
+		out, err = formatWhatWithAggregations(formatter, typeMapper, src, tableName)
+	} else {
+		out, err = formatter.FormatWhat(src, tableName)
+	}
+
 	if err != nil {
 		return "", nil, fmt.Errorf("format select: %w", err)
 	}
@@ -51,6 +64,24 @@ func formatWhat(
 	return out, src, nil
 }
 
+// WriteTailClauses appends the clauses that follow WHERE clause (GROUP BY, ORDER BY, LIMIT)
+func WriteTailClauses(sb *strings.Builder, parts *SelectQueryParts) {
+	if parts.GroupByClause != "" {
+		sb.WriteString(" GROUP BY ")
+		sb.WriteString(parts.GroupByClause)
+	}
+
+	if parts.OrderByClause != "" {
+		sb.WriteString(" ORDER BY ")
+		sb.WriteString(parts.OrderByClause)
+	}
+
+	if parts.LimitClause != "" {
+		sb.WriteString(" ")
+		sb.WriteString(parts.LimitClause)
+	}
+}
+
 func FormatWhatDefault(formatter SQLFormatter, src *api_service_protos.TSelect_TWhat) string {
 	var sb strings.Builder
 
@@ -64,3 +95,121 @@ func FormatWhatDefault(formatter SQLFormatter, src *api_service_protos.TSelect_T
 
 	return sb.String()
 }
+
+// FormatOrderByDefault renders the list of sort keys like `"a" ASC, "b" DESC`
+func FormatOrderByDefault(formatter SQLFormatter, orderBy *api_service_protos.TSelect_TOrderBy) (string, error) {
+	var sb strings.Builder
+
+	for i, key := range orderBy.GetKeys() {
+		if key.Column == "" {
+			return "", fmt.Errorf("empty sort key column: %w", common.ErrInvalidRequest)
+		}
+
+		sb.WriteString(formatter.SanitiseIdentifier(key.Column))
+
+		switch key.Direction {
+		case api_service_protos.TSelect_TOrderBy_DIRECTION_UNSPECIFIED, api_service_protos.TSelect_TOrderBy_ASC:
+			sb.WriteString(" ASC")
+		case api_service_protos.TSelect_TOrderBy_DESC:
+			sb.WriteString(" DESC")
+		default:
+			return "", fmt.Errorf("unexpected sort direction %v: %w", key.Direction, common.ErrInvalidRequest)
+		}
+
+		if i != len(orderBy.GetKeys())-1 {
+			sb.WriteString(", ")
+		}
+	}
+
+	return sb.String(), nil
+}
+
+func formatOrderBy(formatter SQLFormatter, split *api_service_protos.TSplit) (string, error) {
+	orderBy := split.Select.GetOrderBy()
+
+	if len(orderBy.GetKeys()) == 0 {
+		return "", nil
+	}
+
+	// Unlike LIMIT, ordering cannot be silently skipped
+	out, err := formatter.FormatOrderBy(orderBy)
+	if err != nil {
+		return "", fmt.Errorf("format order by: %w", err)
+	}
+
+	return out, nil
+}
+
+// FormatLimitDefault renders `LIMIT n` clause.
+// The split may be only a part of a table, so OFFSET can't be applied to it: instead, the offset is merged into the limit,
+// and the engine applies OFFSET by itself to the rows obtained from all the splits.
+func FormatLimitDefault(limit *api_service_protos.TSelect_TLimit) string {
+	return fmt.Sprintf("LIMIT %d", limit.Limit+limit.Offset)
+}
+
+func formatLimit(
+	logger *zap.Logger,
+	formatter SQLFormatter,
+	split *api_service_protos.TSplit,
+) (string, error) {
+	limit := split.Select.GetLimit()
+
+	// LIMIT clause is meaningless without the number of rows
+	if limit.GetLimit() == 0 {
+		return "", nil
+	}
+
+	out, err := formatter.FormatLimit(limit, split)
+	if err != nil {
+		if errors.Is(err, common.ErrUnimplementedOperation) {
+			logger.Warn("LIMIT pushdown is not supported by the data source", zap.Error(err))
+
+			return "", nil
+		}
+
+		return "", fmt.Errorf("format limit: %w", err)
+	}
+
+	return out, nil
+}
+
+// textCastFormatter renders the columns mapped by user-defined type mapping rules
+// converted into their text representation.
+type textCastFormatter struct {
+	SQLFormatter
+	columns map[string]struct{}
+}
+
+func (f textCastFormatter) FormatWhat(src *api_service_protos.TSelect_TWhat, _ string) (string, error) {
+	var sb strings.Builder
+
+	for i, item := range src.Items {
+		name := item.GetColumn().GetName()
+		ident := f.SanitiseIdentifier(name)
+
+		if _, ok := f.columns[name]; ok {
+			expr, err := f.FormatTextCast(ident)
+			if err != nil {
+				return "", fmt.Errorf("format text cast for column '%s': %w", name, err)
+			}
+
+			ident = fmt.Sprintf("%s AS %s", expr, ident)
+		}
+
+		sb.WriteString(ident)
+
+		if i != len(src.Items)-1 {
+			sb.WriteString(", ")
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// NewTextCastFormatter wraps formatter in order to read the given columns as text
+func NewTextCastFormatter(formatter SQLFormatter, columns map[string]struct{}) SQLFormatter {
+	return textCastFormatter{
+		SQLFormatter: formatter,
+		columns:      columns,
+	}
+}
@@ -6,22 +6,22 @@ import (
 
 	"go.uber.org/zap"
 
-	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
-
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
 type SelectQuery struct {
 	QueryParams
-	// Types and names of the columns that will be returned by the query in terms of YDB type system.
-	YdbColumns []*Ydb.Column
+	// The part of the predicate that was not pushed down into the data source (optional).
+	ResidualPredicate *api_service_protos.TPredicate
 }
 
 func MakeSelectQuery(
 	ctx context.Context,
 	logger *zap.Logger,
 	formatter SQLFormatter,
+	typeMapper datasource.TypeMapper,
 	split *api_service_protos.TSplit,
 	filtering api_service_protos.TReadSplitsRequest_EFiltering,
 	tableName string,
@@ -32,30 +32,55 @@ func MakeSelectQuery(
 		err          error
 	)
 
+	// Column names of the joined tables are qualified with the table names
+	if len(split.Select.GetFrom().GetJoins()) > 0 {
+		if !formatter.SupportsJoins() {
+			return nil, fmt.Errorf("joins: %w", common.ErrUnimplementedOperation)
+		}
+
+		formatter = joinFormatter{SQLFormatter: formatter}
+	}
+
 	// Render SELECT clause
-	parts.SelectClause, modifiedWhat, err = formatWhat(formatter, split.Select.What, tableName)
+	parts.SelectClause, modifiedWhat, err = formatWhat(formatter, typeMapper, split.Select.What, tableName)
 	if err != nil {
 		return nil, fmt.Errorf("format what: %w", err)
 	}
 
-	ydbColumns := common.SelectWhatToYDBColumns(modifiedWhat)
+	ydbColumns, err := selectWhatToYDBColumns(typeMapper, modifiedWhat)
+	if err != nil {
+		return nil, fmt.Errorf("select what to YDB columns: %w", err)
+	}
 
 	// Render FROM clause
-	if tableName == "" {
-		return nil, common.ErrEmptyTableName
-	}
+	if query := split.Select.GetFrom().GetQuery(); query != "" {
+		parts.FromClause, err = FormatNativeQuery(formatter, query)
+		if err != nil {
+			return nil, fmt.Errorf("format native query: %w", err)
+		}
+	} else {
+		if tableName == "" {
+			return nil, common.ErrEmptyTableName
+		}
 
-	parts.FromClause = formatter.FormatFrom(tableName)
+		parts.FromClause, err = formatFrom(formatter, split.Select.From, tableName)
+		if err != nil {
+			return nil, fmt.Errorf("format from: %w", err)
+		}
+	}
 
 	// Validate and render WHERE clause
 	if err = formatter.ValidateWhere(split.Select.Where); err != nil {
 		return nil, fmt.Errorf("validate where clause: %w", err)
 	}
 
-	var queryArgs *QueryArgs
+	var (
+		queryArgs         *QueryArgs
+		residualPredicate *api_service_protos.TPredicate
+	)
 
 	if split.Select.Where != nil {
-		parts.WhereClause, queryArgs, err = formatWhereClause(
+		parts.WhereClause, queryArgs, residualPredicate, err = formatWhereClause(
 			logger,
 			filtering,
 			formatter,
@@ -67,6 +92,33 @@ func MakeSelectQuery(
 		}
 	}
 
+	// Aggregated rows cannot be filtered by the predicate over the source rows
+	hasAggregations := common.SelectWhatHasAggregations(split.Select.What) || len(split.Select.GetGroupBy().GetColumns()) > 0
+	if hasAggregations && residualPredicate != nil {
+		return nil, fmt.Errorf("aggregation over the predicate that can't be pushed down: %w", common.ErrUnimplementedOperation)
+	}
+
+	// Render GROUP BY clause
+	parts.GroupByClause, err = formatGroupBy(formatter, split.Select)
+	if err != nil {
+		return nil, fmt.Errorf("format group by clause: %w", err)
+	}
+
+	// Render ORDER BY clause
+	parts.OrderByClause, err = formatOrderBy(formatter, split)
+	if err != nil {
+		return nil, fmt.Errorf("format order by clause: %w", err)
+	}
+
+	// Render LIMIT clause. It's not pushed down if some rows are going to be filtered out afterwards,
+	// otherwise the result would be shorter than requested.
+	if residualPredicate == nil {
+		parts.LimitClause, err = formatLimit(logger, formatter, split)
+		if err != nil {
+			return nil, fmt.Errorf("format limit clause: %w", err)
+		}
+	}
+
 	// Render whole query
 	queryText, err := formatter.RenderSelectQueryText(&parts, split)
 	if err != nil {
@@ -75,11 +127,13 @@ func MakeSelectQuery(
 
 	return &SelectQuery{
 		QueryParams: QueryParams{
-			Ctx:       ctx,
-			Logger:    logger,
-			QueryText: queryText,
-			QueryArgs: queryArgs,
+			Ctx:          ctx,
+			Logger:       logger,
+			QueryText:    queryText,
+			QueryArgs:    queryArgs,
+			YdbColumns:   ydbColumns,
+			EmptyColumns: len(split.Select.What.GetItems()) == 0,
 		},
-		YdbColumns: ydbColumns,
+		ResidualPredicate: residualPredicate,
 	}, nil
 }
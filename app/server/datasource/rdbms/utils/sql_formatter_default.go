@@ -31,6 +31,8 @@ func (SQLFormatterDefault) RenderSelectQueryText(
 		sb.WriteString(parts.WhereClause)
 	}
 
+	WriteTailClauses(&sb, parts)
+
 	return sb.String(), nil
 }
 
@@ -58,6 +60,39 @@ func (SQLFormatterDefault) FormatCast(_ string, _ *Ydb.Type) (string, error) {
 	return "", common.ErrUnimplementedOperation
 }
 
+func (SQLFormatterDefault) FormatTextCast(_ string) (string, error) {
+	return "", common.ErrUnimplementedOperation
+}
+
+// FormatLimit default implementation renders `LIMIT n` clause (see FormatLimitDefault)
+func (SQLFormatterDefault) FormatLimit(limit *api_service_protos.TSelect_TLimit, _ *api_service_protos.TSplit) (string, error) {
+	return FormatLimitDefault(limit), nil
+}
+
+func (SQLFormatterDefault) FormatOrderBy(_ *api_service_protos.TSelect_TOrderBy) (string, error) {
+	return "", common.ErrUnimplementedOperation
+}
+
+func (SQLFormatterDefault) SupportedAggregations() []api_service_protos.TSelect_TAggregation_EFunction {
+	return nil
+}
+
+func (SQLFormatterDefault) FormatAggregation(
+	_ api_service_protos.TSelect_TAggregation_EFunction,
+	_ string,
+	_ *Ydb.Type,
+) (string, error) {
+	return "", common.ErrUnimplementedOperation
+}
+
+func (SQLFormatterDefault) SupportsJoins() bool {
+	return false
+}
+
+func (SQLFormatterDefault) SupportsNativeQuery() bool {
+	return false
+}
+
 func (SQLFormatterDefault) TransformPredicateComparison(src *api_service_protos.TPredicate_TComparison) (
 	*api_service_protos.TPredicate_TComparison, error) {
 	return src, nil
@@ -0,0 +1,88 @@
+package utils //nolint:revive
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// HasTypeMappingRules returns true if the settings contain any user-defined type mapping rules
+func HasTypeMappingRules(settings *api_service_protos.TTypeMappingSettings) bool {
+	return len(settings.GetRules()) > 0 || settings.GetUnsupportedTypesAsText()
+}
+
+// applyTypeMappingRules tries to map the column of the type unsupported by the type mapper
+// with the help of user-defined rules. Columns mapped this way are always read in text representation.
+func applyTypeMappingRules(
+	settings *api_service_protos.TTypeMappingSettings,
+	kind api_common.EGenericDataSourceKind,
+	columnDescription *datasource.ColumnDescription,
+) (*Ydb.Column, error) {
+	for _, rule := range settings.GetRules() {
+		if !matchTypeMappingRule(rule, kind, columnDescription.Type) {
+			continue
+		}
+
+		if err := validateTypeMappingRule(rule); err != nil {
+			return nil, fmt.Errorf("validate type mapping rule for type '%s': %w", rule.SourceType, err)
+		}
+
+		return makeTextColumn(columnDescription.Name), nil
+	}
+
+	if settings.GetUnsupportedTypesAsText() {
+		return makeTextColumn(columnDescription.Name), nil
+	}
+
+	return nil, common.ErrDataTypeNotSupported
+}
+
+func matchTypeMappingRule(
+	rule *api_service_protos.TTypeMappingRule,
+	kind api_common.EGenericDataSourceKind,
+	typeName string,
+) bool {
+	if rule.Kind != api_common.EGenericDataSourceKind_DATA_SOURCE_KIND_UNSPECIFIED && rule.Kind != kind {
+		return false
+	}
+
+	pattern := strings.ToLower(strings.TrimSpace(rule.SourceType))
+	typeName = strings.ToLower(strings.TrimSpace(typeName))
+
+	if prefix, found := strings.CutSuffix(pattern, "*"); found {
+		return strings.HasPrefix(typeName, prefix)
+	}
+
+	return pattern == typeName
+}
+
+func validateTypeMappingRule(rule *api_service_protos.TTypeMappingRule) error {
+	if rule.SourceType == "" {
+		return fmt.Errorf("empty source type: %w", common.ErrInvalidRequest)
+	}
+
+	// No type means the default one
+	if rule.YdbType == nil {
+		return nil
+	}
+
+	typeID, err := common.YdbTypeToYdbPrimitiveTypeID(rule.YdbType)
+	if err != nil || typeID != Ydb.Type_UTF8 {
+		return fmt.Errorf("type '%v' is not supported as a target type: %w", rule.YdbType, common.ErrInvalidRequest)
+	}
+
+	return nil
+}
+
+func makeTextColumn(name string) *Ydb.Column {
+	return &Ydb.Column{
+		Name: name,
+		Type: common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8)),
+	}
+}
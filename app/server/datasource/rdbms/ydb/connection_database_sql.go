@@ -34,7 +34,12 @@ func (r rowsDatabaseSQL) MakeTransformer(ydbColumns []*Ydb.Column, cc conversion
 		typeNames = append(typeNames, column.DatabaseTypeName())
 	}
 
-	transformer, err := transformerFromSQLTypes(typeNames, common.YDBColumnsToYDBTypes(ydbColumns), cc)
+	transformer, err := transformerFromSQLTypes(
+		typeNames,
+		common.YDBColumnsToYDBTypes(ydbColumns),
+		cc,
+		func() any { return new(any) },
+	)
 	if err != nil {
 		return nil, fmt.Errorf("transformer from sql types: %w", err)
 	}
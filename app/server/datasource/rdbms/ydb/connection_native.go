@@ -15,6 +15,7 @@ import (
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 	ydb_sdk "github.com/ydb-platform/ydb-go-sdk/v3"
 	ydb_sdk_query "github.com/ydb-platform/ydb-go-sdk/v3/query"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
 
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
@@ -90,7 +91,12 @@ func (r *rowsNative) MakeTransformer(ydbColumns []*Ydb.Column, cc conversion.Col
 		typeNames = append(typeNames, columnType.Yql())
 	}
 
-	transformer, err := transformerFromSQLTypes(typeNames, common.YDBColumnsToYDBTypes(ydbColumns), cc)
+	transformer, err := transformerFromSQLTypes(
+		typeNames,
+		common.YDBColumnsToYDBTypes(ydbColumns),
+		cc,
+		func() any { return new(types.Value) },
+	)
 	if err != nil {
 		return nil, fmt.Errorf("transformer from sql types: %w", err)
 	}
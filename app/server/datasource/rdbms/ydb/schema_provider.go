@@ -21,7 +21,10 @@ type schemaProvider struct {
 	tableMetadataCache table_metadata_cache.Cache
 }
 
-var _ rdbms_utils.SchemaProvider = (*schemaProvider)(nil)
+var (
+	_ rdbms_utils.SchemaProvider          = (*schemaProvider)(nil)
+	_ rdbms_utils.TextCastColumnsProvider = (*schemaProvider)(nil)
+)
 
 func (f *schemaProvider) GetSchema(
 	ctx context.Context,
@@ -33,17 +36,85 @@ func (f *schemaProvider) GetSchema(
 
 	logger = logger.With(zap.String("prefix", prefix))
 
-	logger.Debug("obtaining table metadata from YDB")
+	// Cached schemas are built without user-defined type mapping rules
+	cacheable := !rdbms_utils.HasTypeMappingRules(request.TypeMappingSettings)
 
 	// Try to get cached value - this helps us avoid creating a connection
-	cachedValue, cachedValueExists := f.tableMetadataCache.Get(logger, request.DataSourceInstance, request.Table)
-	if cachedValueExists && cachedValue != nil && cachedValue.Schema != nil {
-		logger.Debug("obtained table metadata from cache")
+	if cacheable {
+		cachedValue, cachedValueExists := f.tableMetadataCache.Get(logger, request.DataSourceInstance, request.Table)
+		if cachedValueExists && cachedValue != nil && cachedValue.Schema != nil {
+			logger.Debug("obtained table metadata from cache")
 
-		return cachedValue.Schema, nil
+			return cachedValue.Schema, nil
+		}
 	}
 
 	// Cache miss or empty schema - need to create connection and fetch from YDB
+	desc, err := f.describeTable(ctx, logger, connMgr, request, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("describe table: %w", err)
+	}
+
+	sb, err := f.makeSchemaBuilder(desc, request)
+	if err != nil {
+		return nil, fmt.Errorf("make schema builder: %w", err)
+	}
+
+	schema, err := sb.Build(logger)
+	if err != nil {
+		return nil, fmt.Errorf("build schema: %w", err)
+	}
+
+	if !cacheable {
+		return schema, nil
+	}
+
+	// preserve table metadata into cache to decrease the latency of DescribeTable and ListSplits methods
+	value := &table_metadata_cache.TValue{
+		Schema:    schema,
+		StoreType: table_metadata_cache.EStoreType(desc.StoreType),
+	}
+
+	ok := f.tableMetadataCache.Put(logger, request.DataSourceInstance, request.Table, value)
+	if !ok {
+		logger.Warn("failed to cache table metadata")
+	} else {
+		logger.Debug("cached table metadata")
+	}
+
+	return schema, nil
+}
+
+func (f *schemaProvider) GetTextCastColumns(
+	ctx context.Context,
+	logger *zap.Logger,
+	connMgr rdbms_utils.ConnectionManager,
+	request *api_service_protos.TDescribeTableRequest,
+) (map[string]struct{}, error) {
+	prefix := path.Join(request.DataSourceInstance.Database, request.Table)
+
+	desc, err := f.describeTable(ctx, logger.With(zap.String("prefix", prefix)), connMgr, request, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("describe table: %w", err)
+	}
+
+	sb, err := f.makeSchemaBuilder(desc, request)
+	if err != nil {
+		return nil, fmt.Errorf("make schema builder: %w", err)
+	}
+
+	return sb.TextCastColumns(), nil
+}
+
+func (*schemaProvider) describeTable(
+	ctx context.Context,
+	logger *zap.Logger,
+	connMgr rdbms_utils.ConnectionManager,
+	request *api_service_protos.TDescribeTableRequest,
+	prefix string,
+) (options.Description, error) {
+	logger.Debug("obtaining table metadata from YDB")
+
 	params := &rdbms_utils.ConnectionParams{
 		Ctx:                ctx,
 		Logger:             logger,
@@ -54,7 +125,7 @@ func (f *schemaProvider) GetSchema(
 
 	cs, err := connMgr.Make(params)
 	if err != nil {
-		return nil, fmt.Errorf("make connection: %w", err)
+		return options.Description{}, fmt.Errorf("make connection: %w", err)
 	}
 
 	defer connMgr.Release(ctx, logger, cs)
@@ -82,10 +153,17 @@ func (f *schemaProvider) GetSchema(
 		table.WithIdempotent(),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("get table description: %w", err)
+		return options.Description{}, fmt.Errorf("get table description: %w", err)
 	}
 
-	sb := rdbms_utils.NewSchemaBuilder(f.typeMapper, request.TypeMappingSettings)
+	return desc, nil
+}
+
+func (f *schemaProvider) makeSchemaBuilder(
+	desc options.Description,
+	request *api_service_protos.TDescribeTableRequest,
+) (*rdbms_utils.SchemaBuilder, error) {
+	sb := rdbms_utils.NewSchemaBuilder(f.typeMapper, request.TypeMappingSettings, request.DataSourceInstance.GetKind())
 
 	for _, column := range desc.Columns {
 		desc := &datasource.ColumnDescription{
@@ -95,30 +173,12 @@ func (f *schemaProvider) GetSchema(
 			Scale:     nil,
 		}
 
-		if err = sb.AddColumn(desc); err != nil {
+		if err := sb.AddColumn(desc); err != nil {
 			return nil, fmt.Errorf("add column to schema builder: %w", err)
 		}
 	}
 
-	schema, err := sb.Build(logger)
-	if err != nil {
-		return nil, fmt.Errorf("build schema: %w", err)
-	}
-
-	// preserve table metadata into cache to decrease the latency of DescribeTable and ListSplits methods
-	value := &table_metadata_cache.TValue{
-		Schema:    schema,
-		StoreType: table_metadata_cache.EStoreType(desc.StoreType),
-	}
-
-	ok := f.tableMetadataCache.Put(logger, request.DataSourceInstance, request.Table, value)
-	if !ok {
-		logger.Warn("failed to cache table metadata")
-	} else {
-		logger.Debug("cached table metadata")
-	}
-
-	return schema, nil
+	return sb, nil
 }
 
 func NewSchemaProvider(
@@ -19,6 +19,7 @@ import (
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/ydb/table_metadata_cache"
+	"github.com/ydb-platform/fq-connector-go/common"
 )
 
 var _ rdbms_utils.SplitProvider = (*SplitProvider)(nil)
@@ -34,6 +35,19 @@ func (sp SplitProvider) ListSplits(
 ) error {
 	resultChan, slct, ctx, logger := params.ResultChan, params.Select, params.Ctx, params.Logger
 
+	// Aggregations and groupings must be computed over the whole table, and ordering would be lost
+	// when the splits are read in parallel. Data shard split description is suitable for reading any table at once.
+	if common.SelectWhatHasAggregations(slct.GetWhat()) || len(slct.GetGroupBy().GetColumns()) > 0 ||
+		len(slct.GetOrderBy().GetKeys()) > 0 {
+		logger.Info("aggregation or ordering requested, fallback to single split per table")
+
+		if err := sp.listSingleSplit(ctx, slct, resultChan); err != nil {
+			return fmt.Errorf("list single split: %w", err)
+		}
+
+		return nil
+	}
+
 	storeType := table_metadata_cache.EStoreType_STORE_TYPE_UNSPECIFIED
 
 	// Try to get cached value - this may help us to save a connection
@@ -355,6 +369,11 @@ func makeSplit(
 	}
 }
 
+// SplittingEnabled returns true if OLAP tables are split by column shards (OLTP tables are never split)
+func (sp SplitProvider) SplittingEnabled() bool {
+	return sp.cfg.EnabledOnColumnShards
+}
+
 func NewSplitProvider(cfg *config.TYdbConfig_TSplitting, tableMetadataCache table_metadata_cache.Cache) SplitProvider {
 	return SplitProvider{
 		cfg:                cfg,
@@ -12,6 +12,7 @@ import (
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/config"
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
 )
 
 var _ rdbms_utils.SQLFormatter = (*SQLFormatter)(nil)
@@ -175,9 +176,27 @@ func (SQLFormatter) RenderSelectQueryTextForColumnShard(
 		sb.WriteString(parts.WhereClause)
 	}
 
+	rdbms_utils.WriteTailClauses(&sb, parts)
+
 	return sb.String(), nil
 }
 
+// FormatLimit is applicable only to the splits listed by the connector: every split of the table
+// (either a data shard or a column shard of the OLAP table) is described, so an empty description is not supported.
+func (SQLFormatter) FormatLimit(limit *api_service_protos.TSelect_TLimit, split *api_service_protos.TSplit) (string, error) {
+	if len(split.GetDescription()) == 0 {
+		return "", fmt.Errorf("empty split description: %w", common.ErrUnimplementedOperation)
+	}
+
+	var splitDescription TSplitDescription
+
+	if err := protojson.Unmarshal(split.GetDescription(), &splitDescription); err != nil {
+		return "", fmt.Errorf("unmarshal split description: %w", err)
+	}
+
+	return rdbms_utils.FormatLimitDefault(limit), nil
+}
+
 func (f SQLFormatter) renderSelectQueryTextForDataShard(
 	parts *rdbms_utils.SelectQueryParts,
 	_ *TSplitDescription_TDataShard,
@@ -229,6 +248,34 @@ func (SQLFormatter) FormatCast(value string, ydbType *Ydb.Type) (string, error)
 	return fmt.Sprintf("CAST(%s AS %s)", value, typeName), nil
 }
 
+func (SQLFormatter) FormatTextCast(valueExpr string) (string, error) {
+	return fmt.Sprintf("CAST(%s AS Utf8)", valueExpr), nil
+}
+
+func (f SQLFormatter) FormatOrderBy(orderBy *api_service_protos.TSelect_TOrderBy) (string, error) {
+	return rdbms_utils.FormatOrderByDefault(f, orderBy)
+}
+
+var aggregationCastTemplates = map[Ydb.Type_PrimitiveTypeId]string{
+	Ydb.Type_INT64:  "CAST(%s AS Int64)",
+	Ydb.Type_UINT64: "CAST(%s AS Uint64)",
+	Ydb.Type_DOUBLE: "CAST(%s AS Double)",
+}
+
+func (SQLFormatter) SupportedAggregations() []api_service_protos.TSelect_TAggregation_EFunction {
+	return rdbms_utils.AllAggregations
+}
+
+// FormatAggregation converts the results of aggregate functions, because COUNT returns Uint64 in YQL,
+// and SUM over Float returns Float.
+func (SQLFormatter) FormatAggregation(
+	function api_service_protos.TSelect_TAggregation_EFunction,
+	argExpr string,
+	resultType *Ydb.Type,
+) (string, error) {
+	return rdbms_utils.FormatAggregationDefault(function, argExpr, resultType, aggregationCastTemplates)
+}
+
 func NewSQLFormatter(mode config.TYdbConfig_Mode, cfg *config.TPushdownConfig) SQLFormatter {
 	return SQLFormatter{
 		mode: mode,
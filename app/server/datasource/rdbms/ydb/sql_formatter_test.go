@@ -522,6 +522,7 @@ func TestMakeSelectQuery(t *testing.T) {
 				context.Background(),
 				logger,
 				formatter,
+				NewTypeMapper(),
 				&api_service_protos.TSplit{
 					Select: tc.selectReq,
 					Payload: &api_service_protos.TSplit_Description{
@@ -550,3 +551,31 @@ func TestMakeSelectQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatLimit(t *testing.T) {
+	formatter := NewSQLFormatter(config.TYdbConfig_MODE_QUERY_SERVICE_NATIVE, nil)
+	limit := &api_service_protos.TSelect_TLimit{Limit: 10, Offset: 5}
+
+	t.Run("described_split", func(t *testing.T) {
+		for _, splitDescription := range []*TSplitDescription{
+			{Payload: &TSplitDescription_DataShard{DataShard: &TSplitDescription_TDataShard{}}},
+			{Payload: &TSplitDescription_ColumnShard{ColumnShard: &TSplitDescription_TColumnShard{}}},
+		} {
+			splitDescriptionBytes, err := protojson.Marshal(splitDescription)
+			require.NoError(t, err)
+
+			out, err := formatter.FormatLimit(
+				limit,
+				&api_service_protos.TSplit{Payload: &api_service_protos.TSplit_Description{Description: splitDescriptionBytes}},
+			)
+			require.NoError(t, err)
+			// OFFSET is applied by the engine
+			require.Equal(t, "LIMIT 15", out)
+		}
+	})
+
+	t.Run("empty_description", func(t *testing.T) {
+		_, err := formatter.FormatLimit(limit, &api_service_protos.TSplit{})
+		require.ErrorIs(t, err, common.ErrUnimplementedOperation)
+	})
+}
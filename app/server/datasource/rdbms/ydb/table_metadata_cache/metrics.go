@@ -5,9 +5,20 @@ import (
 	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/solomon"
 )
 
+// MetricsProvider is implemented by any cache reporting the statistics in the form of Metrics
+type MetricsProvider interface {
+	Metrics() *Metrics
+}
+
 // RegisterMetrics registers cache metrics with the provided registry.
 // It uses the ydb_table_metadata_cache_ prefix for all metrics.
 func RegisterMetrics(registry metrics.Registry, cache Cache) {
+	RegisterMetricsWithPrefix(registry, "ydb_table_metadata_cache_", cache)
+}
+
+// RegisterMetricsWithPrefix registers cache metrics with the provided registry,
+// so that the other caches could expose the same set of metrics.
+func RegisterMetricsWithPrefix(registry metrics.Registry, prefix string, cache MetricsProvider) {
 	m := cache.Metrics()
 
 	if m == nil {
@@ -15,8 +26,8 @@ func RegisterMetrics(registry metrics.Registry, cache Cache) {
 		return
 	}
 
-	// Register gauges for cache statistics with ydb_table_metadata_cache_ prefix
-	_ = registry.FuncGauge("ydb_table_metadata_cache_hit_ratio", func() float64 {
+	// Register gauges for cache statistics with the given prefix
+	_ = registry.FuncGauge(prefix+"hit_ratio", func() float64 {
 		m := cache.Metrics()
 		if m == nil {
 			return 0
@@ -25,7 +36,7 @@ func RegisterMetrics(registry metrics.Registry, cache Cache) {
 		return m.Ratio
 	})
 
-	cacheHits := registry.FuncCounter("ydb_table_metadata_cache_hits_total", func() int64 {
+	cacheHits := registry.FuncCounter(prefix+"hits_total", func() int64 {
 		m := cache.Metrics()
 		if m == nil {
 			return 0
@@ -34,7 +45,7 @@ func RegisterMetrics(registry metrics.Registry, cache Cache) {
 		return int64(m.Hits)
 	})
 
-	cacheMisses := registry.FuncCounter("ydb_table_metadata_cache_misses_total", func() int64 {
+	cacheMisses := registry.FuncCounter(prefix+"misses_total", func() int64 {
 		m := cache.Metrics()
 		if m == nil {
 			return 0
@@ -43,7 +54,7 @@ func RegisterMetrics(registry metrics.Registry, cache Cache) {
 		return int64(m.Misses)
 	})
 
-	cacheKeysAdded := registry.FuncCounter("ydb_table_metadata_cache_keys_added_total", func() int64 {
+	cacheKeysAdded := registry.FuncCounter(prefix+"keys_added_total", func() int64 {
 		m := cache.Metrics()
 		if m == nil {
 			return 0
@@ -52,7 +63,7 @@ func RegisterMetrics(registry metrics.Registry, cache Cache) {
 		return int64(m.KeysAdded)
 	})
 
-	cacheKeysEvicted := registry.FuncCounter("ydb_table_metadata_cache_keys_evicted_total", func() int64 {
+	cacheKeysEvicted := registry.FuncCounter(prefix+"keys_evicted_total", func() int64 {
 		m := cache.Metrics()
 		if m == nil {
 			return 0
@@ -61,7 +72,7 @@ func RegisterMetrics(registry metrics.Registry, cache Cache) {
 		return int64(m.KeysEvicted)
 	})
 
-	cacheKeysDropped := registry.FuncCounter("ydb_table_metadata_cache_keys_dropped_total", func() int64 {
+	cacheKeysDropped := registry.FuncCounter(prefix+"keys_dropped_total", func() int64 {
 		m := cache.Metrics()
 		if m == nil {
 			return 0
@@ -70,7 +81,7 @@ func RegisterMetrics(registry metrics.Registry, cache Cache) {
 		return int64(m.KeysDropped)
 	})
 
-	_ = registry.FuncGauge("ydb_table_metadata_cache_size", func() float64 {
+	_ = registry.FuncGauge(prefix+"size", func() float64 {
 		m := cache.Metrics()
 		if m == nil {
 			return 0
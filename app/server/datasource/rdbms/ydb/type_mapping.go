@@ -12,12 +12,14 @@ import (
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
 var _ datasource.TypeMapper = typeMapper{}
+var _ datasource.AggregationTypeMapper = typeMapper{}
 
 type typeMapper struct {
 }
@@ -43,6 +45,14 @@ const (
 	typeDatetime     = "Datetime"
 	typeTimestamp    = "Timestamp"
 	typeJSONDocument = "JsonDocument"
+	typeInterval     = "Interval"
+	typeDate32       = "Date32"
+	typeDatetime64   = "Datetime64"
+	typeTimestamp64  = "Timestamp64"
+	typeInterval64   = "Interval64"
+	typeUUID         = "Uuid"
+	typeYSON         = "Yson"
+	typeDyNumber     = "DyNumber"
 )
 
 func primitiveYqlTypeName(typeId Ydb.Type_PrimitiveTypeId) (string, error) {
@@ -73,8 +83,32 @@ func primitiveYqlTypeName(typeId Ydb.Type_PrimitiveTypeId) (string, error) {
 		return typeString, nil
 	case Ydb.Type_UTF8:
 		return typeUtf8, nil
+	case Ydb.Type_JSON:
+		return typeJSON, nil
+	case Ydb.Type_JSON_DOCUMENT:
+		return typeJSONDocument, nil
+	case Ydb.Type_YSON:
+		return typeYSON, nil
+	case Ydb.Type_UUID:
+		return typeUUID, nil
+	case Ydb.Type_DYNUMBER:
+		return typeDyNumber, nil
+	case Ydb.Type_DATE:
+		return typeDate, nil
+	case Ydb.Type_DATETIME:
+		return typeDatetime, nil
 	case Ydb.Type_TIMESTAMP:
 		return typeTimestamp, nil
+	case Ydb.Type_INTERVAL:
+		return typeInterval, nil
+	case Ydb.Type_DATE32:
+		return typeDate32, nil
+	case Ydb.Type_DATETIME64:
+		return typeDatetime64, nil
+	case Ydb.Type_TIMESTAMP64:
+		return typeTimestamp64, nil
+	case Ydb.Type_INTERVAL64:
+		return typeInterval64, nil
 	default:
 		return "", fmt.Errorf("unexpected primitive type id: %v", typeId)
 	}
@@ -84,35 +118,16 @@ func (typeMapper) SQLTypeToYDBColumn(
 	columnDescription *datasource.ColumnDescription,
 	_ *api_service_protos.TTypeMappingSettings,
 ) (*Ydb.Column, error) {
-	var (
-		ydbType *Ydb.Type
-		err     error
-	)
-
-	typeName := columnDescription.Type
-
-	optional := false
-
-	if matches := isOptional.FindStringSubmatch(typeName); len(matches) > 0 {
-		optional = true
-		typeName = matches[1]
-	}
-
-	ydbType, err = makePrimitiveTypeFromString(typeName)
+	ydbType, err := parseYQLType(columnDescription.Type)
 	if err != nil {
 		return nil, fmt.Errorf("make type: %w", err)
 	}
 
-	if optional {
-		ydbType = common.MakeOptionalType(ydbType)
-	}
-
 	return &Ydb.Column{Name: columnDescription.Name, Type: ydbType}, nil
 }
 
 //nolint:gocyclo
 func makePrimitiveTypeFromString(typeName string) (*Ydb.Type, error) {
-	// TODO: add all types support
 	// Reference table: https://ydb.yandex-team.ru/docs/yql/reference/types/
 	switch typeName {
 	case typeBool:
@@ -151,16 +166,54 @@ func makePrimitiveTypeFromString(typeName string) (*Ydb.Type, error) {
 		return common.MakePrimitiveType(Ydb.Type_DATETIME), nil
 	case typeTimestamp:
 		return common.MakePrimitiveType(Ydb.Type_TIMESTAMP), nil
+	case typeInterval:
+		return common.MakePrimitiveType(Ydb.Type_INTERVAL), nil
+	case typeDate32:
+		return common.MakePrimitiveType(Ydb.Type_DATE32), nil
+	case typeDatetime64:
+		return common.MakePrimitiveType(Ydb.Type_DATETIME64), nil
+	case typeTimestamp64:
+		return common.MakePrimitiveType(Ydb.Type_TIMESTAMP64), nil
+	case typeInterval64:
+		return common.MakePrimitiveType(Ydb.Type_INTERVAL64), nil
+	case typeUUID:
+		return common.MakePrimitiveType(Ydb.Type_UUID), nil
+	case typeYSON:
+		return common.MakePrimitiveType(Ydb.Type_YSON), nil
+	case typeDyNumber:
+		return common.MakePrimitiveType(Ydb.Type_DYNUMBER), nil
 	default:
 		return nil, fmt.Errorf("convert type '%s': %w", typeName, common.ErrDataTypeNotSupported)
 	}
 }
 
-func transformerFromSQLTypes(typeNames []string, ydbTypes []*Ydb.Type, cc conversion.Collection) (paging.RowTransformer[any], error) {
+// transformerFromSQLTypes makes row transformer for the result set.
+// The valueAcceptor function produces acceptors for the columns handled by the generic value appender,
+// because different YDB drivers are able to fill different acceptor types.
+func transformerFromSQLTypes(
+	typeNames []string,
+	ydbTypes []*Ydb.Type,
+	cc conversion.Collection,
+	valueAcceptor func() any,
+) (paging.RowTransformer[any], error) {
 	acceptors := make([]any, 0, len(typeNames))
 	appenders := make([]func(acceptor any, builder array.Builder) error, 0, len(typeNames))
 
 	for i, typeName := range typeNames {
+		// Complex types and the types that have no dedicated converters
+		// are handled by the generic value appender
+		if requiresValueAppender(ydbTypes[i]) {
+			appender, err := makeValueAppender(ydbTypes[i])
+			if err != nil {
+				return nil, fmt.Errorf("make value appender for column #%d: %w", i, err)
+			}
+
+			acceptors = append(acceptors, valueAcceptor())
+			appenders = append(appenders, appender)
+
+			continue
+		}
+
 		var optional bool
 
 		if matches := isOptional.FindStringSubmatch(typeName); len(matches) > 0 {
@@ -262,6 +315,17 @@ func makeAcceptorAppenderCheckOptional[
 	return new(IN), utils.MakeAppender[IN, OUT, AB](conv), nil
 }
 
+// The results are converted to these types with aggregationCastTemplates
+var aggregationResultTypeNames = map[Ydb.Type_PrimitiveTypeId]string{
+	Ydb.Type_INT64:  typeInt64,
+	Ydb.Type_UINT64: typeUint64,
+	Ydb.Type_DOUBLE: typeDouble,
+}
+
+func (tm typeMapper) AggregationToYDBColumn(agg *api_service_protos.TSelect_TAggregation) (*Ydb.Column, error) {
+	return rdbms_utils.AggregationToYDBColumnDefault(tm, agg, aggregationResultTypeNames)
+}
+
 func NewTypeMapper() datasource.TypeMapper {
 	return typeMapper{}
 }
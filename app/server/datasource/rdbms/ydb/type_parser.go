@@ -0,0 +1,328 @@
+package ydb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// yqlTypeParser converts the textual representation of YQL types
+// (the one returned by YDB SDK's `Type.Yql()` and `Type.String()` methods)
+// into the YDB protobuf types. Examples of the supported expressions:
+//
+//	Optional<Decimal(22,9)>
+//	List<Struct<'a':Int32,'b':Optional<Utf8>>>
+//	Dict<Utf8,Tuple<Int32,Uuid>>
+type yqlTypeParser struct {
+	input string
+	pos   int
+}
+
+func parseYQLType(typeName string) (*Ydb.Type, error) {
+	p := &yqlTypeParser{input: typeName}
+
+	ydbType, err := p.parseType()
+	if err != nil {
+		return nil, fmt.Errorf("parse type '%s': %w", typeName, err)
+	}
+
+	p.skipSpaces()
+
+	if p.pos != len(p.input) {
+		return nil, fmt.Errorf("parse type '%s': unexpected trailing symbols at position %d", typeName, p.pos)
+	}
+
+	return ydbType, nil
+}
+
+//nolint:gocyclo
+func (p *yqlTypeParser) parseType() (*Ydb.Type, error) {
+	name := p.parseIdentifier()
+	if name == "" {
+		return nil, fmt.Errorf("type name expected at position %d", p.pos)
+	}
+
+	var (
+		ydbType *Ydb.Type
+		err     error
+	)
+
+	switch name {
+	case "Optional":
+		ydbType, err = p.parseSingleArgument(common.MakeOptionalType)
+	case "List":
+		ydbType, err = p.parseSingleArgument(common.MakeListType)
+	case "Decimal":
+		ydbType, err = p.parseDecimal()
+	case "Tuple":
+		ydbType, err = p.parseTuple()
+	case "Struct":
+		ydbType, err = p.parseStruct()
+	case "Dict":
+		ydbType, err = p.parseDict()
+	case "Tagged":
+		ydbType, err = p.parseTagged()
+	default:
+		ydbType, err = makePrimitiveTypeFromString(name)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// YQL also allows `T?` as a shorthand for `Optional<T>`
+	for p.consume('?') {
+		ydbType = common.MakeOptionalType(ydbType)
+	}
+
+	return ydbType, nil
+}
+
+func (p *yqlTypeParser) parseSingleArgument(wrap func(*Ydb.Type) *Ydb.Type) (*Ydb.Type, error) {
+	if err := p.expect('<'); err != nil {
+		return nil, err
+	}
+
+	inner, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect('>'); err != nil {
+		return nil, err
+	}
+
+	return wrap(inner), nil
+}
+
+func (p *yqlTypeParser) parseDecimal() (*Ydb.Type, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	precision, err := p.parseUint()
+	if err != nil {
+		return nil, fmt.Errorf("decimal precision: %w", err)
+	}
+
+	if err = p.expect(','); err != nil {
+		return nil, err
+	}
+
+	scale, err := p.parseUint()
+	if err != nil {
+		return nil, fmt.Errorf("decimal scale: %w", err)
+	}
+
+	if err = p.expect(')'); err != nil {
+		return nil, err
+	}
+
+	return common.MakeDecimalType(precision, scale), nil
+}
+
+func (p *yqlTypeParser) parseTuple() (*Ydb.Type, error) {
+	if err := p.expect('<'); err != nil {
+		return nil, err
+	}
+
+	var elements []*Ydb.Type
+
+	for !p.consume('>') {
+		if len(elements) > 0 {
+			if err := p.expect(','); err != nil {
+				return nil, err
+			}
+		}
+
+		element, err := p.parseType()
+		if err != nil {
+			return nil, fmt.Errorf("tuple element #%d: %w", len(elements), err)
+		}
+
+		elements = append(elements, element)
+	}
+
+	return &Ydb.Type{Type: &Ydb.Type_TupleType{TupleType: &Ydb.TupleType{Elements: elements}}}, nil
+}
+
+func (p *yqlTypeParser) parseStruct() (*Ydb.Type, error) {
+	if err := p.expect('<'); err != nil {
+		return nil, err
+	}
+
+	var members []*Ydb.StructMember
+
+	for !p.consume('>') {
+		if len(members) > 0 {
+			if err := p.expect(','); err != nil {
+				return nil, err
+			}
+		}
+
+		name, err := p.parseMemberName()
+		if err != nil {
+			return nil, fmt.Errorf("struct member #%d: %w", len(members), err)
+		}
+
+		if err = p.expect(':'); err != nil {
+			return nil, err
+		}
+
+		memberType, err := p.parseType()
+		if err != nil {
+			return nil, fmt.Errorf("struct member '%s': %w", name, err)
+		}
+
+		members = append(members, &Ydb.StructMember{Name: name, Type: memberType})
+	}
+
+	return common.MakeStructType(members), nil
+}
+
+func (p *yqlTypeParser) parseDict() (*Ydb.Type, error) {
+	if err := p.expect('<'); err != nil {
+		return nil, err
+	}
+
+	key, err := p.parseType()
+	if err != nil {
+		return nil, fmt.Errorf("dict key: %w", err)
+	}
+
+	if err = p.expect(','); err != nil {
+		return nil, err
+	}
+
+	payload, err := p.parseType()
+	if err != nil {
+		return nil, fmt.Errorf("dict payload: %w", err)
+	}
+
+	if err = p.expect('>'); err != nil {
+		return nil, err
+	}
+
+	return &Ydb.Type{Type: &Ydb.Type_DictType{DictType: &Ydb.DictType{Key: key, Payload: payload}}}, nil
+}
+
+func (p *yqlTypeParser) parseTagged() (*Ydb.Type, error) {
+	if err := p.expect('<'); err != nil {
+		return nil, err
+	}
+
+	inner, err := p.parseType()
+	if err != nil {
+		return nil, fmt.Errorf("tagged type: %w", err)
+	}
+
+	if err = p.expect(','); err != nil {
+		return nil, err
+	}
+
+	tag, err := p.parseMemberName()
+	if err != nil {
+		return nil, fmt.Errorf("tag: %w", err)
+	}
+
+	if err = p.expect('>'); err != nil {
+		return nil, err
+	}
+
+	return common.MakeTaggedType(tag, inner), nil
+}
+
+// parseMemberName handles both quoted ('name', `name`) and bare member names
+func (p *yqlTypeParser) parseMemberName() (string, error) {
+	p.skipSpaces()
+
+	if p.pos >= len(p.input) {
+		return "", fmt.Errorf("member name expected at position %d", p.pos)
+	}
+
+	quote := p.input[p.pos]
+	if quote != '\'' && quote != '`' && quote != '"' {
+		name := p.parseIdentifier()
+		if name == "" {
+			return "", fmt.Errorf("member name expected at position %d", p.pos)
+		}
+
+		return name, nil
+	}
+
+	end := strings.IndexByte(p.input[p.pos+1:], quote)
+	if end < 0 {
+		return "", fmt.Errorf("unterminated member name at position %d", p.pos)
+	}
+
+	name := p.input[p.pos+1 : p.pos+1+end]
+	p.pos += end + 2
+
+	return name, nil
+}
+
+func (p *yqlTypeParser) parseIdentifier() string {
+	p.skipSpaces()
+
+	start := p.pos
+
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' {
+			p.pos++
+
+			continue
+		}
+
+		break
+	}
+
+	return p.input[start:p.pos]
+}
+
+func (p *yqlTypeParser) parseUint() (uint32, error) {
+	p.skipSpaces()
+
+	start := p.pos
+
+	for p.pos < len(p.input) && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+		p.pos++
+	}
+
+	value, err := strconv.ParseUint(p.input[start:p.pos], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("parse uint at position %d: %w", start, err)
+	}
+
+	return uint32(value), nil
+}
+
+func (p *yqlTypeParser) consume(c byte) bool {
+	p.skipSpaces()
+
+	if p.pos < len(p.input) && p.input[p.pos] == c {
+		p.pos++
+
+		return true
+	}
+
+	return false
+}
+
+func (p *yqlTypeParser) expect(c byte) error {
+	if !p.consume(c) {
+		return fmt.Errorf("'%c' expected at position %d", c, p.pos)
+	}
+
+	return nil
+}
+
+func (p *yqlTypeParser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
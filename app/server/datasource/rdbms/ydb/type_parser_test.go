@@ -0,0 +1,86 @@
+package ydb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestParseYQLType(t *testing.T) {
+	type testCase struct {
+		input  string
+		output *Ydb.Type
+		err    error
+	}
+
+	tcs := []testCase{
+		{
+			input:  "Int32",
+			output: common.MakePrimitiveType(Ydb.Type_INT32),
+		},
+		{
+			input:  "Uuid?",
+			output: common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UUID)),
+		},
+		{
+			input:  "Optional<Decimal(22,9)>",
+			output: common.MakeOptionalType(common.MakeDecimalType(22, 9)),
+		},
+		{
+			input: "List<Struct<'a':Int32,'b':Optional<Utf8>>>",
+			output: common.MakeListType(common.MakeStructType([]*Ydb.StructMember{
+				{Name: "a", Type: common.MakePrimitiveType(Ydb.Type_INT32)},
+				{Name: "b", Type: common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8))},
+			})),
+		},
+		{
+			input: "Dict<Utf8,Tuple<Int32,Timestamp64>>",
+			output: &Ydb.Type{Type: &Ydb.Type_DictType{DictType: &Ydb.DictType{
+				Key: common.MakePrimitiveType(Ydb.Type_UTF8),
+				Payload: &Ydb.Type{Type: &Ydb.Type_TupleType{TupleType: &Ydb.TupleType{
+					Elements: []*Ydb.Type{
+						common.MakePrimitiveType(Ydb.Type_INT32),
+						common.MakePrimitiveType(Ydb.Type_TIMESTAMP64),
+					},
+				}}},
+			}}},
+		},
+		{
+			input:  "Tagged<JsonDocument,'tag'>",
+			output: common.MakeTaggedType("tag", common.MakePrimitiveType(Ydb.Type_JSON)),
+		},
+		{
+			input: "Set<Int32>",
+			err:   common.ErrDataTypeNotSupported,
+		},
+		{
+			input: "List<Int32",
+			err:   errors.New("parse type 'List<Int32': '>' expected at position 10"),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.input, func(t *testing.T) {
+			output, err := parseYQLType(tc.input)
+			if tc.err != nil {
+				if errors.Is(tc.err, common.ErrDataTypeNotSupported) {
+					require.ErrorIs(t, err, tc.err)
+				} else {
+					require.EqualError(t, err, tc.err.Error())
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.output.String(), output.String())
+		})
+	}
+}
@@ -0,0 +1,386 @@
+package ydb
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/google/uuid"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// requiresValueAppender returns true for the types that cannot be handled by
+// the type-specific acceptors and appenders (see makeAcceptorAppender).
+// Values of such types are scanned as is and converted into Arrow by the generic value appender.
+func requiresValueAppender(ydbType *Ydb.Type) bool {
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	switch t := ydbType.Type.(type) {
+	case *Ydb.Type_TypeId:
+		switch t.TypeId {
+		case Ydb.Type_INTERVAL,
+			Ydb.Type_DATE32, Ydb.Type_DATETIME64, Ydb.Type_TIMESTAMP64, Ydb.Type_INTERVAL64,
+			Ydb.Type_UUID, Ydb.Type_YSON, Ydb.Type_DYNUMBER:
+			return true
+		default:
+			return false
+		}
+	default:
+		return true
+	}
+}
+
+// makeValueAppender makes appender that works with the acceptors of `*any` or `*types.Value` types.
+// The first ones are filled by `database/sql` driver with the values of Go types
+// (or YDB SDK values in case of containers), the second ones are filled by the native YDB SDK driver.
+func makeValueAppender(ydbType *Ydb.Type) (func(acceptor any, builder array.Builder) error, error) {
+	if err := validateValueAppenderType(ydbType); err != nil {
+		return nil, fmt.Errorf("validate type: %w", err)
+	}
+
+	return func(acceptor any, builder array.Builder) error {
+		var value any
+
+		switch t := acceptor.(type) {
+		case *any:
+			value = *t
+		case *types.Value:
+			if *t != nil {
+				value = *t
+			}
+		default:
+			return fmt.Errorf("unexpected acceptor type %T", acceptor)
+		}
+
+		return appendValue(ydbType, value, builder)
+	}, nil
+}
+
+func validateValueAppenderType(ydbType *Ydb.Type) error {
+	switch t := ydbType.Type.(type) {
+	case *Ydb.Type_TypeId:
+		if _, err := primitiveYqlTypeName(t.TypeId); err != nil {
+			return fmt.Errorf("%w: %w", err, common.ErrDataTypeNotSupported)
+		}
+
+		return nil
+	case *Ydb.Type_OptionalType:
+		return validateValueAppenderType(t.OptionalType.Item)
+	case *Ydb.Type_TaggedType:
+		return validateValueAppenderType(t.TaggedType.Type)
+	case *Ydb.Type_DecimalType:
+		return nil
+	case *Ydb.Type_ListType:
+		return validateValueAppenderType(t.ListType.Item)
+	case *Ydb.Type_TupleType:
+		for _, element := range t.TupleType.Elements {
+			if err := validateValueAppenderType(element); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case *Ydb.Type_StructType:
+		for _, member := range t.StructType.Members {
+			if err := validateValueAppenderType(member.Type); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case *Ydb.Type_DictType:
+		if err := validateValueAppenderType(t.DictType.Key); err != nil {
+			return err
+		}
+
+		return validateValueAppenderType(t.DictType.Payload)
+	default:
+		return fmt.Errorf("unexpected type %v: %w", ydbType, common.ErrDataTypeNotSupported)
+	}
+}
+
+//nolint:gocyclo
+func appendValue(ydbType *Ydb.Type, value any, builder array.Builder) error {
+	if value == nil {
+		builder.AppendNull()
+
+		return nil
+	}
+
+	if sdkValue, ok := value.(types.Value); ok {
+		if types.IsNull(sdkValue) {
+			builder.AppendNull()
+
+			return nil
+		}
+
+		// unwrap optional values
+		value = types.Unwrap(sdkValue)
+	}
+
+	switch t := ydbType.Type.(type) {
+	case *Ydb.Type_TypeId:
+		return appendPrimitiveValue(t.TypeId, value, builder)
+	case *Ydb.Type_OptionalType:
+		return appendValue(t.OptionalType.Item, value, builder)
+	case *Ydb.Type_TaggedType:
+		return appendValue(t.TaggedType.Type, value, builder)
+	case *Ydb.Type_DecimalType:
+		return appendDecimalValue(value, builder)
+	case *Ydb.Type_ListType:
+		return appendListValue(t.ListType, value, builder)
+	case *Ydb.Type_TupleType:
+		return appendTupleValue(t.TupleType, value, builder)
+	case *Ydb.Type_StructType:
+		return appendStructValue(t.StructType, value, builder)
+	case *Ydb.Type_DictType:
+		return appendDictValue(t.DictType, value, builder)
+	default:
+		return fmt.Errorf("unexpected type %v: %w", ydbType, common.ErrDataTypeNotSupported)
+	}
+}
+
+//nolint:gocyclo,funlen
+func appendPrimitiveValue(typeID Ydb.Type_PrimitiveTypeId, value any, builder array.Builder) error {
+	switch typeID {
+	case Ydb.Type_BOOL:
+		return appendCastedValue(value, builder, func(b *array.Uint8Builder, v bool) {
+			if v {
+				b.Append(1)
+			} else {
+				b.Append(0)
+			}
+		})
+	case Ydb.Type_INT8:
+		return appendCastedValue(value, builder, (*array.Int8Builder).Append)
+	case Ydb.Type_UINT8:
+		return appendCastedValue(value, builder, (*array.Uint8Builder).Append)
+	case Ydb.Type_INT16:
+		return appendCastedValue(value, builder, (*array.Int16Builder).Append)
+	case Ydb.Type_UINT16:
+		return appendCastedValue(value, builder, (*array.Uint16Builder).Append)
+	case Ydb.Type_INT32:
+		return appendCastedValue(value, builder, (*array.Int32Builder).Append)
+	case Ydb.Type_UINT32:
+		return appendCastedValue(value, builder, (*array.Uint32Builder).Append)
+	case Ydb.Type_INT64:
+		return appendCastedValue(value, builder, (*array.Int64Builder).Append)
+	case Ydb.Type_UINT64:
+		return appendCastedValue(value, builder, (*array.Uint64Builder).Append)
+	case Ydb.Type_FLOAT:
+		return appendCastedValue(value, builder, (*array.Float32Builder).Append)
+	case Ydb.Type_DOUBLE:
+		return appendCastedValue(value, builder, (*array.Float64Builder).Append)
+	case Ydb.Type_STRING, Ydb.Type_YSON, Ydb.Type_JSON_DOCUMENT, Ydb.Type_DYNUMBER:
+		// JsonDocument and DyNumber values are delivered in the text representation
+		if _, ok := value.(string); ok {
+			return appendCastedValue(value, builder, func(b *array.BinaryBuilder, v string) { b.AppendString(v) })
+		}
+
+		return appendCastedValue(value, builder, (*array.BinaryBuilder).Append)
+	case Ydb.Type_UTF8, Ydb.Type_JSON:
+		if _, ok := value.([]byte); ok {
+			return appendCastedValue(value, builder, func(b *array.StringBuilder, v []byte) { b.BinaryBuilder.Append(v) })
+		}
+
+		return appendCastedValue(value, builder, (*array.StringBuilder).Append)
+	case Ydb.Type_DATE:
+		// YDB Date is the number of days since the epoch
+		return appendCastedValue(value, builder, func(b *array.Uint16Builder, v uint64) { b.Append(uint16(v)) })
+	case Ydb.Type_DATETIME:
+		return appendCastedValue(value, builder, (*array.Uint32Builder).Append)
+	case Ydb.Type_TIMESTAMP:
+		return appendCastedValue(value, builder, (*array.Uint64Builder).Append)
+	case Ydb.Type_DATE32:
+		return appendCastedValue(value, builder, (*array.Int32Builder).Append)
+	case Ydb.Type_INTERVAL, Ydb.Type_INTERVAL64:
+		// `database/sql` driver returns intervals as time.Duration
+		if duration, ok := value.(time.Duration); ok {
+			builder.(*array.Int64Builder).Append(duration.Microseconds())
+
+			return nil
+		}
+
+		return appendCastedValue(value, builder, (*array.Int64Builder).Append)
+	case Ydb.Type_DATETIME64, Ydb.Type_TIMESTAMP64:
+		return appendCastedValue(value, builder, (*array.Int64Builder).Append)
+	case Ydb.Type_UUID:
+		return appendCastedValue(value, builder, func(b *array.FixedSizeBinaryBuilder, v uuid.UUID) {
+			b.Append(uuidToYDBBytes(v))
+		})
+	default:
+		return fmt.Errorf("unexpected primitive type %v: %w", typeID, common.ErrDataTypeNotSupported)
+	}
+}
+
+// appendCastedValue casts value to the type expected by the Arrow builder.
+// YDB SDK values are casted with the help of SDK, Go values are expected to have exactly the requested type.
+func appendCastedValue[T any, B array.Builder](value any, builder array.Builder, appendFn func(B, T)) error {
+	typedBuilder, ok := builder.(B)
+	if !ok {
+		return fmt.Errorf("unexpected builder type %T", builder)
+	}
+
+	var dst T
+
+	switch v := value.(type) {
+	case types.Value:
+		if err := types.CastTo(v, &dst); err != nil {
+			return fmt.Errorf("cast value '%s' to %T: %w", v.Yql(), dst, err)
+		}
+	case T:
+		dst = v
+	default:
+		return fmt.Errorf("unexpected value type %T, expected %T", value, dst)
+	}
+
+	appendFn(typedBuilder, dst)
+
+	return nil
+}
+
+func appendDecimalValue(value any, builder array.Builder) error {
+	sdkValue, ok := value.(types.Value)
+	if !ok {
+		return fmt.Errorf("unexpected decimal value type %T", value)
+	}
+
+	decimalValue, err := types.ToDecimal(sdkValue)
+	if err != nil {
+		return fmt.Errorf("to decimal: %w", err)
+	}
+
+	// YDB SDK keeps decimals as big-endian 128-bit integers,
+	// while YDB expects little-endian byte order in Arrow blocks
+	buf := decimalValue.Bytes
+	slices.Reverse(buf[:])
+
+	builder.(*array.FixedSizeBinaryBuilder).Append(buf[:])
+
+	return nil
+}
+
+func appendListValue(listType *Ydb.ListType, value any, builder array.Builder) error {
+	sdkValue, ok := value.(types.Value)
+	if !ok {
+		return fmt.Errorf("unexpected list value type %T", value)
+	}
+
+	items, err := types.ListItems(sdkValue)
+	if err != nil {
+		return fmt.Errorf("list items: %w", err)
+	}
+
+	listBuilder := builder.(*array.ListBuilder)
+	listBuilder.Append(true)
+
+	for i, item := range items {
+		if err := appendValue(listType.Item, item, listBuilder.ValueBuilder()); err != nil {
+			return fmt.Errorf("append list item #%d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func appendTupleValue(tupleType *Ydb.TupleType, value any, builder array.Builder) error {
+	sdkValue, ok := value.(types.Value)
+	if !ok {
+		return fmt.Errorf("unexpected tuple value type %T", value)
+	}
+
+	items, err := types.TupleItems(sdkValue)
+	if err != nil {
+		return fmt.Errorf("tuple items: %w", err)
+	}
+
+	if len(items) != len(tupleType.Elements) {
+		return fmt.Errorf("tuple size mismatch: expected %d, got %d", len(tupleType.Elements), len(items))
+	}
+
+	structBuilder := builder.(*array.StructBuilder)
+	structBuilder.Append(true)
+
+	for i, item := range items {
+		if err := appendValue(tupleType.Elements[i], item, structBuilder.FieldBuilder(i)); err != nil {
+			return fmt.Errorf("append tuple element #%d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func appendStructValue(structType *Ydb.StructType, value any, builder array.Builder) error {
+	sdkValue, ok := value.(types.Value)
+	if !ok {
+		return fmt.Errorf("unexpected struct value type %T", value)
+	}
+
+	fields, err := types.StructFields(sdkValue)
+	if err != nil {
+		return fmt.Errorf("struct fields: %w", err)
+	}
+
+	structBuilder := builder.(*array.StructBuilder)
+	structBuilder.Append(true)
+
+	for i, member := range structType.Members {
+		field, exists := fields[member.Name]
+		if !exists {
+			structBuilder.FieldBuilder(i).AppendNull()
+
+			continue
+		}
+
+		if err := appendValue(member.Type, field, structBuilder.FieldBuilder(i)); err != nil {
+			return fmt.Errorf("append struct member '%s': %w", member.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func appendDictValue(dictType *Ydb.DictType, value any, builder array.Builder) error {
+	sdkValue, ok := value.(types.Value)
+	if !ok {
+		return fmt.Errorf("unexpected dict value type %T", value)
+	}
+
+	pairs, err := types.DictValues(sdkValue)
+	if err != nil {
+		return fmt.Errorf("dict values: %w", err)
+	}
+
+	mapBuilder := builder.(*array.MapBuilder)
+	mapBuilder.Append(true)
+
+	for key, payload := range pairs {
+		if err := appendValue(dictType.Key, key, mapBuilder.KeyBuilder()); err != nil {
+			return fmt.Errorf("append dict key: %w", err)
+		}
+
+		if err := appendValue(dictType.Payload, payload, mapBuilder.ItemBuilder()); err != nil {
+			return fmt.Errorf("append dict payload: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// uuidToYDBBytes converts UUID to the binary representation used by YDB
+// (the first three groups of bytes are little-endian).
+func uuidToYDBBytes(id uuid.UUID) []byte {
+	return []byte{
+		id[3], id[2], id[1], id[0],
+		id[5], id[4],
+		id[7], id[6],
+		id[8], id[9], id[10], id[11], id[12], id[13], id[14], id[15],
+	}
+}
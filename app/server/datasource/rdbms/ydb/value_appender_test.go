@@ -0,0 +1,98 @@
+package ydb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestValueAppender(t *testing.T) {
+	decimalValue, err := types.DecimalValueFromString("-0.000000258", 22, 9)
+	require.NoError(t, err)
+
+	listType := common.MakeOptionalType(common.MakeListType(common.MakePrimitiveType(Ydb.Type_INT32)))
+
+	tcs := []struct {
+		name     string
+		ydbType  *Ydb.Type
+		values   []any
+		expected string
+	}{
+		{
+			name:     "interval",
+			ydbType:  common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INTERVAL)),
+			values:   []any{types.IntervalValueFromDuration(time.Second), time.Millisecond, nil},
+			expected: "[1000000 1000 (null)]",
+		},
+		{
+			name:     "uuid",
+			ydbType:  common.MakePrimitiveType(Ydb.Type_UUID),
+			values:   []any{types.UuidValue(uuid.MustParse("00010203-0405-0607-0809-0a0b0c0d0e0f"))},
+			expected: `["\x03\x02\x01\x00\x05\x04\a\x06\b\t\n\v\f\r\x0e\x0f"]`,
+		},
+		{
+			name:     "decimal",
+			ydbType:  common.MakeDecimalType(22, 9),
+			values:   []any{decimalValue},
+			expected: `["\xfe\xfe\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff"]`,
+		},
+		{
+			name:    "list",
+			ydbType: listType,
+			values: []any{
+				types.OptionalValue(types.ListValue(types.Int32Value(1), types.Int32Value(2))),
+				types.NullValue(types.List(types.TypeInt32)),
+			},
+			expected: "[[1 2] (null)]",
+		},
+		{
+			name: "struct",
+			ydbType: common.MakeStructType([]*Ydb.StructMember{
+				{Name: "a", Type: common.MakePrimitiveType(Ydb.Type_INT32)},
+				{Name: "b", Type: common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8))},
+			}),
+			values: []any{
+				types.StructValue(
+					types.StructFieldValue("a", types.Int32Value(1)),
+					types.StructFieldValue("b", types.OptionalValue(types.TextValue("x"))),
+				),
+			},
+			expected: `{[1] ["x"]}`,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			appender, err := makeValueAppender(tc.ydbType)
+			require.NoError(t, err)
+
+			builders, err := common.YdbTypesToArrowBuilders([]*Ydb.Type{tc.ydbType}, memory.NewGoAllocator())
+			require.NoError(t, err)
+
+			builder := builders[0]
+			defer builder.Release()
+
+			for _, value := range tc.values {
+				acceptor := new(any)
+				*acceptor = value
+
+				require.NoError(t, appender(acceptor, builder))
+			}
+
+			arr := builder.NewArray()
+			defer arr.Release()
+
+			require.Equal(t, tc.expected, arr.String())
+		})
+	}
+}
@@ -0,0 +1,3 @@
+// Package filtering contains the in-connector evaluator of the predicates
+// that were not pushed down into the data source.
+package filtering
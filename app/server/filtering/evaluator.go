@@ -0,0 +1,369 @@
+package filtering
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/compute"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/apache/arrow/go/v13/arrow/scalar"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// evaluator computes the value of an expression (or a predicate) for every row of the record.
+// The resulting datum is owned by the caller.
+type evaluator func(ctx context.Context, record arrow.Record) (compute.Datum, error)
+
+var comparisonFunctions = map[api_service_protos.TPredicate_TComparison_EOperation]string{
+	api_service_protos.TPredicate_TComparison_EQ: "equal",
+	api_service_protos.TPredicate_TComparison_NE: "not_equal",
+	api_service_protos.TPredicate_TComparison_L:  "less",
+	api_service_protos.TPredicate_TComparison_LE: "less_equal",
+	api_service_protos.TPredicate_TComparison_G:  "greater",
+	api_service_protos.TPredicate_TComparison_GE: "greater_equal",
+}
+
+// Overflow is not checked, because YQL integer arithmetic wraps around too
+var arithmeticalFunctions = map[api_service_protos.TExpression_TArithmeticalExpression_EOperation]string{
+	api_service_protos.TExpression_TArithmeticalExpression_ADD: "add_unchecked",
+	api_service_protos.TExpression_TArithmeticalExpression_SUB: "sub_unchecked",
+	api_service_protos.TExpression_TArithmeticalExpression_MUL: "multiply_unchecked",
+}
+
+type compiler struct {
+	schema    *arrow.Schema
+	allocator memory.Allocator
+}
+
+func (c *compiler) compilePredicate(predicate *api_service_protos.TPredicate) (evaluator, error) {
+	switch p := predicate.Payload.(type) {
+	case *api_service_protos.TPredicate_Comparison:
+		return c.compileComparison(p.Comparison)
+	case *api_service_protos.TPredicate_Conjunction:
+		return c.compileOperands(p.Conjunction.Operands, "and_kleene")
+	case *api_service_protos.TPredicate_Disjunction:
+		return c.compileOperands(p.Disjunction.Operands, "or_kleene")
+	case *api_service_protos.TPredicate_Negation:
+		operand, err := c.compilePredicate(p.Negation.Operand)
+		if err != nil {
+			return nil, fmt.Errorf("compile negation operand: %w", err)
+		}
+
+		// `true AND NOT x` preserves nulls in the same way as `NOT x`
+		return callFunction("and_not_kleene", constant(scalar.NewBooleanScalar(true)), operand), nil
+	case *api_service_protos.TPredicate_IsNull:
+		return c.compileNullCheck(p.IsNull.Value, false)
+	case *api_service_protos.TPredicate_IsNotNull:
+		return c.compileNullCheck(p.IsNotNull.Value, true)
+	case *api_service_protos.TPredicate_Between:
+		return c.compileBetween(p.Between)
+	case *api_service_protos.TPredicate_In:
+		return c.compileIn(p.In)
+	case *api_service_protos.TPredicate_BoolExpression:
+		return c.compileBoolExpression(p.BoolExpression.Value)
+	default:
+		return nil, fmt.Errorf("%w, type: %T", common.ErrUnimplementedPredicateType, p)
+	}
+}
+
+func (c *compiler) compileOperands(operands []*api_service_protos.TPredicate, function string) (evaluator, error) {
+	if len(operands) == 0 {
+		return nil, fmt.Errorf("no operands: %w", common.ErrInvalidRequest)
+	}
+
+	result, err := c.compilePredicate(operands[0])
+	if err != nil {
+		return nil, fmt.Errorf("compile operand: %w", err)
+	}
+
+	for _, operand := range operands[1:] {
+		next, err := c.compilePredicate(operand)
+		if err != nil {
+			return nil, fmt.Errorf("compile operand: %w", err)
+		}
+
+		result = callFunction(function, result, next)
+	}
+
+	return result, nil
+}
+
+func (c *compiler) compileComparison(comparison *api_service_protos.TPredicate_TComparison) (evaluator, error) {
+	function, ok := comparisonFunctions[comparison.Operation]
+	if !ok {
+		return nil, fmt.Errorf("comparison operation %v: %w", comparison.Operation, common.ErrUnimplementedOperation)
+	}
+
+	left, err := c.compileExpression(comparison.LeftValue)
+	if err != nil {
+		return nil, fmt.Errorf("compile left value: %w", err)
+	}
+
+	right, err := c.compileExpression(comparison.RightValue)
+	if err != nil {
+		return nil, fmt.Errorf("compile right value: %w", err)
+	}
+
+	return callFunction(function, left, right), nil
+}
+
+func (c *compiler) compileBetween(between *api_service_protos.TPredicate_TBetween) (evaluator, error) {
+	value, err := c.compileExpression(between.Value)
+	if err != nil {
+		return nil, fmt.Errorf("compile value: %w", err)
+	}
+
+	least, err := c.compileExpression(between.Least)
+	if err != nil {
+		return nil, fmt.Errorf("compile least: %w", err)
+	}
+
+	greatest, err := c.compileExpression(between.Greatest)
+	if err != nil {
+		return nil, fmt.Errorf("compile greatest: %w", err)
+	}
+
+	return callFunction(
+		"and_kleene",
+		callFunction("greater_equal", value, least),
+		callFunction("less_equal", value, greatest),
+	), nil
+}
+
+func (c *compiler) compileIn(in *api_service_protos.TPredicate_TIn) (evaluator, error) {
+	if len(in.Set) == 0 {
+		return nil, fmt.Errorf("empty IN set: %w", common.ErrInvalidRequest)
+	}
+
+	value, err := c.compileExpression(in.Value)
+	if err != nil {
+		return nil, fmt.Errorf("compile value: %w", err)
+	}
+
+	var result evaluator
+
+	for _, item := range in.Set {
+		itemEvaluator, err := c.compileExpression(item)
+		if err != nil {
+			return nil, fmt.Errorf("compile set item: %w", err)
+		}
+
+		eq := callFunction("equal", value, itemEvaluator)
+
+		if result == nil {
+			result = eq
+		} else {
+			result = callFunction("or_kleene", result, eq)
+		}
+	}
+
+	return result, nil
+}
+
+func (c *compiler) compileNullCheck(expression *api_service_protos.TExpression, isNotNull bool) (evaluator, error) {
+	value, err := c.compileExpression(expression)
+	if err != nil {
+		return nil, fmt.Errorf("compile value: %w", err)
+	}
+
+	return func(ctx context.Context, record arrow.Record) (compute.Datum, error) {
+		datum, err := value(ctx, record)
+		if err != nil {
+			return nil, err
+		}
+
+		defer datum.Release()
+
+		arrayDatum, ok := datum.(*compute.ArrayDatum)
+		if !ok {
+			return nil, fmt.Errorf("null check of %s: %w", datum.Kind(), common.ErrUnimplementedExpression)
+		}
+
+		values := arrayDatum.MakeArray()
+		defer values.Release()
+
+		builder := array.NewBooleanBuilder(c.allocator)
+		defer builder.Release()
+
+		builder.Reserve(values.Len())
+
+		for i := 0; i < values.Len(); i++ {
+			builder.UnsafeAppend(values.IsValid(i) == isNotNull)
+		}
+
+		result := builder.NewArray()
+		defer result.Release()
+
+		return compute.NewDatum(result), nil
+	}, nil
+}
+
+func (c *compiler) compileBoolExpression(expression *api_service_protos.TExpression) (evaluator, error) {
+	value, err := c.compileExpression(expression)
+	if err != nil {
+		return nil, fmt.Errorf("compile value: %w", err)
+	}
+
+	// YDB Bool is represented with Arrow Uint8
+	return callFunction("not_equal", value, constant(scalar.NewUint8Scalar(0))), nil
+}
+
+func (c *compiler) compileExpression(expression *api_service_protos.TExpression) (evaluator, error) {
+	switch e := expression.GetPayload().(type) {
+	case *api_service_protos.TExpression_Column:
+		return c.compileColumn(e.Column)
+	case *api_service_protos.TExpression_TypedValue:
+		value, err := makeScalar(e.TypedValue)
+		if err != nil {
+			return nil, fmt.Errorf("make scalar: %w", err)
+		}
+
+		return constant(value), nil
+	case *api_service_protos.TExpression_ArithmeticalExpression:
+		return c.compileArithmeticalExpression(e.ArithmeticalExpression)
+	default:
+		return nil, fmt.Errorf("%w, type: %T", common.ErrUnimplementedExpression, e)
+	}
+}
+
+func (c *compiler) compileColumn(name string) (evaluator, error) {
+	indices := c.schema.FieldIndices(name)
+	if len(indices) != 1 {
+		// the column is not returned to the engine, so it cannot be checked here
+		return nil, fmt.Errorf("column '%s' is missing from the result: %w", name, common.ErrUnimplementedExpression)
+	}
+
+	index := indices[0]
+
+	return func(_ context.Context, record arrow.Record) (compute.Datum, error) {
+		return compute.NewDatum(record.Column(index)), nil
+	}, nil
+}
+
+func (c *compiler) compileArithmeticalExpression(
+	expression *api_service_protos.TExpression_TArithmeticalExpression,
+) (evaluator, error) {
+	function, ok := arithmeticalFunctions[expression.Operation]
+	if !ok {
+		return nil, fmt.Errorf("arithmetical operation %v: %w", expression.Operation, common.ErrUnimplementedOperation)
+	}
+
+	left, err := c.compileExpression(expression.LeftValue)
+	if err != nil {
+		return nil, fmt.Errorf("compile left value: %w", err)
+	}
+
+	right, err := c.compileExpression(expression.RightValue)
+	if err != nil {
+		return nil, fmt.Errorf("compile right value: %w", err)
+	}
+
+	return callFunction(function, left, right), nil
+}
+
+func callFunction(function string, args ...evaluator) evaluator {
+	return func(ctx context.Context, record arrow.Record) (compute.Datum, error) {
+		datums := make([]compute.Datum, 0, len(args))
+
+		defer func() {
+			for _, datum := range datums {
+				datum.Release()
+			}
+		}()
+
+		for _, arg := range args {
+			datum, err := arg(ctx, record)
+			if err != nil {
+				return nil, err
+			}
+
+			datums = append(datums, datum)
+		}
+
+		result, err := compute.CallFunction(ctx, function, nil, datums...)
+		if err != nil {
+			return nil, fmt.Errorf("call function '%s': %w", function, err)
+		}
+
+		return result, nil
+	}
+}
+
+func constant(value scalar.Scalar) evaluator {
+	return func(context.Context, arrow.Record) (compute.Datum, error) {
+		return compute.NewDatum(value), nil
+	}
+}
+
+// makeScalar converts the value into the Arrow scalar of the same type
+// that is used to represent the YDB type in the Arrow blocks returned by the connector
+//
+//nolint:gocyclo
+func makeScalar(value *Ydb.TypedValue) (scalar.Scalar, error) {
+	ydbType := value.GetType()
+
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	typeID := ydbType.GetTypeId()
+
+	switch v := value.GetValue().GetValue().(type) {
+	case *Ydb.Value_BoolValue:
+		// YDB Bool is represented with Arrow Uint8
+		if v.BoolValue {
+			return scalar.NewUint8Scalar(1), nil
+		}
+
+		return scalar.NewUint8Scalar(0), nil
+	case *Ydb.Value_Int32Value:
+		switch typeID {
+		case Ydb.Type_INT8:
+			return scalar.NewInt8Scalar(int8(v.Int32Value)), nil
+		case Ydb.Type_INT16:
+			return scalar.NewInt16Scalar(int16(v.Int32Value)), nil
+		case Ydb.Type_INT32, Ydb.Type_DATE32:
+			return scalar.NewInt32Scalar(v.Int32Value), nil
+		}
+	case *Ydb.Value_Uint32Value:
+		switch typeID {
+		case Ydb.Type_UINT8:
+			return scalar.NewUint8Scalar(uint8(v.Uint32Value)), nil
+		case Ydb.Type_UINT16, Ydb.Type_DATE:
+			return scalar.NewUint16Scalar(uint16(v.Uint32Value)), nil
+		case Ydb.Type_UINT32, Ydb.Type_DATETIME:
+			return scalar.NewUint32Scalar(v.Uint32Value), nil
+		}
+	case *Ydb.Value_Int64Value:
+		switch typeID {
+		case Ydb.Type_INT64, Ydb.Type_INTERVAL, Ydb.Type_DATETIME64, Ydb.Type_TIMESTAMP64, Ydb.Type_INTERVAL64:
+			return scalar.NewInt64Scalar(v.Int64Value), nil
+		}
+	case *Ydb.Value_Uint64Value:
+		switch typeID {
+		case Ydb.Type_UINT64, Ydb.Type_TIMESTAMP:
+			return scalar.NewUint64Scalar(v.Uint64Value), nil
+		}
+	case *Ydb.Value_FloatValue:
+		return scalar.NewFloat32Scalar(v.FloatValue), nil
+	case *Ydb.Value_DoubleValue:
+		return scalar.NewFloat64Scalar(v.DoubleValue), nil
+	case *Ydb.Value_BytesValue:
+		if typeID == Ydb.Type_STRING {
+			return scalar.NewBinaryScalar(memory.NewBufferBytes(v.BytesValue), arrow.BinaryTypes.Binary), nil
+		}
+	case *Ydb.Value_TextValue:
+		switch typeID {
+		case Ydb.Type_UTF8, Ydb.Type_JSON:
+			return scalar.NewStringScalar(v.TextValue), nil
+		}
+	}
+
+	return nil, fmt.Errorf("value of type %v: %w", ydbType, common.ErrUnimplementedTypedValue)
+}
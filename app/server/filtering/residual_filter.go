@@ -0,0 +1,226 @@
+package filtering
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/compute"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/apache/arrow/go/v13/arrow/scalar"
+	"go.uber.org/zap"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// ResidualFilter applies the part of the predicate that was not pushed down into the data source
+// (in the FILTERING_OPTIONAL mode) to the Arrow records before they are sent to the engine.
+// This is just an optimization reducing the network traffic: the engine filters the data anyway,
+// so the parts of the predicate that cannot be evaluated by the connector are simply skipped.
+type ResidualFilter struct {
+	evaluators []evaluator
+}
+
+// Apply returns the record containing only the rows satisfying the predicate.
+// The returned record is owned by the caller, the incoming record is left intact.
+func (f *ResidualFilter) Apply(ctx context.Context, record arrow.Record) (arrow.Record, error) {
+	mask, err := f.evaluate(ctx, record)
+	if err != nil {
+		return nil, fmt.Errorf("evaluate predicate: %w", err)
+	}
+
+	defer mask.Release()
+
+	// all the rows satisfy the predicate
+	if mask.NullN() == 0 && countTrue(mask) == mask.Len() {
+		record.Retain()
+
+		return record, nil
+	}
+
+	out, err := compute.FilterRecordBatch(ctx, record, mask, compute.DefaultFilterOptions())
+	if err != nil {
+		return nil, fmt.Errorf("filter record batch: %w", err)
+	}
+
+	return out, nil
+}
+
+// evaluate returns the boolean array with the values of the predicate for every row
+func (f *ResidualFilter) evaluate(ctx context.Context, record arrow.Record) (*array.Boolean, error) {
+	var result compute.Datum
+
+	for _, eval := range f.evaluators {
+		datum, err := eval(ctx, record)
+		if err != nil {
+			if result != nil {
+				result.Release()
+			}
+
+			return nil, err
+		}
+
+		if result == nil {
+			result = datum
+
+			continue
+		}
+
+		conjunction, err := compute.CallFunction(ctx, "and_kleene", nil, result, datum)
+
+		result.Release()
+		datum.Release()
+
+		if err != nil {
+			return nil, fmt.Errorf("call function 'and_kleene': %w", err)
+		}
+
+		result = conjunction
+	}
+
+	defer result.Release()
+
+	switch r := result.(type) {
+	case *compute.ArrayDatum:
+		if r.Type().ID() != arrow.BOOL {
+			return nil, fmt.Errorf("unexpected predicate type %v", r.Type())
+		}
+
+		return r.MakeArray().(*array.Boolean), nil
+	case *compute.ScalarDatum:
+		// predicate does not depend on columns
+		if r.Type().ID() != arrow.BOOL {
+			return nil, fmt.Errorf("unexpected predicate type %v", r.Type())
+		}
+
+		out, err := scalar.MakeArrayFromScalar(r.Value, int(record.NumRows()), memory.DefaultAllocator)
+		if err != nil {
+			return nil, fmt.Errorf("make array from scalar: %w", err)
+		}
+
+		return out.(*array.Boolean), nil
+	default:
+		return nil, fmt.Errorf("unexpected predicate datum %s", result.Kind())
+	}
+}
+
+// validate runs the evaluator on the empty record to check that
+// the types of the arguments are acceptable by the compute functions
+func validate(ctx context.Context, schema *arrow.Schema, eval evaluator) error {
+	columns := make([]arrow.Array, 0, len(schema.Fields()))
+
+	for _, field := range schema.Fields() {
+		column := array.MakeArrayOfNull(memory.DefaultAllocator, field.Type, 0)
+		defer column.Release()
+
+		columns = append(columns, column)
+	}
+
+	record := array.NewRecord(schema, columns, 0)
+	defer record.Release()
+
+	datum, err := eval(ctx, record)
+	if err != nil {
+		return err
+	}
+
+	defer datum.Release()
+
+	if datum.(compute.ArrayLikeDatum).Type().ID() != arrow.BOOL {
+		return errors.New("predicate is not boolean")
+	}
+
+	return nil
+}
+
+// NewResidualFilter compiles the predicate that was not pushed down into the data source.
+// Every conjunct of the predicate is compiled independently, and the ones that cannot be evaluated
+// (for example, referring to the columns that are not returned to the engine) are skipped.
+// Returns nil if there is nothing to evaluate.
+func NewResidualFilter(
+	logger *zap.Logger,
+	predicate *api_service_protos.TPredicate,
+	what *api_service_protos.TSelect_TWhat,
+) (*ResidualFilter, error) {
+	if predicate == nil {
+		return nil, nil
+	}
+
+	schema, err := common.SelectWhatToArrowSchema(what)
+	if err != nil {
+		return nil, fmt.Errorf("select what to arrow schema: %w", err)
+	}
+
+	c := &compiler{schema: schema, allocator: memory.DefaultAllocator}
+
+	var evaluators []evaluator
+
+	for _, conjunct := range splitConjunction(predicate) {
+		eval, err := c.compilePredicate(conjunct)
+		if err == nil {
+			err = validate(context.Background(), schema, eval)
+		}
+
+		if err != nil {
+			logger.Debug("predicate is left to the engine", zap.String("predicate", conjunct.String()), zap.Error(err))
+
+			continue
+		}
+
+		evaluators = append(evaluators, eval)
+	}
+
+	if len(evaluators) == 0 {
+		return nil, nil
+	}
+
+	return &ResidualFilter{evaluators: evaluators}, nil
+}
+
+// MakeConjunction combines the predicates that were not pushed down into a single predicate
+func MakeConjunction(predicates []*api_service_protos.TPredicate) *api_service_protos.TPredicate {
+	switch len(predicates) {
+	case 0:
+		return nil
+	case 1:
+		return predicates[0]
+	default:
+		return &api_service_protos.TPredicate{
+			Payload: &api_service_protos.TPredicate_Conjunction{
+				Conjunction: &api_service_protos.TPredicate_TConjunction{
+					Operands: predicates,
+				},
+			},
+		}
+	}
+}
+
+func splitConjunction(predicate *api_service_protos.TPredicate) []*api_service_protos.TPredicate {
+	conjunction := predicate.GetConjunction()
+	if conjunction == nil {
+		return []*api_service_protos.TPredicate{predicate}
+	}
+
+	var out []*api_service_protos.TPredicate
+
+	for _, operand := range conjunction.Operands {
+		out = append(out, splitConjunction(operand)...)
+	}
+
+	return out
+}
+
+func countTrue(mask *array.Boolean) int {
+	n := 0
+
+	for i := 0; i < mask.Len(); i++ {
+		if mask.Value(i) {
+			n++
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,175 @@
+package filtering
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestResidualFilter(t *testing.T) {
+	what := &api_service_protos.TSelect_TWhat{
+		Items: []*api_service_protos.TSelect_TWhat_TItem{
+			makeColumn("id", common.MakePrimitiveType(Ydb.Type_INT32)),
+			makeColumn("name", common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8))),
+			makeColumn("flag", common.MakePrimitiveType(Ydb.Type_BOOL)),
+		},
+	}
+
+	schema, err := common.SelectWhatToArrowSchema(what)
+	require.NoError(t, err)
+
+	record := makeRecord(t, schema)
+	defer record.Release()
+
+	type testCase struct {
+		testName    string
+		predicate   *api_service_protos.TPredicate
+		expectedIDs []int32 // nil means that filter is not created
+	}
+
+	tcs := []testCase{
+		{
+			testName: "comparison",
+			predicate: makeComparison(
+				"id", api_service_protos.TPredicate_TComparison_GE,
+				common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_INT64), int64(3))),
+			expectedIDs: []int32{3, 4},
+		},
+		{
+			testName: "optional_literal_and_null_column",
+			predicate: makeComparison(
+				"name", api_service_protos.TPredicate_TComparison_NE,
+				common.MakeTypedValue(common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8)), "b")),
+			expectedIDs: []int32{1, 3},
+		},
+		{
+			testName: "conjunction_with_unsupported_part",
+			predicate: MakeConjunction([]*api_service_protos.TPredicate{
+				{
+					Payload: &api_service_protos.TPredicate_IsNotNull{
+						IsNotNull: &api_service_protos.TPredicate_TIsNotNull{
+							Value: &api_service_protos.TExpression{
+								Payload: &api_service_protos.TExpression_Column{Column: "name"},
+							},
+						},
+					},
+				},
+				// the column is missing from the result, so this part is left to the engine
+				makeComparison(
+					"missing", api_service_protos.TPredicate_TComparison_EQ,
+					common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_INT32), int32(1))),
+			}),
+			expectedIDs: []int32{1, 2, 3},
+		},
+		{
+			testName: "negated_bool_column",
+			predicate: &api_service_protos.TPredicate{
+				Payload: &api_service_protos.TPredicate_Negation{
+					Negation: &api_service_protos.TPredicate_TNegation{
+						Operand: &api_service_protos.TPredicate{
+							Payload: &api_service_protos.TPredicate_BoolExpression{
+								BoolExpression: &api_service_protos.TPredicate_TBoolExpression{
+									Value: &api_service_protos.TExpression{
+										Payload: &api_service_protos.TExpression_Column{Column: "flag"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedIDs: []int32{2, 4},
+		},
+		{
+			testName: "incompatible_types",
+			predicate: makeComparison(
+				"name", api_service_protos.TPredicate_TComparison_EQ,
+				common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_INT32), int32(1))),
+			expectedIDs: nil,
+		},
+		{
+			testName: "unsupported_operation",
+			predicate: makeComparison(
+				"name", api_service_protos.TPredicate_TComparison_STARTS_WITH,
+				common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_UTF8), "a")),
+			expectedIDs: nil,
+		},
+	}
+
+	logger := common.NewTestLogger(t)
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			filter, err := NewResidualFilter(logger, tc.predicate, what)
+			require.NoError(t, err)
+
+			if tc.expectedIDs == nil {
+				require.Nil(t, filter)
+
+				return
+			}
+
+			require.NotNil(t, filter)
+
+			out, err := filter.Apply(context.Background(), record)
+			require.NoError(t, err)
+
+			defer out.Release()
+
+			require.Equal(t, tc.expectedIDs, out.Column(0).(*array.Int32).Int32Values())
+		})
+	}
+}
+
+func makeRecord(t *testing.T, schema *arrow.Schema) arrow.Record {
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+
+	builder.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2, 3, 4}, nil)
+	builder.Field(1).(*array.StringBuilder).AppendValues([]string{"a", "b", "c", ""}, []bool{true, true, true, false})
+	builder.Field(2).(*array.Uint8Builder).AppendValues([]uint8{1, 0, 1, 0}, nil)
+
+	record := builder.NewRecord()
+	require.Equal(t, int64(4), record.NumRows())
+
+	return record
+}
+
+func makeColumn(name string, ydbType *Ydb.Type) *api_service_protos.TSelect_TWhat_TItem {
+	return &api_service_protos.TSelect_TWhat_TItem{
+		Payload: &api_service_protos.TSelect_TWhat_TItem_Column{
+			Column: &Ydb.Column{Name: name, Type: ydbType},
+		},
+	}
+}
+
+func makeComparison(
+	column string,
+	operation api_service_protos.TPredicate_TComparison_EOperation,
+	value *Ydb.TypedValue,
+) *api_service_protos.TPredicate {
+	return &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_Comparison{
+			Comparison: &api_service_protos.TPredicate_TComparison{
+				LeftValue: &api_service_protos.TExpression{
+					Payload: &api_service_protos.TExpression_Column{Column: column},
+				},
+				Operation: operation,
+				RightValue: &api_service_protos.TExpression{
+					Payload: &api_service_protos.TExpression_TypedValue{TypedValue: value},
+				},
+			},
+		},
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ydb-platform/fq-connector-go/app/config"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/ydb/table_metadata_cache"
 	"github.com/ydb-platform/fq-connector-go/app/server/observation"
+	"github.com/ydb-platform/fq-connector-go/app/server/schema_cache"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils"
 	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/solomon"
 )
@@ -45,6 +46,8 @@ const (
 	pprofServiceKey       = "pprof"
 	metricsServiceKey     = "metrics"
 	observationServiceKey = "observation"
+	flightServiceKey      = "flight"
+	adminServiceKey       = "admin"
 )
 
 func NewLauncher(logger *zap.Logger, cfg *config.TServerConfig) (*Launcher, error) {
@@ -73,6 +76,14 @@ func NewLauncher(logger *zap.Logger, cfg *config.TServerConfig) (*Launcher, erro
 		return nil, fmt.Errorf("new YDB table metadata cache: %w", err)
 	}
 
+	// initialize cache of the DescribeTable results, it's shared by the Connector and the Admin services
+	schemaCache, err := schema_cache.NewCache(cfg.SchemaCache)
+	if err != nil {
+		return nil, fmt.Errorf("new schema cache: %w", err)
+	}
+
+	table_metadata_cache.RegisterMetricsWithPrefix(solomonRegistry, "schema_cache_", schemaCache)
+
 	// init metrics server
 	if cfg.MetricsServer != nil {
 		l.services[metricsServiceKey] = newServiceMetrics(
@@ -84,17 +95,44 @@ func NewLauncher(logger *zap.Logger, cfg *config.TServerConfig) (*Launcher, erro
 	}
 
 	// init GRPC server
-	l.services[connectorServiceKey], err = newServiceConnector(
+	connector, err := newServiceConnector(
 		logger.With(zap.String("service", connectorServiceKey)),
 		cfg,
 		solomonRegistry,
 		observationStorage,
 		ydbTableMetadataCache,
+		schemaCache,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("new connector service: %w", err)
 	}
 
+	l.services[connectorServiceKey] = connector
+
+	// init Arrow Flight server
+	if cfg.FlightServer != nil {
+		l.services[flightServiceKey], err = newServiceFlight(
+			logger.With(zap.String("service", flightServiceKey)),
+			cfg,
+			connector,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("new flight service: %w", err)
+		}
+	}
+
+	// init Admin server
+	if cfg.AdminServer != nil {
+		l.services[adminServiceKey], err = newServiceAdmin(
+			logger.With(zap.String("service", adminServiceKey)),
+			cfg.AdminServer,
+			schemaCache,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("new admin service: %w", err)
+		}
+	}
+
 	// init Pprof server
 	if cfg.PprofServer != nil {
 		l.services[pprofServiceKey] = newServicePprof(
@@ -18,9 +18,13 @@ type Storage interface {
 	CreateIncomingQuery(
 		ctx context.Context, logger *zap.Logger, dataSourceKind api_common.EGenericDataSourceKind) (*zap.Logger, string, error)
 	FinishIncomingQuery(
-		ctx context.Context, logger *zap.Logger, id string, stats *api_service_protos.TReadSplitsResponse_TStats) error
+		ctx context.Context, logger *zap.Logger, id string,
+		stats *api_service_protos.TReadSplitsResponse_TStats, peakMemoryBytes uint64) error
 	CancelIncomingQuery(
-		ctx context.Context, logger *zap.Logger, id string, errorMsg string, stats *api_service_protos.TReadSplitsResponse_TStats) error
+		ctx context.Context, logger *zap.Logger, id string, errorMsg string,
+		stats *api_service_protos.TReadSplitsResponse_TStats, peakMemoryBytes uint64) error
+	UpdateIncomingQueryMemory(
+		ctx context.Context, logger *zap.Logger, id string, memoryBytes, peakMemoryBytes uint64) error
 	ListIncomingQueries(
 		ctx context.Context, logger *zap.Logger, state *observation.QueryState, limit, offset int,
 	) ([]*observation.IncomingQuery, error)
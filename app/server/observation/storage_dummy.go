@@ -23,12 +23,16 @@ func (storageDummyImpl) CreateIncomingQuery(
 }
 
 func (storageDummyImpl) FinishIncomingQuery(
-	_ context.Context, _ *zap.Logger, _ string, _ *api_service_protos.TReadSplitsResponse_TStats) error {
+	_ context.Context, _ *zap.Logger, _ string, _ *api_service_protos.TReadSplitsResponse_TStats, _ uint64) error {
 	return nil
 }
 
 func (storageDummyImpl) CancelIncomingQuery(
-	_ context.Context, _ *zap.Logger, _ string, _ string, _ *api_service_protos.TReadSplitsResponse_TStats) error {
+	_ context.Context, _ *zap.Logger, _ string, _ string, _ *api_service_protos.TReadSplitsResponse_TStats, _ uint64) error {
+	return nil
+}
+
+func (storageDummyImpl) UpdateIncomingQueryMemory(_ context.Context, _ *zap.Logger, _ string, _, _ uint64) error {
 	return nil
 }
 
@@ -23,16 +23,17 @@ var _ Storage = (*storageSQLite)(nil)
 
 // storageSQLite handles storing and retrieving query data
 type storageSQLite struct {
-	db                      *sql.DB
-	exitChan                chan struct{}
-	createIncomingQueryStmt *sql.Stmt
-	finishIncomingQueryStmt *sql.Stmt
-	cancelIncomingQueryStmt *sql.Stmt
-	createOutgoingQueryStmt *sql.Stmt
-	finishOutgoingQueryStmt *sql.Stmt
-	cancelOutgoingQueryStmt *sql.Stmt
-	logger                  *zap.Logger
-	cfg                     *config.TObservationConfig_TStorage_TSQLite
+	db                            *sql.DB
+	exitChan                      chan struct{}
+	createIncomingQueryStmt       *sql.Stmt
+	finishIncomingQueryStmt       *sql.Stmt
+	cancelIncomingQueryStmt       *sql.Stmt
+	updateIncomingQueryMemoryStmt *sql.Stmt
+	createOutgoingQueryStmt       *sql.Stmt
+	finishOutgoingQueryStmt       *sql.Stmt
+	cancelOutgoingQueryStmt       *sql.Stmt
+	logger                        *zap.Logger
+	cfg                           *config.TObservationConfig_TStorage_TSQLite
 }
 
 // initialize creates the necessary tables and prepared statements
@@ -47,7 +48,9 @@ func (s *storageSQLite) initialize() error {
 		state TEXT NOT NULL,
 		created_at TIMESTAMP NOT NULL,
 		finished_at TIMESTAMP,
-		error TEXT
+		error TEXT,
+		peak_memory_bytes INTEGER NOT NULL DEFAULT 0,
+		memory_bytes INTEGER NOT NULL DEFAULT 0
 	);
 	
 	CREATE INDEX IF NOT EXISTS idx_incoming_queries_state ON incoming_queries(state);
@@ -97,6 +100,13 @@ func (s *storageSQLite) initialize() error {
 		return fmt.Errorf("creating incoming_queries table: %w", err)
 	}
 
+	// The table may have been created by the previous versions of the service
+	for _, column := range []string{"peak_memory_bytes", "memory_bytes"} {
+		if err = s.addColumnIfMissing(ctx, "incoming_queries", column, "INTEGER NOT NULL DEFAULT 0"); err != nil {
+			return fmt.Errorf("adding column to incoming_queries table: %w", err)
+		}
+	}
+
 	_, err = s.db.ExecContext(ctx, createOutgoingTableSQL)
 	if err != nil {
 		return fmt.Errorf("creating outgoing_queries table: %w", err)
@@ -110,17 +120,26 @@ func (s *storageSQLite) initialize() error {
 	}
 
 	s.finishIncomingQueryStmt, err = s.db.PrepareContext(ctx,
-		"UPDATE incoming_queries SET state = ?, finished_at = ?, rows_read = ?, bytes_read = ? WHERE id = ?")
+		"UPDATE incoming_queries SET state = ?, finished_at = ?, rows_read = ?, bytes_read = ?, memory_bytes = 0, peak_memory_bytes = ? "+
+			"WHERE id = ?")
 	if err != nil {
 		return fmt.Errorf("preparing finish incoming query statement: %w", err)
 	}
 
 	s.cancelIncomingQueryStmt, err = s.db.PrepareContext(ctx,
-		"UPDATE incoming_queries SET state = ?, finished_at = ?, error = ?, rows_read = ?, bytes_read = ? WHERE id = ?")
+		"UPDATE incoming_queries SET state = ?, finished_at = ?, error = ?, rows_read = ?, bytes_read = ?, "+
+			"memory_bytes = 0, peak_memory_bytes = ? "+
+			"WHERE id = ?")
 	if err != nil {
 		return fmt.Errorf("preparing cancel incoming query statement: %w", err)
 	}
 
+	s.updateIncomingQueryMemoryStmt, err = s.db.PrepareContext(ctx,
+		"UPDATE incoming_queries SET memory_bytes = ?, peak_memory_bytes = ? WHERE id = ?")
+	if err != nil {
+		return fmt.Errorf("preparing update incoming query memory statement: %w", err)
+	}
+
 	// Prepare statements for outgoing queries
 	s.createOutgoingQueryStmt, err = s.db.PrepareContext(ctx, `
 		INSERT INTO outgoing_queries
@@ -145,6 +164,27 @@ func (s *storageSQLite) initialize() error {
 	return nil
 }
 
+func (s *storageSQLite) addColumnIfMissing(ctx context.Context, table, column, definition string) error {
+	var count int
+
+	err := s.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?", table, column).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("checking column %s: %w", column, err)
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	_, err = s.db.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	if err != nil {
+		return fmt.Errorf("adding column %s: %w", column, err)
+	}
+
+	return nil
+}
+
 // Helper function to convert state enum to string
 func stateToString(state observation.QueryState) string {
 	switch state {
@@ -199,11 +239,12 @@ func (s *storageSQLite) CreateIncomingQuery(
 
 // FinishIncomingQuery marks an incoming query as finished with final stats
 func (s *storageSQLite) FinishIncomingQuery(
-	ctx context.Context, logger *zap.Logger, id string, stats *api_service_protos.TReadSplitsResponse_TStats) error {
+	ctx context.Context, logger *zap.Logger, id string,
+	stats *api_service_protos.TReadSplitsResponse_TStats, peakMemoryBytes uint64) error {
 	finishedAt := time.Now().UTC()
 
 	result, err := s.finishIncomingQueryStmt.ExecContext(ctx,
-		stateToString(observation.QueryState_QUERY_STATE_FINISHED), finishedAt, stats.Rows, stats.Bytes, id,
+		stateToString(observation.QueryState_QUERY_STATE_FINISHED), finishedAt, stats.Rows, stats.Bytes, peakMemoryBytes, id,
 	)
 	if err != nil {
 		return fmt.Errorf("marking incoming query as finished: %w", err)
@@ -223,16 +264,27 @@ func (s *storageSQLite) FinishIncomingQuery(
 	return nil
 }
 
+// UpdateIncomingQueryMemory saves the memory usage of a running incoming query
+func (s *storageSQLite) UpdateIncomingQueryMemory(
+	ctx context.Context, _ *zap.Logger, id string, memoryBytes, peakMemoryBytes uint64) error {
+	if _, err := s.updateIncomingQueryMemoryStmt.ExecContext(ctx, memoryBytes, peakMemoryBytes, id); err != nil {
+		return fmt.Errorf("updating incoming query memory: %w", err)
+	}
+
+	return nil
+}
+
 // CancelIncomingQuery marks an incoming query as canceled with an error message
 func (s *storageSQLite) CancelIncomingQuery(ctx context.Context, logger *zap.Logger,
 	id string,
 	errorMsg string,
 	stats *api_service_protos.TReadSplitsResponse_TStats,
+	peakMemoryBytes uint64,
 ) error {
 	finishedAt := time.Now().UTC()
 
 	result, err := s.cancelIncomingQueryStmt.ExecContext(ctx,
-		stateToString(observation.QueryState_QUERY_STATE_CANCELED), finishedAt, errorMsg, stats.Rows, stats.Bytes, id,
+		stateToString(observation.QueryState_QUERY_STATE_CANCELED), finishedAt, errorMsg, stats.Rows, stats.Bytes, peakMemoryBytes, id,
 	)
 	if err != nil {
 		return fmt.Errorf("canceling incoming query: %w", err)
@@ -263,12 +315,12 @@ func (s *storageSQLite) ListIncomingQueries(
 
 	if state == nil || *state == observation.QueryState_QUERY_STATE_UNSPECIFIED {
 		querySQL = `
-			SELECT id, data_source_kind, rows_read, bytes_read, state, created_at, finished_at, error
+			SELECT id, data_source_kind, rows_read, bytes_read, state, created_at, finished_at, error, memory_bytes, peak_memory_bytes
 			FROM incoming_queries ORDER BY created_at DESC LIMIT ? OFFSET ?`
 		args = []any{limit, offset}
 	} else {
 		querySQL = `
-			SELECT id, data_source_kind, rows_read, bytes_read, state, created_at, finished_at, error
+			SELECT id, data_source_kind, rows_read, bytes_read, state, created_at, finished_at, error, memory_bytes, peak_memory_bytes
 			FROM incoming_queries WHERE state = ? ORDER BY created_at DESC LIMIT ? OFFSET ?`
 		args = []any{stateToString(*state), limit, offset}
 	}
@@ -283,30 +335,34 @@ func (s *storageSQLite) ListIncomingQueries(
 
 	for rows.Next() {
 		var (
-			id             string
-			dataSourceKind string
-			rowsRead       int64
-			bytesRead      int64
-			stateStr       string
-			createdAt      time.Time
-			finishedAt     sql.NullTime
-			errorMsg       sql.NullString
+			id              string
+			dataSourceKind  string
+			rowsRead        int64
+			bytesRead       int64
+			stateStr        string
+			createdAt       time.Time
+			finishedAt      sql.NullTime
+			errorMsg        sql.NullString
+			memoryBytes     int64
+			peakMemoryBytes int64
 		)
 
 		if err := rows.Scan(
 			&id, &dataSourceKind, &rowsRead, &bytesRead,
-			&stateStr, &createdAt, &finishedAt, &errorMsg,
+			&stateStr, &createdAt, &finishedAt, &errorMsg, &memoryBytes, &peakMemoryBytes,
 		); err != nil {
 			return nil, fmt.Errorf("scanning incoming query: %w", err)
 		}
 
 		query := &observation.IncomingQuery{
-			Id:             id,
-			DataSourceKind: dataSourceKind,
-			RowsRead:       rowsRead,
-			BytesRead:      bytesRead,
-			State:          stringToState(stateStr),
-			CreatedAt:      timestamppb.New(createdAt),
+			Id:              id,
+			DataSourceKind:  dataSourceKind,
+			RowsRead:        rowsRead,
+			BytesRead:       bytesRead,
+			State:           stringToState(stateStr),
+			CreatedAt:       timestamppb.New(createdAt),
+			MemoryBytes:     memoryBytes,
+			PeakMemoryBytes: peakMemoryBytes,
 		}
 
 		if errorMsg.Valid {
@@ -577,6 +633,10 @@ func (s *storageSQLite) Close(_ context.Context) error {
 			s.logger.Error("close cancel incoming query statement", zap.Error(err))
 		}
 
+		if err := s.updateIncomingQueryMemoryStmt.Close(); err != nil {
+			s.logger.Error("close update incoming query memory statement", zap.Error(err))
+		}
+
 		// Close prepared statements for outgoing queries
 		if err := s.createOutgoingQueryStmt.Close(); err != nil {
 			s.logger.Error("close create outgoing query statement", zap.Error(err))
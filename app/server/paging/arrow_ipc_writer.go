@@ -0,0 +1,119 @@
+package paging
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/ipc"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// ArrowIPCWriter serializes pages in Arrow IPC Streaming format
+// with the compression and the dictionary encoding requested by the client.
+// It's shared by the columnar buffers and the sinks serving the same request.
+type ArrowIPCWriter struct {
+	allocator         memory.Allocator
+	options           []ipc.Option
+	dictionaryEncoder *dictionaryEncoder // nil if dictionary encoding is not requested
+}
+
+// write serializes the record and returns the serialized data
+// along with the size of the record buffers before compression.
+func (w *ArrowIPCWriter) write(record arrow.Record) ([]byte, uint64, error) {
+	if w.dictionaryEncoder != nil {
+		encoded, err := w.dictionaryEncoder.encode(record)
+		if err != nil {
+			return nil, 0, fmt.Errorf("dictionary encode: %w", err)
+		}
+
+		defer encoded.Release()
+
+		record = encoded
+	}
+
+	var buf bytes.Buffer
+
+	options := append([]ipc.Option{ipc.WithSchema(record.Schema()), ipc.WithAllocator(w.allocator)}, w.options...)
+
+	writer := ipc.NewWriter(&buf, options...)
+
+	if err := writer.Write(record); err != nil {
+		return nil, 0, fmt.Errorf("write record: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, 0, fmt.Errorf("close arrow writer: %w", err)
+	}
+
+	return buf.Bytes(), recordBuffersSize(record), nil
+}
+
+func recordBuffersSize(record arrow.Record) uint64 {
+	var size uint64
+
+	for _, column := range record.Columns() {
+		size += arrayDataBuffersSize(column.Data())
+	}
+
+	return size
+}
+
+func arrayDataBuffersSize(data arrow.ArrayData) uint64 {
+	var size uint64
+
+	for _, buffer := range data.Buffers() {
+		if buffer != nil {
+			size += uint64(buffer.Len())
+		}
+	}
+
+	for _, child := range data.Children() {
+		size += arrayDataBuffersSize(child)
+	}
+
+	return size
+}
+
+// NewArrowIPCWriter makes a writer for the records corresponding to the selected columns.
+func NewArrowIPCWriter(
+	allocator memory.Allocator,
+	compression *api_service_protos.TReadSplitsRequest_TCompression,
+	dictionaryEncoding *api_service_protos.TReadSplitsRequest_TDictionaryEncoding,
+	selectWhat *api_service_protos.TSelect_TWhat,
+) (*ArrowIPCWriter, error) {
+	schema, err := common.SelectWhatToArrowSchema(selectWhat)
+	if err != nil {
+		return nil, fmt.Errorf("convert Select.What to Arrow schema: %w", err)
+	}
+
+	dictionaryEncoder, err := newDictionaryEncoder(allocator, schema, dictionaryEncoding)
+	if err != nil {
+		return nil, fmt.Errorf("new dictionary encoder: %w", err)
+	}
+
+	return newArrowIPCWriter(allocator, compression, dictionaryEncoder), nil
+}
+
+// newArrowIPCWriter expects the compression settings to be validated along with the request
+func newArrowIPCWriter(
+	allocator memory.Allocator,
+	compression *api_service_protos.TReadSplitsRequest_TCompression,
+	dictionaryEncoder *dictionaryEncoder,
+) *ArrowIPCWriter {
+	w := &ArrowIPCWriter{allocator: allocator, dictionaryEncoder: dictionaryEncoder}
+
+	// Arrow IPC writer always uses the default level of the codec
+	switch compression.GetCodec() {
+	case api_service_protos.TReadSplitsRequest_TCompression_LZ4_FRAME:
+		w.options = append(w.options, ipc.WithLZ4())
+	case api_service_protos.TReadSplitsRequest_TCompression_ZSTD:
+		w.options = append(w.options, ipc.WithZstd())
+	default:
+	}
+
+	return w
+}
@@ -0,0 +1,68 @@
+package paging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/ipc"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+func TestArrowIPCWriter(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{{Name: "col", Type: arrow.BinaryTypes.String}}, nil)
+
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+
+	for i := 0; i < 1000; i++ {
+		builder.Field(0).(*array.StringBuilder).Append(strings.Repeat("abc", 10))
+	}
+
+	record := builder.NewRecord()
+	defer record.Release()
+
+	writeRecord := func(codec api_service_protos.TReadSplitsRequest_TCompression_ECodec) ([]byte, uint64) {
+		writer := newArrowIPCWriter(
+			memory.DefaultAllocator,
+			&api_service_protos.TReadSplitsRequest_TCompression{Codec: codec},
+			nil,
+		)
+
+		data, uncompressedBytes, err := writer.write(record)
+		require.NoError(t, err)
+
+		return data, uncompressedBytes
+	}
+
+	plainData, plainBytes := writeRecord(api_service_protos.TReadSplitsRequest_TCompression_CODEC_UNSPECIFIED)
+
+	for _, codec := range []api_service_protos.TReadSplitsRequest_TCompression_ECodec{
+		api_service_protos.TReadSplitsRequest_TCompression_LZ4_FRAME,
+		api_service_protos.TReadSplitsRequest_TCompression_ZSTD,
+	} {
+		codec := codec
+
+		t.Run(codec.String(), func(t *testing.T) {
+			data, uncompressedBytes := writeRecord(codec)
+
+			require.Equal(t, plainBytes, uncompressedBytes)
+			require.Less(t, len(data), len(plainData))
+
+			// compressed data must be readable without any additional options
+			reader, err := ipc.NewReader(bytes.NewReader(data))
+			require.NoError(t, err)
+
+			defer reader.Release()
+
+			require.True(t, reader.Next())
+			require.True(t, array.RecordEqual(record, reader.Record()))
+		})
+	}
+
+}
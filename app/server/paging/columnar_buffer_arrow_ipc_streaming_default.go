@@ -1,27 +1,35 @@
 package paging
 
 import (
-	"bytes"
+	"context"
 	"fmt"
 
 	"github.com/apache/arrow/go/v13/arrow"
 	"github.com/apache/arrow/go/v13/arrow/array"
-	"github.com/apache/arrow/go/v13/arrow/ipc"
-	"github.com/apache/arrow/go/v13/arrow/memory"
 	"go.uber.org/zap"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
 )
 
 var _ ColumnarBuffer[any] = (*columnarBufferArrowIPCStreamingDefault[any])(nil)
 
 type columnarBufferArrowIPCStreamingDefault[T Acceptor] struct {
-	arrowAllocator memory.Allocator
+	writer         *ArrowIPCWriter
 	builders       []array.Builder
 	schema         *arrow.Schema
 	logger         *zap.Logger
 	arrowRecord    arrow.Record // Store the Arrow Record directly
 	rowsAdded      bool         // Track if rows were added via addRow
+	residualFilter *filtering.ResidualFilter
+}
+
+// setResidualFilter makes the buffer filter the accumulated rows before serialization.
+// Buffers without columns are left intact.
+func setResidualFilter[T Acceptor](buffer ColumnarBuffer[T], filter *filtering.ResidualFilter) {
+	if cb, ok := buffer.(*columnarBufferArrowIPCStreamingDefault[T]); ok {
+		cb.residualFilter = filter
+	}
 }
 
 // AddRow saves a row obtained from the datasource into the buffer
@@ -66,40 +74,40 @@ func (cb *columnarBufferArrowIPCStreamingDefault[T]) ToResponse() (*api_service_
 
 		// We'll need to release this record after writing it
 		releaseRecord = true
-	} else {
-		// No data to return
-		return &api_service_protos.TReadSplitsResponse{}, nil
-	}
-
-	// prepare arrow writer
-	var buf bytes.Buffer
-
-	writer := ipc.NewWriter(&buf, ipc.WithSchema(cb.schema), ipc.WithAllocator(cb.arrowAllocator))
 
-	if err := writer.Write(record); err != nil {
-		if releaseRecord {
+		if cb.residualFilter != nil {
+			filtered, err := cb.residualFilter.Apply(context.Background(), record)
 			record.Release()
-		}
 
-		return nil, fmt.Errorf("write record: %w", err)
-	}
+			if err != nil {
+				return nil, fmt.Errorf("apply residual filter: %w", err)
+			}
 
-	if err := writer.Close(); err != nil {
-		if releaseRecord {
-			record.Release()
+			record = filtered
 		}
-
-		return nil, fmt.Errorf("close arrow writer: %w", err)
+	} else {
+		// No data to return
+		return &api_service_protos.TReadSplitsResponse{}, nil
 	}
 
+	data, uncompressedBytes, err := cb.writer.write(record)
+
 	// Release the record if we created it
 	if releaseRecord {
 		record.Release()
 	}
 
+	if err != nil {
+		return nil, fmt.Errorf("write record: %w", err)
+	}
+
 	out := &api_service_protos.TReadSplitsResponse{
 		Payload: &api_service_protos.TReadSplitsResponse_ArrowIpcStreaming{
-			ArrowIpcStreaming: buf.Bytes(),
+			ArrowIpcStreaming: data,
+		},
+		Stats: &api_service_protos.TReadSplitsResponse_TStats{
+			PayloadBytes:             uint64(len(data)),
+			UncompressedPayloadBytes: uncompressedBytes,
 		},
 	}
 
@@ -1,13 +1,10 @@
 package paging
 
 import (
-	"bytes"
 	"fmt"
 
 	"github.com/apache/arrow/go/v13/arrow"
 	"github.com/apache/arrow/go/v13/arrow/array"
-	"github.com/apache/arrow/go/v13/arrow/ipc"
-	"github.com/apache/arrow/go/v13/arrow/memory"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 )
@@ -16,9 +13,9 @@ var _ ColumnarBuffer[any] = (*columnarBufferArrowIPCStreamingEmptyColumns[any])(
 
 // special implementation for buffer that writes schema with empty columns set
 type columnarBufferArrowIPCStreamingEmptyColumns[T Acceptor] struct {
-	arrowAllocator memory.Allocator
-	schema         *arrow.Schema
-	rowsAdded      int
+	writer    *ArrowIPCWriter
+	schema    *arrow.Schema
+	rowsAdded int
 }
 
 // AddRow saves a row obtained from the datasource into the buffer
@@ -40,22 +37,18 @@ func (cb *columnarBufferArrowIPCStreamingEmptyColumns[T]) ToResponse() (*api_ser
 
 	record := array.NewRecord(cb.schema, columns, int64(cb.rowsAdded))
 
-	// prepare arrow writer
-	var buf bytes.Buffer
-
-	writer := ipc.NewWriter(&buf, ipc.WithSchema(cb.schema), ipc.WithAllocator(cb.arrowAllocator))
-
-	if err := writer.Write(record); err != nil {
+	data, uncompressedBytes, err := cb.writer.write(record)
+	if err != nil {
 		return nil, fmt.Errorf("write record: %w", err)
 	}
 
-	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("close arrow writer: %w", err)
-	}
-
 	out := &api_service_protos.TReadSplitsResponse{
 		Payload: &api_service_protos.TReadSplitsResponse_ArrowIpcStreaming{
-			ArrowIpcStreaming: buf.Bytes(),
+			ArrowIpcStreaming: data,
+		},
+		Stats: &api_service_protos.TReadSplitsResponse_TStats{
+			PayloadBytes:             uint64(len(data)),
+			UncompressedPayloadBytes: uncompressedBytes,
 		},
 	}
 
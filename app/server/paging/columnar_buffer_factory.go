@@ -17,6 +17,7 @@ type columnarBufferFactoryImpl[T Acceptor] struct {
 	arrowAllocator memory.Allocator
 	logger         *zap.Logger
 	format         api_service_protos.TReadSplitsRequest_EFormat
+	writer         *ArrowIPCWriter
 	schema         *arrow.Schema
 	ydbTypes       []*Ydb.Type
 }
@@ -27,9 +28,9 @@ func (cbf *columnarBufferFactoryImpl[T]) MakeBuffer() (ColumnarBuffer[T], error)
 		// Special case for empty columns
 		if len(cbf.ydbTypes) == 0 {
 			return &columnarBufferArrowIPCStreamingEmptyColumns[T]{
-				arrowAllocator: cbf.arrowAllocator,
-				schema:         cbf.schema,
-				rowsAdded:      0,
+				writer:    cbf.writer,
+				schema:    cbf.schema,
+				rowsAdded: 0,
 			}, nil
 		}
 
@@ -40,10 +41,10 @@ func (cbf *columnarBufferFactoryImpl[T]) MakeBuffer() (ColumnarBuffer[T], error)
 		}
 
 		return &columnarBufferArrowIPCStreamingDefault[T]{
-			arrowAllocator: cbf.arrowAllocator,
-			builders:       builders,
-			schema:         cbf.schema,
-			logger:         cbf.logger,
+			writer:   cbf.writer,
+			builders: builders,
+			schema:   cbf.schema,
+			logger:   cbf.logger,
 		}, nil
 	default:
 		return nil, fmt.Errorf("unknown format: %v", cbf.format)
@@ -54,6 +55,7 @@ func NewColumnarBufferFactory[T Acceptor](
 	logger *zap.Logger,
 	arrowAllocator memory.Allocator,
 	format api_service_protos.TReadSplitsRequest_EFormat,
+	writer *ArrowIPCWriter,
 	selectWhat *api_service_protos.TSelect_TWhat,
 ) (ColumnarBufferFactory[T], error) {
 	ydbTypes, err := common.SelectWhatToYDBTypes(selectWhat)
@@ -70,6 +72,7 @@ func NewColumnarBufferFactory[T Acceptor](
 		logger:         logger,
 		arrowAllocator: arrowAllocator,
 		format:         format,
+		writer:         writer,
 		schema:         schema,
 		ydbTypes:       ydbTypes,
 	}
@@ -0,0 +1,105 @@
+package paging
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// dictionaryEncoder replaces the plain string columns of a record with the dictionary-encoded ones.
+// Every record gets its own dictionary, so the pages remain independent from each other.
+type dictionaryEncoder struct {
+	allocator memory.Allocator
+	columns   map[string]struct{}
+}
+
+func (e *dictionaryEncoder) encode(record arrow.Record) (arrow.Record, error) {
+	fields := make([]arrow.Field, 0, record.NumCols())
+	columns := make([]arrow.Array, 0, record.NumCols())
+
+	defer func() {
+		for _, column := range columns {
+			column.Release()
+		}
+	}()
+
+	for i, field := range record.Schema().Fields() {
+		column := record.Column(i)
+
+		if _, ok := e.columns[field.Name]; !ok {
+			column.Retain()
+			fields = append(fields, field)
+			columns = append(columns, column)
+
+			continue
+		}
+
+		encoded, err := e.encodeColumn(column)
+		if err != nil {
+			return nil, fmt.Errorf("encode column '%s': %w", field.Name, err)
+		}
+
+		field.Type = encoded.DataType()
+		fields = append(fields, field)
+		columns = append(columns, encoded)
+	}
+
+	schema := arrow.NewSchema(fields, nil)
+
+	return array.NewRecord(schema, columns, record.NumRows()), nil
+}
+
+func (e *dictionaryEncoder) encodeColumn(column arrow.Array) (arrow.Array, error) {
+	dataType := &arrow.DictionaryType{
+		IndexType: arrow.PrimitiveTypes.Int32,
+		ValueType: column.DataType(),
+	}
+
+	builder := array.NewDictionaryBuilder(e.allocator, dataType)
+	defer builder.Release()
+
+	if err := builder.AppendArray(column); err != nil {
+		return nil, fmt.Errorf("append array: %w", err)
+	}
+
+	return builder.NewArray(), nil
+}
+
+// newDictionaryEncoder returns nil if the client didn't request dictionary encoding
+func newDictionaryEncoder(
+	allocator memory.Allocator,
+	schema *arrow.Schema,
+	dictionaryEncoding *api_service_protos.TReadSplitsRequest_TDictionaryEncoding,
+) (*dictionaryEncoder, error) {
+	if len(dictionaryEncoding.GetColumns()) == 0 {
+		return nil, nil
+	}
+
+	e := &dictionaryEncoder{
+		allocator: allocator,
+		columns:   make(map[string]struct{}, len(dictionaryEncoding.GetColumns())),
+	}
+
+	for _, name := range dictionaryEncoding.GetColumns() {
+		fields, ok := schema.FieldsByName(name)
+		if !ok {
+			return nil, fmt.Errorf("column '%s' is not selected: %w", name, common.ErrInvalidRequest)
+		}
+
+		switch fields[0].Type.ID() {
+		case arrow.STRING, arrow.BINARY:
+		default:
+			return nil, fmt.Errorf(
+				"column '%s' of type %v can't be dictionary-encoded: %w", name, fields[0].Type, common.ErrInvalidRequest)
+		}
+
+		e.columns[name] = struct{}{}
+	}
+
+	return e, nil
+}
@@ -0,0 +1,105 @@
+package paging
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/ipc"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestDictionaryEncoder(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "level", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "host", Type: arrow.BinaryTypes.Binary},
+	}, nil)
+
+	allocator := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer allocator.AssertSize(t, 0)
+
+	builder := array.NewRecordBuilder(allocator, schema)
+	defer builder.Release()
+
+	levels := []string{"INFO", "WARN", "ERROR"}
+
+	for i := 0; i < 1000; i++ {
+		builder.Field(0).(*array.Int32Builder).Append(int32(i))
+
+		if i%10 == 0 {
+			builder.Field(1).(*array.StringBuilder).AppendNull()
+		} else {
+			builder.Field(1).(*array.StringBuilder).Append(levels[i%len(levels)])
+		}
+
+		builder.Field(2).(*array.BinaryBuilder).Append([]byte("host.example.com"))
+	}
+
+	record := builder.NewRecord()
+	defer record.Release()
+
+	writeRecord := func(dictionaryEncoding *api_service_protos.TReadSplitsRequest_TDictionaryEncoding) []byte {
+		encoder, err := newDictionaryEncoder(allocator, schema, dictionaryEncoding)
+		require.NoError(t, err)
+
+		writer := newArrowIPCWriter(allocator, nil, encoder)
+
+		data, _, err := writer.write(record)
+		require.NoError(t, err)
+
+		return data
+	}
+
+	plainData := writeRecord(nil)
+
+	data := writeRecord(&api_service_protos.TReadSplitsRequest_TDictionaryEncoding{Columns: []string{"level", "host"}})
+	require.Less(t, len(data), len(plainData))
+
+	reader, err := ipc.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	defer reader.Release()
+
+	require.True(t, reader.Next())
+
+	actual := reader.Record()
+	require.Equal(t, record.NumRows(), actual.NumRows())
+
+	// not requested columns are left intact
+	require.True(t, array.Equal(record.Column(0), actual.Column(0)))
+
+	for i := 1; i < 3; i++ {
+		dict, ok := actual.Column(i).(*array.Dictionary)
+		require.True(t, ok)
+		require.Equal(t, arrow.PrimitiveTypes.Int32, dict.DataType().(*arrow.DictionaryType).IndexType)
+		require.Equal(t, record.Column(i).DataType(), dict.Dictionary().DataType())
+
+		for row := 0; row < int(record.NumRows()); row++ {
+			require.Equal(t, record.Column(i).IsNull(row), dict.IsNull(row))
+
+			if !dict.IsNull(row) {
+				require.Equal(t, record.Column(i).GetOneForMarshal(row), dict.Dictionary().GetOneForMarshal(dict.GetValueIndex(row)))
+			}
+		}
+	}
+
+	require.Equal(t, len(levels), actual.Column(1).(*array.Dictionary).Dictionary().Len())
+	require.Equal(t, 1, actual.Column(2).(*array.Dictionary).Dictionary().Len())
+
+	t.Run("invalid_columns", func(t *testing.T) {
+		for _, column := range []string{"id", "unknown"} {
+			_, err := newDictionaryEncoder(
+				allocator,
+				schema,
+				&api_service_protos.TReadSplitsRequest_TDictionaryEncoding{Columns: []string{column}},
+			)
+			require.ErrorIs(t, err, common.ErrInvalidRequest)
+		}
+	})
+}
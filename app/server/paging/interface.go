@@ -1,11 +1,15 @@
 package paging
 
 import (
+	"time"
+
 	"github.com/apache/arrow/go/v13/arrow"
 	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
 	"go.uber.org/zap"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
 )
 
 // Acceptor is a fundamental type class that is used during data extraction from the data source
@@ -47,7 +51,17 @@ type ReadResult[T Acceptor] struct {
 	Stats             *api_service_protos.TReadSplitsResponse_TStats
 	Error             error
 	IsTerminalMessage bool
-	Logger            *zap.Logger // logger annotated with the data source instance description
+	Logger            *zap.Logger  // logger annotated with the data source instance description
+	memoryQuota       *MemoryQuota // accounts the serialized data until it is sent (optional)
+}
+
+// Release returns the memory occupied by the serialized data to the budget.
+// Must be called when the result is sent or discarded.
+func (r *ReadResult[T]) Release() {
+	if r.memoryQuota != nil {
+		r.memoryQuota.Track(-int64(len(r.Data)))
+		r.memoryQuota = nil
+	}
 }
 
 // Sink is a destination for a data stream that is read out of an external data source connection.
@@ -68,6 +82,9 @@ type Sink[T Acceptor] interface {
 }
 type SinkParams struct {
 	Logger *zap.Logger
+	// ResidualFilter is applied to the data before sending it to the engine.
+	// It holds the part of the predicate that was not pushed down into the data source (optional).
+	ResidualFilter *filtering.ResidualFilter
 }
 
 // SinkFactory should be instantiated once for each ReadSplits request.
@@ -80,4 +97,10 @@ type SinkFactory[T Acceptor] interface {
 	ResultQueue() <-chan *ReadResult[T]
 	// FinalStats returns the overall statistics collected during the request processing.
 	FinalStats() *api_service_protos.TReadSplitsResponse_TStats
+	// ReportPageSent notifies factory about the page delivered to the client.
+	// It's used to adjust the page size to the speed of the client.
+	ReportPageSent(sendLatency time.Duration)
+	// Allocator returns the allocator accounting the memory of the request.
+	// It should be used for the Arrow records built by the data source connections.
+	Allocator() memory.Allocator
 }
@@ -0,0 +1,209 @@
+package paging
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/v13/arrow/memory"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
+)
+
+// MemoryBudget should be instantiated once per server.
+// It tracks the memory allocated by all the ReadSplits requests in order to prevent OOMs.
+type MemoryBudget struct {
+	allocator    memory.Allocator
+	limit        uint64 // zero means no limit
+	requestLimit uint64 // zero means no limit
+	allocated    atomic.Int64
+
+	// every memory release closes the channel to wake up the waiting sinks
+	mutex    sync.Mutex
+	released chan struct{}
+	waiters  atomic.Int32
+}
+
+// MakeQuota returns the allocator accounting the memory of a single request
+func (b *MemoryBudget) MakeQuota() *MemoryQuota {
+	return &MemoryQuota{budget: b}
+}
+
+// Allocated returns the amount of memory allocated by all the requests
+func (b *MemoryBudget) Allocated() uint64 {
+	return uint64(max(b.allocated.Load(), 0))
+}
+
+// Exhausted checks if the requests have allocated all the memory available to the server
+func (b *MemoryBudget) Exhausted() bool {
+	return b.limit > 0 && b.Allocated() >= b.limit
+}
+
+func (b *MemoryBudget) add(delta int64) {
+	b.allocated.Add(delta)
+
+	if delta < 0 && b.waiters.Load() > 0 {
+		b.mutex.Lock()
+		close(b.released)
+		b.released = make(chan struct{})
+		b.mutex.Unlock()
+	}
+}
+
+func (b *MemoryBudget) releasedChan() <-chan struct{} {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.released
+}
+
+// RegisterMetrics exposes the memory usage
+func (b *MemoryBudget) RegisterMetrics(registry metrics.Registry) {
+	_ = registry.FuncGauge("memory_allocated_bytes", func() float64 {
+		return float64(b.Allocated())
+	})
+
+	_ = registry.FuncGauge("memory_limit_bytes", func() float64 {
+		return float64(b.limit)
+	})
+}
+
+func NewMemoryBudget(cfg *config.TMemoryConfig, allocator memory.Allocator) *MemoryBudget {
+	b := &MemoryBudget{
+		allocator: allocator,
+		limit:     cfg.GetLimitBytes(),
+		released:  make(chan struct{}),
+	}
+
+	if b.limit > 0 {
+		b.requestLimit = b.limit
+
+		if ratio := cfg.GetRequestQuotaRatio(); ratio > 0 {
+			b.requestLimit = uint64(float64(b.limit) * ratio)
+		}
+	}
+
+	return b
+}
+
+var _ memory.Allocator = (*MemoryQuota)(nil)
+
+// MemoryQuota is an Arrow allocator tracking the memory allocated by a single request.
+// The allocations never fail, but the sinks wait for the memory release
+// before accumulating new pages when the budget is exhausted.
+type MemoryQuota struct {
+	budget    *MemoryBudget
+	mutex     sync.Mutex
+	allocated int64
+	peak      int64
+	closed    bool // the allocations made after closing are not accounted anymore
+}
+
+func (q *MemoryQuota) Allocate(size int) []byte {
+	q.account(int64(size))
+
+	return q.budget.allocator.Allocate(size)
+}
+
+func (q *MemoryQuota) Reallocate(size int, b []byte) []byte {
+	q.account(int64(size - len(b)))
+
+	return q.budget.allocator.Reallocate(size, b)
+}
+
+func (q *MemoryQuota) Free(b []byte) {
+	q.account(-int64(len(b)))
+
+	q.budget.allocator.Free(b)
+}
+
+// Track accounts the memory allocated bypassing Arrow allocator (e. g. the copies of the pages kept for the read cache).
+// Negative delta means that the memory was released.
+func (q *MemoryQuota) Track(delta int64) {
+	q.account(delta)
+}
+
+func (q *MemoryQuota) account(delta int64) {
+	q.mutex.Lock()
+
+	if q.closed {
+		q.mutex.Unlock()
+
+		return
+	}
+
+	q.allocated += delta
+	q.peak = max(q.peak, q.allocated)
+	q.mutex.Unlock()
+
+	q.budget.add(delta)
+}
+
+// Allocated returns the amount of memory currently allocated by the request
+func (q *MemoryQuota) Allocated() uint64 {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return uint64(max(q.allocated, 0))
+}
+
+// Peak returns the maximum amount of memory allocated by the request
+func (q *MemoryQuota) Peak() uint64 {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return uint64(q.peak)
+}
+
+// exhausted checks if the request has allocated more memory than it is allowed to
+func (q *MemoryQuota) exhausted() bool {
+	if q.budget.limit == 0 {
+		return false
+	}
+
+	q.mutex.Lock()
+	allocated := q.allocated
+	q.mutex.Unlock()
+
+	return uint64(max(allocated, 0)) > q.budget.requestLimit || q.budget.Allocated() > q.budget.limit
+}
+
+// wait blocks until the memory allocated by the request and by the server fits the limits
+func (q *MemoryQuota) wait(ctx context.Context) error {
+	if !q.exhausted() {
+		return nil
+	}
+
+	q.budget.waiters.Add(1)
+	defer q.budget.waiters.Add(-1)
+
+	for {
+		// take the channel before the check not to miss the release
+		released := q.budget.releasedChan()
+
+		if !q.exhausted() {
+			return nil
+		}
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Close returns the memory that was not released explicitly to the budget,
+// so that the leaks won't exhaust it. Must be called when the request is finished.
+func (q *MemoryQuota) Close() {
+	q.mutex.Lock()
+	allocated := q.allocated
+	q.allocated = 0
+	q.closed = true
+	q.mutex.Unlock()
+
+	if allocated != 0 {
+		q.budget.add(-allocated)
+	}
+}
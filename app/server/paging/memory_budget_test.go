@@ -0,0 +1,145 @@
+package paging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+)
+
+func TestMemoryBudget(t *testing.T) {
+	t.Run("accounting", func(t *testing.T) {
+		budget := NewMemoryBudget(nil, memory.NewGoAllocator())
+
+		quota1 := budget.MakeQuota()
+		quota2 := budget.MakeQuota()
+
+		buf1 := quota1.Allocate(100)
+		buf2 := quota2.Allocate(50)
+		require.Equal(t, uint64(150), budget.Allocated())
+
+		buf1 = quota1.Reallocate(200, buf1)
+		require.Equal(t, uint64(250), budget.Allocated())
+
+		quota1.Free(buf1)
+		require.Equal(t, uint64(50), budget.Allocated())
+		require.Equal(t, uint64(200), quota1.Peak())
+
+		// no limits are set
+		require.NoError(t, quota2.wait(context.Background()))
+
+		// leaked memory is returned to the budget
+		quota2.Close()
+		require.Equal(t, uint64(0), budget.Allocated())
+
+		quota2.Free(buf2)
+		require.Equal(t, uint64(0), budget.Allocated())
+	})
+
+	t.Run("request_quota", func(t *testing.T) {
+		budget := NewMemoryBudget(
+			&config.TMemoryConfig{LimitBytes: 1000, RequestQuotaRatio: 0.5},
+			memory.NewGoAllocator(),
+		)
+
+		quota1 := budget.MakeQuota()
+		quota2 := budget.MakeQuota()
+
+		buf := quota1.Allocate(600)
+
+		// the other request is not affected
+		require.NoError(t, quota2.wait(context.Background()))
+
+		done := make(chan error)
+
+		go func() { done <- quota1.wait(context.Background()) }()
+
+		select {
+		case <-done:
+			require.FailNow(t, "wait must block until the memory is released")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		quota1.Free(buf)
+		require.NoError(t, <-done)
+	})
+
+	t.Run("server_limit", func(t *testing.T) {
+		budget := NewMemoryBudget(&config.TMemoryConfig{LimitBytes: 1000}, memory.NewGoAllocator())
+
+		quota1 := budget.MakeQuota()
+		quota2 := budget.MakeQuota()
+
+		quota1.Allocate(600)
+		require.False(t, budget.Exhausted())
+		quota2.Allocate(600)
+		require.True(t, budget.Exhausted())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		require.ErrorIs(t, quota2.wait(ctx), context.DeadlineExceeded)
+
+		// the memory of the finished request is released
+		quota1.Close()
+		require.NoError(t, quota2.wait(context.Background()))
+		require.False(t, budget.Exhausted())
+	})
+
+	t.Run("queued_pages", func(t *testing.T) {
+		schema := arrow.NewSchema([]arrow.Field{{Name: "col", Type: arrow.PrimitiveTypes.Int64}}, nil)
+
+		builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+		defer builder.Release()
+
+		builder.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2, 3}, nil)
+
+		record := builder.NewRecord()
+		defer record.Release()
+
+		budget := NewMemoryBudget(nil, memory.NewGoAllocator())
+		quota := budget.MakeQuota()
+
+		defer quota.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sink := &sinkImpl[any]{
+			resultQueue: make(chan *ReadResult[any], 1),
+			ipcWriter:   newArrowIPCWriter(memory.DefaultAllocator, nil, nil),
+			memoryQuota: quota,
+			logger:      zap.NewNop(),
+			ctx:         ctx,
+		}
+
+		// the serialized page is accounted while it is waiting in the queue
+		sink.respondWithArrowRecord(record, &api_service_protos.TReadSplitsResponse_TStats{}, nil, false)
+
+		result := <-sink.resultQueue
+		require.NotEmpty(t, result.Data)
+		require.Equal(t, uint64(len(result.Data)), quota.Allocated())
+		require.Equal(t, uint64(len(result.Data)), budget.Allocated())
+
+		// and released once it is sent
+		result.Release()
+		require.Equal(t, uint64(0), quota.Allocated())
+		require.Equal(t, uint64(0), budget.Allocated())
+
+		// the page is released if the request has been canceled before it was enqueued
+		sink.resultQueue = make(chan *ReadResult[any])
+
+		cancel()
+		sink.respondWithArrowRecord(record, &api_service_protos.TReadSplitsResponse_TStats{}, nil, false)
+		require.Equal(t, uint64(0), budget.Allocated())
+		require.NotZero(t, quota.Peak())
+	})
+}
@@ -1,7 +1,10 @@
 package paging
 
 import (
+	"time"
+
 	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/memory"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
 
@@ -62,6 +65,14 @@ func (m *SinkFactoryMock) FinalStats() *api_service_protos.TReadSplitsResponse_T
 	return m.Called().Get(0).(*api_service_protos.TReadSplitsResponse_TStats)
 }
 
+func (m *SinkFactoryMock) ReportPageSent(sendLatency time.Duration) {
+	m.Called(sendLatency)
+}
+
+func (m *SinkFactoryMock) Allocator() memory.Allocator {
+	return m.Called().Get(0).(memory.Allocator)
+}
+
 var _ ColumnarBuffer[any] = (*ColumnarBufferMock)(nil)
 
 type ColumnarBufferMock struct {
@@ -0,0 +1,126 @@
+package paging
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+const (
+	// the prefetch queue occupancy thresholds signaling that the client is slow or fast
+	queueOccupancyHigh = 0.75
+	queueOccupancyLow  = 0.25
+)
+
+// pageSizer determines the page size limits for a single ReadSplits request.
+// In adaptive mode the limits follow the speed of the page consumption by the client.
+// It's shared across multiple Sink instances, so it must be thread-safe.
+type pageSizer struct {
+	mutex        sync.RWMutex
+	rowsPerPage  uint64 // zero means no limit
+	bytesPerPage uint64 // zero means no limit
+
+	adaptive             *config.TAdaptivePagingConfig // nil if page size limits are static
+	sendLatencyThreshold time.Duration
+}
+
+// limits returns the current page size limits
+func (ps *pageSizer) limits() (rowsPerPage, bytesPerPage uint64) {
+	ps.mutex.RLock()
+	defer ps.mutex.RUnlock()
+
+	return ps.rowsPerPage, ps.bytesPerPage
+}
+
+// maxBytesPerPage returns the hard limit of a page size in bytes
+func (ps *pageSizer) maxBytesPerPage() uint64 {
+	_, bytesPerPage := ps.limits()
+
+	if ps.adaptive != nil && bytesPerPage != 0 {
+		return ps.adaptive.MaxBytesPerPage
+	}
+
+	return bytesPerPage
+}
+
+// adjust changes the page size limits according to the time spent on sending the last page to the client
+// and to the share of the prefetch queue filled with pages awaiting to be sent.
+func (ps *pageSizer) adjust(sendLatency time.Duration, queueOccupancy float64) {
+	if ps.adaptive == nil {
+		return
+	}
+
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+
+	switch {
+	case sendLatency > ps.sendLatencyThreshold || queueOccupancy >= queueOccupancyHigh:
+		// the client is slow, there is no need to accumulate large pages
+		ps.rowsPerPage = shrinkPageLimit(ps.rowsPerPage, ps.adaptive.MinRowsPerPage)
+		ps.bytesPerPage = shrinkPageLimit(ps.bytesPerPage, ps.adaptive.MinBytesPerPage)
+	case sendLatency <= ps.sendLatencyThreshold/2 && queueOccupancy <= queueOccupancyLow:
+		// the client is fast, larger pages reduce the per-message overhead
+		ps.rowsPerPage = growPageLimit(ps.rowsPerPage, ps.adaptive.MaxRowsPerPage)
+		ps.bytesPerPage = growPageLimit(ps.bytesPerPage, ps.adaptive.MaxBytesPerPage)
+	}
+}
+
+func shrinkPageLimit(value, lowerBound uint64) uint64 {
+	if value == 0 {
+		return 0
+	}
+
+	return max(value/2, lowerBound)
+}
+
+func growPageLimit(value, upperBound uint64) uint64 {
+	if value == 0 {
+		return 0
+	}
+
+	return min(value*2, upperBound)
+}
+
+func newPageSizer(cfg *config.TPagingConfig) *pageSizer {
+	ps := &pageSizer{
+		rowsPerPage:  cfg.RowsPerPage,
+		bytesPerPage: cfg.BytesPerPage,
+	}
+
+	// the config is validated during server startup
+	if cfg.Adaptive != nil {
+		ps.adaptive = cfg.Adaptive
+		ps.sendLatencyThreshold = common.MustDurationFromString(cfg.Adaptive.SendLatencyThreshold)
+	}
+
+	return ps
+}
+
+// MakePagingConfig applies the page size limits requested by the client to the server-wide paging config.
+// Adaptive page sizing is disabled for the requests with explicit limits.
+func MakePagingConfig(
+	cfg *config.TPagingConfig,
+	paging *api_service_protos.TReadSplitsRequest_TPaging,
+) *config.TPagingConfig {
+	if paging.GetRowsPerPage() == 0 && paging.GetBytesPerPage() == 0 {
+		return cfg
+	}
+
+	out := proto.Clone(cfg).(*config.TPagingConfig)
+	out.Adaptive = nil
+
+	if paging.RowsPerPage != 0 {
+		out.RowsPerPage = paging.RowsPerPage
+	}
+
+	if paging.BytesPerPage != 0 {
+		out.BytesPerPage = paging.BytesPerPage
+	}
+
+	return out
+}
@@ -0,0 +1,123 @@
+package paging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+)
+
+func TestPageSizer(t *testing.T) {
+	t.Run("static", func(t *testing.T) {
+		ps := newPageSizer(&config.TPagingConfig{RowsPerPage: 10, BytesPerPage: 100})
+
+		ps.adjust(time.Hour, 1)
+
+		rows, bytes := ps.limits()
+		require.Equal(t, uint64(10), rows)
+		require.Equal(t, uint64(100), bytes)
+		require.Equal(t, uint64(100), ps.maxBytesPerPage())
+	})
+
+	t.Run("adaptive", func(t *testing.T) {
+		ps := newPageSizer(&config.TPagingConfig{
+			BytesPerPage: 100,
+			Adaptive: &config.TAdaptivePagingConfig{
+				MinBytesPerPage:      30,
+				MaxBytesPerPage:      300,
+				SendLatencyThreshold: "100ms",
+			},
+		})
+
+		type step struct {
+			sendLatency    time.Duration
+			queueOccupancy float64
+			bytesPerPage   uint64
+		}
+
+		steps := []step{
+			{sendLatency: time.Millisecond, queueOccupancy: 0, bytesPerPage: 200},         // fast client
+			{sendLatency: time.Millisecond, queueOccupancy: 0, bytesPerPage: 300},         // upper bound
+			{sendLatency: 70 * time.Millisecond, queueOccupancy: 0, bytesPerPage: 300},    // moderate latency
+			{sendLatency: time.Millisecond, queueOccupancy: 0.5, bytesPerPage: 300},       // moderate occupancy
+			{sendLatency: time.Second, queueOccupancy: 0, bytesPerPage: 150},              // slow client
+			{sendLatency: time.Millisecond, queueOccupancy: 1, bytesPerPage: 75},          // full queue
+			{sendLatency: time.Second, queueOccupancy: 1, bytesPerPage: 37},               // slow client
+			{sendLatency: time.Second, queueOccupancy: 1, bytesPerPage: 30},               // lower bound
+			{sendLatency: time.Millisecond, queueOccupancy: 0.25, bytesPerPage: 60},       // recovery
+			{sendLatency: 50 * time.Millisecond, queueOccupancy: 0.25, bytesPerPage: 120}, // recovery
+		}
+
+		for i, s := range steps {
+			ps.adjust(s.sendLatency, s.queueOccupancy)
+
+			rows, bytes := ps.limits()
+			require.Zero(t, rows, "step %d", i) // unlimited dimension is not affected
+			require.Equal(t, s.bytesPerPage, bytes, "step %d", i)
+		}
+
+		require.Equal(t, uint64(300), ps.maxBytesPerPage())
+	})
+
+	t.Run("concurrent access", func(t *testing.T) {
+		ps := newPageSizer(&config.TPagingConfig{
+			BytesPerPage: 100,
+			Adaptive: &config.TAdaptivePagingConfig{
+				MinBytesPerPage:      30,
+				MaxBytesPerPage:      300,
+				SendLatencyThreshold: "100ms",
+			},
+		})
+
+		// sinks check the page size while the factory adjusts it (run with -race)
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+
+			for i := 0; i < 100; i++ {
+				ps.adjust(time.Duration(i%2)*time.Second, 0)
+			}
+		}()
+
+		for i := 0; i < 100; i++ {
+			require.Equal(t, uint64(300), ps.maxBytesPerPage())
+		}
+
+		<-done
+	})
+}
+
+func TestMakePagingConfig(t *testing.T) {
+	cfg := &config.TPagingConfig{
+		RowsPerPage:           10,
+		BytesPerPage:          100,
+		PrefetchQueueCapacity: 2,
+		Adaptive: &config.TAdaptivePagingConfig{
+			MinBytesPerPage:      30,
+			MaxBytesPerPage:      300,
+			SendLatencyThreshold: "100ms",
+		},
+	}
+
+	t.Run("no overrides", func(t *testing.T) {
+		require.Same(t, cfg, MakePagingConfig(cfg, nil))
+		require.Same(t, cfg, MakePagingConfig(cfg, &api_service_protos.TReadSplitsRequest_TPaging{}))
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		actual := MakePagingConfig(cfg, &api_service_protos.TReadSplitsRequest_TPaging{BytesPerPage: 1000})
+
+		require.Equal(t, uint64(10), actual.RowsPerPage)
+		require.Equal(t, uint64(1000), actual.BytesPerPage)
+		require.Equal(t, uint32(2), actual.PrefetchQueueCapacity)
+		require.Nil(t, actual.Adaptive)
+
+		// server config is not changed
+		require.Equal(t, uint64(100), cfg.BytesPerPage)
+		require.NotNil(t, cfg.Adaptive)
+	})
+}
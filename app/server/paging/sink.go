@@ -2,15 +2,14 @@
 package paging
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 
 	"github.com/apache/arrow/go/v13/arrow"
-	"github.com/apache/arrow/go/v13/arrow/ipc"
 	"go.uber.org/zap"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
@@ -26,15 +25,18 @@ var _ Sink[any] = (*sinkImpl[any])(nil)
 var _ Sink[string] = (*sinkImpl[string])(nil)
 
 type sinkImpl[T Acceptor] struct {
-	currBuffer     ColumnarBuffer[T]        // accumulates incoming rows
-	resultQueue    chan *ReadResult[T]      // outgoing buffer queue
-	terminateChan  chan<- Sink[T]           // notify factory when the data reading is finished via this channel
-	bufferFactory  ColumnarBufferFactory[T] // creates new buffer
-	trafficTracker *trafficTracker[T]       // tracks the amount of data passed through the sink
-	readLimiter    ReadLimiter              // helps to restrict the number of rows read in every request
-	logger         *zap.Logger              // annotated logger
-	state          sinkState                // flag showing if it's ready to return data
-	ctx            context.Context          // client context
+	currBuffer     ColumnarBuffer[T]         // accumulates incoming rows
+	resultQueue    chan *ReadResult[T]       // outgoing buffer queue
+	terminateChan  chan<- Sink[T]            // notify factory when the data reading is finished via this channel
+	bufferFactory  ColumnarBufferFactory[T]  // creates new buffer
+	ipcWriter      *ArrowIPCWriter           // serializes the Arrow records obtained directly from the data source
+	trafficTracker *trafficTracker[T]        // tracks the amount of data passed through the sink
+	readLimiter    ReadLimiter               // helps to restrict the number of rows read in every request
+	memoryQuota    *MemoryQuota              // blocks the producer when the memory budget is exhausted
+	residualFilter *filtering.ResidualFilter // filters data with the predicate that was not pushed down (optional)
+	logger         *zap.Logger               // annotated logger
+	state          sinkState                 // flag showing if it's ready to return data
+	ctx            context.Context           // client context
 }
 
 func (s *sinkImpl[T]) AddRow(rowTransformer RowTransformer[T]) error {
@@ -88,6 +90,16 @@ func (s *sinkImpl[T]) AddArrowRecord(record arrow.Record) error {
 		return nil
 	}
 
+	// The blocks obtained from the data source may not fit into a single page, so they are sent in parts
+	tooLarge, err := s.trafficTracker.exceedsPageSize(record)
+	if err != nil {
+		return fmt.Errorf("check arrow record size: %w", err)
+	}
+
+	if tooLarge && record.NumRows() > 1 {
+		return s.addArrowRecordInParts(record)
+	}
+
 	// Apply read limiter for each row in the record
 	rowCount := record.NumRows()
 	for i := int64(0); i < rowCount; i++ {
@@ -115,6 +127,22 @@ func (s *sinkImpl[T]) AddArrowRecord(record arrow.Record) error {
 		}
 	}
 
+	if s.residualFilter != nil {
+		filtered, err := s.residualFilter.Apply(s.ctx, record)
+		if err != nil {
+			return fmt.Errorf("apply residual filter: %w", err)
+		}
+
+		defer filtered.Release()
+
+		// nothing to send, the traffic will be accounted with the next record
+		if filtered.NumRows() == 0 {
+			return nil
+		}
+
+		record = filtered
+	}
+
 	// Get stats
 	stats := s.trafficTracker.DumpStats(false)
 
@@ -124,6 +152,28 @@ func (s *sinkImpl[T]) AddArrowRecord(record arrow.Record) error {
 	// Reset counters for the next record
 	s.trafficTracker.refreshCounters()
 
+	// Wait for the client to consume the data if the memory budget is exhausted
+	if err := s.memoryQuota.wait(s.ctx); err != nil {
+		return fmt.Errorf("wait for memory: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sinkImpl[T]) addArrowRecordInParts(record arrow.Record) error {
+	middle := record.NumRows() / 2
+
+	for _, bounds := range [][2]int64{{0, middle}, {middle, record.NumRows()}} {
+		part := record.NewSlice(bounds[0], bounds[1])
+		err := s.AddArrowRecord(part)
+
+		part.Release()
+
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -142,12 +192,19 @@ func (s *sinkImpl[T]) flush(makeNewBuffer bool, isTerminalMessage bool) error {
 	s.trafficTracker.refreshCounters()
 
 	if makeNewBuffer {
+		// Wait for the client to consume the data if the memory budget is exhausted
+		if err := s.memoryQuota.wait(s.ctx); err != nil {
+			return fmt.Errorf("wait for memory: %w", err)
+		}
+
 		var err error
 
 		s.currBuffer, err = s.bufferFactory.MakeBuffer()
 		if err != nil {
 			return fmt.Errorf("make buffer: %w", err)
 		}
+
+		setResidualFilter(s.currBuffer, s.residualFilter)
 	}
 
 	return nil
@@ -203,24 +260,18 @@ func (s *sinkImpl[T]) respondWithArrowRecord(
 	err error,
 	isTerminalMessage bool) {
 	// Create a response directly from the Arrow record
-	var buf bytes.Buffer
-
-	writer := ipc.NewWriter(&buf, ipc.WithSchema(record.Schema()))
-
-	if writeErr := writer.Write(record); writeErr != nil {
+	serializedData, uncompressedBytes, writeErr := s.ipcWriter.write(record)
+	if writeErr != nil {
 		s.respondWith(nil, stats, fmt.Errorf("write record: %w", writeErr), isTerminalMessage)
 
 		return
 	}
 
-	if closeErr := writer.Close(); closeErr != nil {
-		s.respondWith(nil, stats, fmt.Errorf("close arrow writer: %w", closeErr), isTerminalMessage)
-
-		return
-	}
+	stats.PayloadBytes = uint64(len(serializedData))
+	stats.UncompressedPayloadBytes = uncompressedBytes
 
-	// Get the serialized data from the buffer
-	serializedData := buf.Bytes()
+	// The serialized data is accounted until the result is sent to the client
+	s.memoryQuota.Track(int64(len(serializedData)))
 
 	// Create a result with the serialized data
 	result := &ReadResult[T]{
@@ -230,12 +281,14 @@ func (s *sinkImpl[T]) respondWithArrowRecord(
 		Error:             err,
 		IsTerminalMessage: isTerminalMessage,
 		Logger:            s.logger,
+		memoryQuota:       s.memoryQuota,
 	}
 
 	// Send the result to the queue
 	select {
 	case s.resultQueue <- result:
 	case <-s.ctx.Done():
+		result.Release()
 	}
 }
 
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/apache/arrow/go/v13/arrow/memory"
 	"go.uber.org/zap"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
@@ -28,7 +30,10 @@ type sinkFactoryImpl[T Acceptor] struct {
 	cfg           *config.TPagingConfig
 	resultQueue   chan *ReadResult[T]      // outgoing buffer queue
 	bufferFactory ColumnarBufferFactory[T] // factory responsible for ColumnarBuffer generation
+	ipcWriter     *ArrowIPCWriter          // serializes the Arrow records obtained directly from the data source
 	readLimiter   ReadLimiter              // helps to restrict the number of rows read in every request
+	memoryQuota   *MemoryQuota             // accounts the memory allocated by the request
+	pageSizer     *pageSizer               // determines the page size limits shared by all the sinks
 	state         sinkFactoryState
 	totalSinks    int
 
@@ -59,18 +64,23 @@ func (f *sinkFactoryImpl[T]) MakeSinks(params []*SinkParams) ([]Sink[T], error)
 			return nil, fmt.Errorf("make buffer: %w", err)
 		}
 
+		setResidualFilter(buffer, params[i].ResidualFilter)
+
 		// preserve traffic tracker to obtain stats in future
-		trafficTracker := newTrafficTracker[T](f.cfg)
+		trafficTracker := newTrafficTracker[T](f.pageSizer)
 
 		f.trafficTrackers = append(f.trafficTrackers, trafficTracker)
 
 		sink := &sinkImpl[T]{
 			bufferFactory:  f.bufferFactory,
+			ipcWriter:      f.ipcWriter,
 			readLimiter:    f.readLimiter,
+			memoryQuota:    f.memoryQuota,
 			resultQueue:    f.resultQueue, // result queue is shared across multiple Sink instances
 			terminateChan:  terminateChan,
 			trafficTracker: trafficTracker,
 			currBuffer:     buffer,
+			residualFilter: params[i].ResidualFilter,
 			logger:         params[i].Logger,
 			state:          sinkOperational,
 			ctx:            f.ctx,
@@ -106,6 +116,22 @@ func (f *sinkFactoryImpl[T]) FinalStats() *api_service_protos.TReadSplitsRespons
 	return overallStats
 }
 
+// ReportPageSent notifies factory about the page delivered to the client,
+// so that the page size could be adjusted to the speed of the client.
+func (f *sinkFactoryImpl[T]) ReportPageSent(sendLatency time.Duration) {
+	var queueOccupancy float64
+
+	if capacity := cap(f.resultQueue); capacity > 0 {
+		queueOccupancy = float64(len(f.resultQueue)) / float64(capacity)
+	}
+
+	f.pageSizer.adjust(sendLatency, queueOccupancy)
+}
+
+func (f *sinkFactoryImpl[T]) Allocator() memory.Allocator {
+	return f.memoryQuota
+}
+
 func (f *sinkFactoryImpl[T]) sinkTerminationHandler(terminateChan <-chan Sink[T]) {
 	terminatedSinks := 0
 
@@ -141,12 +167,17 @@ func NewSinkFactory[T Acceptor](
 	logger *zap.Logger,
 	cfg *config.TPagingConfig,
 	columnarBufferFactory ColumnarBufferFactory[T],
+	ipcWriter *ArrowIPCWriter,
 	readLimiter ReadLimiter,
+	memoryQuota *MemoryQuota,
 ) SinkFactory[T] {
 	sf := &sinkFactoryImpl[T]{
 		state:         sinkFactoryIdle,
 		bufferFactory: columnarBufferFactory,
+		ipcWriter:     ipcWriter,
 		readLimiter:   readLimiter,
+		memoryQuota:   memoryQuota,
+		pageSizer:     newPageSizer(cfg),
 		resultQueue:   make(chan *ReadResult[T], cfg.PrefetchQueueCapacity),
 		cfg:           cfg,
 		ctx:           ctx,
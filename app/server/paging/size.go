@@ -10,6 +10,8 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	bson_primitive "go.mongodb.org/mongo-driver/bson/primitive"
 
+	ydb_types "github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
@@ -179,6 +181,12 @@ func sizeOfValueBloated(v any) (uint64, acceptorKind, error) {
 		}
 
 		return uint64(len(**t)), variableSize, nil
+	case *ydb_types.Value:
+		if t == nil || *t == nil {
+			return 0, variableSize, nil
+		}
+
+		return sizeOfYdbValue(*t), variableSize, nil
 	case *bson_primitive.Binary:
 		if t == nil {
 			return 0, variableSize, nil
@@ -197,11 +205,24 @@ func sizeOfValueBloated(v any) (uint64, acceptorKind, error) {
 		}
 
 		switch tt := (*t).(type) {
+		case nil:
+			return 0, variableSize, nil
 		case bson_primitive.ObjectID:
 			return 12, fixedSize, nil
 		case bson_primitive.Binary:
 			return 0, variableSize, nil
-
+		case uuid.UUID:
+			return 16, fixedSize, nil
+		case time.Duration:
+			return 8, fixedSize, nil
+		case string:
+			return uint64(len(tt)), variableSize, nil
+		case []byte:
+			return uint64(len(tt)), variableSize, nil
+		case ydb_types.Value:
+			return sizeOfYdbValue(tt), variableSize, nil
+		case []any, map[string]any, bool, float64:
+			return sizeOfJSONValue(tt), variableSize, nil
 		default:
 			return 0, 0, fmt.Errorf("value %v of unexpected data type %T: %w", tt, tt, common.ErrDataTypeNotSupported)
 		}
@@ -330,3 +351,79 @@ func sizeOfValueBloated(v any) (uint64, acceptorKind, error) {
 		return 0, 0, fmt.Errorf("value %v of unexpected data type %T: %w", t, t, common.ErrDataTypeNotSupported)
 	}
 }
+
+// sizeOfJSONValue roughly estimates the size of the value decoded from JSON document
+func sizeOfJSONValue(v any) uint64 {
+	switch t := v.(type) {
+	case nil:
+		return 0
+	case bool:
+		return 1
+	case string:
+		return uint64(len(t))
+	case []any:
+		var size uint64
+
+		for _, item := range t {
+			size += sizeOfJSONValue(item)
+		}
+
+		return size
+	case map[string]any:
+		var size uint64
+
+		for k, item := range t {
+			size += uint64(len(k)) + sizeOfJSONValue(item)
+		}
+
+		return size
+	default:
+		// numbers are decoded into float64
+		return 8
+	}
+}
+
+// sizeOfYdbValue roughly estimates the size of the value obtained from YDB SDK.
+// Containers are traversed recursively, primitive values are estimated by their binary representation.
+func sizeOfYdbValue(v ydb_types.Value) uint64 {
+	if ydb_types.IsNull(v) {
+		return 0
+	}
+
+	v = ydb_types.Unwrap(v)
+
+	var size uint64
+
+	switch vv := v.(type) {
+	case interface{ ListItems() []ydb_types.Value }:
+		for _, item := range vv.ListItems() {
+			size += sizeOfYdbValue(item)
+		}
+	case interface{ TupleItems() []ydb_types.Value }:
+		for _, item := range vv.TupleItems() {
+			size += sizeOfYdbValue(item)
+		}
+	case interface {
+		StructFields() map[string]ydb_types.Value
+	}:
+		for _, field := range vv.StructFields() {
+			size += sizeOfYdbValue(field)
+		}
+	case interface {
+		DictValues() map[ydb_types.Value]ydb_types.Value
+	}:
+		for key, payload := range vv.DictValues() {
+			size += sizeOfYdbValue(key) + sizeOfYdbValue(payload)
+		}
+	default:
+		var buf []byte
+		if err := ydb_types.CastTo(v, &buf); err == nil {
+			return uint64(len(buf))
+		}
+
+		// fixed size values (numbers, dates, decimals, uuids) take no more than 16 bytes
+		return 16
+	}
+
+	return size
+}
@@ -104,6 +104,29 @@ func estimateArrowArraySize(arr arrow.Array) (uint64, error) {
 				size += uint64(len(arr.Value(i)))
 			}
 		}
+	case *array.FixedSizeBinary:
+		// Decimals and UUIDs are represented with fixed size binary arrays
+		size += uint64(arr.Len() * arr.DataType().(*arrow.FixedSizeBinaryType).ByteWidth)
+	case *array.LargeString:
+		size += uint64((arr.Len() + 1) * 8) // Offsets are int64
+
+		for i := 0; i < arr.Len(); i++ {
+			if arr.IsValid(i) {
+				size += uint64(len(arr.Value(i)))
+			}
+		}
+	case *array.LargeBinary:
+		size += uint64((arr.Len() + 1) * 8) // Offsets are int64
+
+		for i := 0; i < arr.Len(); i++ {
+			if arr.IsValid(i) {
+				size += uint64(len(arr.Value(i)))
+			}
+		}
+	case *array.Duration:
+		size += uint64(nonNullCount * 8) // 8 bytes per interval
+	case *array.Null:
+		// no data buffers at all
 	case *array.Timestamp:
 		size += uint64(nonNullCount * 8) // 8 bytes per timestamp
 	case *array.Date32:
@@ -6,13 +6,12 @@ import (
 	"github.com/apache/arrow/go/v13/arrow"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
-	"github.com/ydb-platform/fq-connector-go/app/config"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
 type trafficTracker[T Acceptor] struct {
-	pagination  *config.TPagingConfig
+	pageSizer   *pageSizer
 	sizePattern *sizePattern[T]
 
 	// cumulative sums of bytes passed and rows handled since the start of the request
@@ -89,6 +88,21 @@ func (tt *trafficTracker[T]) tryAddArrowRecord(record arrow.Record) (bool, error
 	return true, nil
 }
 
+// exceedsPageSize checks if the Arrow record is too large to fit into a single page.
+func (tt *trafficTracker[T]) exceedsPageSize(record arrow.Record) (bool, error) {
+	maxBytesPerPage := tt.pageSizer.maxBytesPerPage()
+	if maxBytesPerPage == 0 {
+		return false, nil
+	}
+
+	totalBytes, err := estimateArrowRecordSize(record)
+	if err != nil {
+		return false, fmt.Errorf("estimate arrow record size: %w", err)
+	}
+
+	return totalBytes > maxBytesPerPage, nil
+}
+
 func (tt *trafficTracker[T]) maybeInit(acceptors []T) error {
 	if tt.sizePattern == nil {
 		// lazy initialization when the first row is ready
@@ -104,25 +118,33 @@ func (tt *trafficTracker[T]) maybeInit(acceptors []T) error {
 }
 
 func (tt *trafficTracker[T]) checkPageSizeLimit(bytesDelta, rowsDelta uint64) (bool, error) {
-	if tt.pagination.BytesPerPage != 0 {
+	rowsPerPage, bytesPerPage := tt.pageSizer.limits()
+
+	if bytesPerPage != 0 {
+		maxBytesPerPage := tt.pageSizer.maxBytesPerPage()
+
 		// almost impossible case, but have to check
-		if bytesDelta > tt.pagination.BytesPerPage {
+		if bytesDelta > maxBytesPerPage {
 			err := fmt.Errorf(
 				"single row size exceeds page size limit (%d > %d bytes): %w",
 				bytesDelta,
-				tt.pagination.BytesPerPage,
+				maxBytesPerPage,
 				common.ErrPageSizeExceeded)
 
 			return true, err
 		}
 
-		if tt.bytesCurr.Value()+bytesDelta > tt.pagination.BytesPerPage {
+		// the adaptive limit may be less than the size of a single row,
+		// but the page must contain at least one row anyway
+		pageIsEmpty := tt.bytesCurr.Value() == 0 && tt.rowsCurr.Value() == 0
+
+		if !pageIsEmpty && tt.bytesCurr.Value()+bytesDelta > bytesPerPage {
 			return true, nil
 		}
 	}
 
-	if tt.pagination.RowsPerPage != 0 {
-		if tt.rowsCurr.Value()+rowsDelta > tt.pagination.RowsPerPage {
+	if rowsPerPage != 0 {
+		if tt.rowsCurr.Value()+rowsDelta > rowsPerPage {
 			return true, nil
 		}
 	}
@@ -152,9 +174,9 @@ func (tt *trafficTracker[T]) DumpStats(total bool) *api_service_protos.TReadSpli
 	return result
 }
 
-func newTrafficTracker[T Acceptor](pagination *config.TPagingConfig) *trafficTracker[T] {
+func newTrafficTracker[T Acceptor](pageSizer *pageSizer) *trafficTracker[T] {
 	tt := &trafficTracker[T]{
-		pagination: pagination,
+		pageSizer:  pageSizer,
 		bytesTotal: utils.NewCounter[uint64](),
 		rowsTotal:  utils.NewCounter[uint64](),
 	}
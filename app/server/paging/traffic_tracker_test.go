@@ -5,6 +5,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
 	"github.com/stretchr/testify/require"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
@@ -18,7 +21,7 @@ func TestTrafficTracker(t *testing.T) {
 			RowsPerPage: 2,
 		}
 
-		tt := newTrafficTracker[any](cfg)
+		tt := newTrafficTracker[any](newPageSizer(cfg))
 
 		col1Acceptor := new(int32)
 		col2Acceptor := new(string)
@@ -88,7 +91,7 @@ func TestTrafficTracker(t *testing.T) {
 			BytesPerPage: 40,
 		}
 
-		tt := newTrafficTracker[any](cfg)
+		tt := newTrafficTracker[any](newPageSizer(cfg))
 
 		col1Acceptor := new(uint64)
 		col2Acceptor := new([]byte)
@@ -128,7 +131,7 @@ func TestTrafficTracker(t *testing.T) {
 			BytesPerPage: 1,
 		}
 
-		tt := newTrafficTracker[any](cfg)
+		tt := newTrafficTracker[any](newPageSizer(cfg))
 		col1Acceptor := new(int32)
 		acceptors := []any{col1Acceptor}
 
@@ -138,4 +141,67 @@ func TestTrafficTracker(t *testing.T) {
 		require.True(t, errors.Is(err, common.ErrPageSizeExceeded))
 		require.False(t, ok)
 	})
+
+	t.Run("adaptive page smaller than row", func(t *testing.T) {
+		cfg := &config.TPagingConfig{
+			BytesPerPage: 4,
+			Adaptive: &config.TAdaptivePagingConfig{
+				MinBytesPerPage:      1,
+				MaxBytesPerPage:      8,
+				SendLatencyThreshold: "1s",
+			},
+		}
+
+		ps := newPageSizer(cfg)
+		ps.adjust(time.Hour, 1) // shrink page to 2 bytes
+
+		tt := newTrafficTracker[any](ps)
+		col1Acceptor := new(int32)
+		acceptors := []any{col1Acceptor}
+
+		*col1Acceptor = 1 // 4 bytes > 2 bytes, but less than upper bound
+
+		// the first row fits the empty page anyway
+		ok, err := tt.tryAddRow(acceptors)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = tt.tryAddRow(acceptors)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("arrow record larger than page", func(t *testing.T) {
+		cfg := &config.TPagingConfig{
+			BytesPerPage: 500,
+		}
+
+		tt := newTrafficTracker[any](newPageSizer(cfg))
+
+		builder := array.NewInt64Builder(memory.DefaultAllocator)
+		defer builder.Release()
+
+		for i := int64(0); i < 100; i++ {
+			builder.Append(i)
+		}
+
+		column := builder.NewArray()
+		defer column.Release()
+
+		schema := arrow.NewSchema([]arrow.Field{{Name: "col", Type: arrow.PrimitiveTypes.Int64}}, nil)
+		record := array.NewRecord(schema, []arrow.Array{column}, 100)
+
+		defer record.Release()
+
+		tooLarge, err := tt.exceedsPageSize(record) // 800 bytes of data > 500 bytes
+		require.NoError(t, err)
+		require.True(t, tooLarge)
+
+		part := record.NewSlice(0, 40)
+		defer part.Release()
+
+		tooLarge, err = tt.exceedsPageSize(part) // 320 bytes of data < 500 bytes
+		require.NoError(t, err)
+		require.False(t, tooLarge)
+	})
 }
@@ -0,0 +1,284 @@
+package read_cache
+
+import (
+	"bufio"
+	"container/list"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// diskFileExtension marks the files created by the cache, so that only these files are removed on startup
+const diskFileExtension = ".readcache"
+
+// diskSpillQueueSize limits the number of the evicted entries waiting to be written on disk.
+// The entries exceeding it are dropped, so that the memory cache is never blocked by the disk.
+const diskSpillQueueSize = 64
+
+type diskFile struct {
+	key        string
+	path       string
+	size       int64
+	expiration time.Time
+	element    *list.Element
+}
+
+// diskStorage keeps the cached results in files (one file per key).
+// When the size limit is exceeded, the oldest files are removed.
+type diskStorage struct {
+	mutex     sync.Mutex
+	directory string
+	maxSize   int64
+	size      int64
+	files     map[string]*diskFile
+	order     *list.List // keys from the oldest to the newest
+}
+
+func (d *diskStorage) put(e *entry) error {
+	if e.size > d.maxSize {
+		return nil
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	path := filepath.Join(d.directory, e.key+diskFileExtension)
+
+	size, err := writePages(path, e.pages)
+	if err != nil {
+		return fmt.Errorf("write pages: %w", err)
+	}
+
+	if file, exists := d.files[e.key]; exists {
+		// file was overwritten
+		d.order.Remove(file.element)
+		d.size -= file.size
+
+		delete(d.files, e.key)
+	}
+
+	file := &diskFile{
+		key:        e.key,
+		path:       path,
+		size:       size,
+		expiration: e.expiration,
+	}
+
+	file.element = d.order.PushBack(file)
+	d.files[e.key] = file
+	d.size += size
+
+	for d.size > d.maxSize {
+		oldest := d.order.Front().Value.(*diskFile)
+		if err := d.removeLocked(oldest); err != nil {
+			return fmt.Errorf("remove file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (d *diskStorage) get(key string) ([][]byte, bool, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	file, exists := d.files[key]
+	if !exists {
+		return nil, false, nil
+	}
+
+	if !time.Now().Before(file.expiration) {
+		if err := d.removeLocked(file); err != nil {
+			return nil, false, fmt.Errorf("remove expired file: %w", err)
+		}
+
+		return nil, false, nil
+	}
+
+	pages, err := readPages(file.path)
+	if err != nil {
+		// broken file is useless
+		if removeErr := d.removeLocked(file); removeErr != nil {
+			err = errors.Join(err, removeErr)
+		}
+
+		return nil, false, fmt.Errorf("read pages: %w", err)
+	}
+
+	return pages, true, nil
+}
+
+func (d *diskStorage) removeLocked(file *diskFile) error {
+	d.order.Remove(file.element)
+	d.size -= file.size
+
+	delete(d.files, file.key)
+
+	if err := os.Remove(file.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("os remove: %w", err)
+	}
+
+	return nil
+}
+
+func (d *diskStorage) stats() (size uint64, fileCount uint64) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return uint64(d.size), uint64(len(d.files))
+}
+
+// writePages stores pages as a sequence of length-prefixed blobs
+func writePages(path string, pages [][]byte) (int64, error) {
+	// cached results may contain sensitive data, so they are available to the server user only
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return 0, fmt.Errorf("os open file: %w", err)
+	}
+
+	w := bufio.NewWriter(f)
+
+	var size int64
+
+	for _, page := range pages {
+		if err = binary.Write(w, binary.BigEndian, uint32(len(page))); err != nil {
+			break
+		}
+
+		if _, err = w.Write(page); err != nil {
+			break
+		}
+
+		size += 4 + int64(len(page))
+	}
+
+	if err == nil {
+		err = w.Flush()
+	}
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return 0, errors.Join(fmt.Errorf("write file: %w", err), os.Remove(path))
+	}
+
+	return size, nil
+}
+
+func readPages(path string) ([][]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("os open: %w", err)
+	}
+
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+
+	var pages [][]byte
+
+	for {
+		var length uint32
+
+		if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+			if errors.Is(err, io.EOF) {
+				return pages, nil
+			}
+
+			return nil, fmt.Errorf("read page length: %w", err)
+		}
+
+		page := make([]byte, length)
+		if _, err := io.ReadFull(r, page); err != nil {
+			return nil, fmt.Errorf("read page: %w", err)
+		}
+
+		pages = append(pages, page)
+	}
+}
+
+func newDiskStorage(directory string, maxSize int64) (*diskStorage, error) {
+	if err := os.MkdirAll(directory, 0o700); err != nil {
+		return nil, fmt.Errorf("make directory: %w", err)
+	}
+
+	// files left by the previous server run are not tracked anymore
+	staleFiles, err := filepath.Glob(filepath.Join(directory, "*"+diskFileExtension))
+	if err != nil {
+		return nil, fmt.Errorf("glob stale files: %w", err)
+	}
+
+	for _, path := range staleFiles {
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("remove stale file: %w", err)
+		}
+	}
+
+	return &diskStorage{
+		directory: directory,
+		maxSize:   maxSize,
+		files:     make(map[string]*diskFile),
+		order:     list.New(),
+	}, nil
+}
+
+// diskWriter spills the entries evicted from the memory cache in the background,
+// since Ristretto invokes the eviction callbacks synchronously within the cache operations.
+type diskWriter struct {
+	storage *diskStorage
+	queue   chan *entry
+	done    chan struct{}
+	logger  *zap.Logger
+}
+
+// enqueue never blocks: the entry is dropped if the writer doesn't keep up with the evictions
+func (w *diskWriter) enqueue(e *entry) {
+	select {
+	case w.queue <- e:
+	default:
+		w.logger.Warn("disk spill queue is full, cached result is dropped", zap.Int64("size", e.size))
+	}
+}
+
+func (w *diskWriter) run() {
+	defer close(w.done)
+
+	for e := range w.queue {
+		// the entry may have expired while waiting in the queue
+		if !time.Now().Before(e.expiration) {
+			continue
+		}
+
+		if err := w.storage.put(e); err != nil {
+			w.logger.Error("spill cached result to disk", zap.Error(err))
+		}
+	}
+}
+
+// close waits for the queued entries to be written
+func (w *diskWriter) close() {
+	close(w.queue)
+	<-w.done
+}
+
+func newDiskWriter(logger *zap.Logger, storage *diskStorage) *diskWriter {
+	w := &diskWriter{
+		storage: storage,
+		queue:   make(chan *entry, diskSpillQueueSize),
+		done:    make(chan struct{}),
+		logger:  logger,
+	}
+
+	go w.run()
+
+	return w
+}
@@ -0,0 +1,5 @@
+// Package read_cache contains the cache of `ReadSplits` results.
+// Dashboards often run the same queries again and again, and it's cheaper
+// to serve the repeated reads of the same split from the connector memory (or disk)
+// than to extract the same rows from the data source every time.
+package read_cache
@@ -0,0 +1,15 @@
+package read_cache
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+)
+
+func NewCache(logger *zap.Logger, cfg *config.TReadCacheConfig) (Cache, error) {
+	if cfg == nil {
+		return &noopCache{}, nil
+	}
+
+	return newRistrettoCache(logger, cfg)
+}
@@ -0,0 +1,31 @@
+package read_cache
+
+import (
+	"go.uber.org/zap"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+)
+
+// Metrics represents cache statistics
+type Metrics struct {
+	Hits          uint64
+	Misses        uint64
+	KeysAdded     uint64
+	KeysEvicted   uint64
+	MemorySize    uint64
+	DiskSize      uint64
+	DiskFileCount uint64
+}
+
+// Cache keeps the pages of `ReadSplits` responses obtained during the previous reads of the same split.
+type Cache interface {
+	// Enabled checks if the results of the data source of a given kind are cached
+	Enabled(kind api_common.EGenericDataSourceKind) bool
+	// Put saves the serialized responses of the split reading
+	Put(logger *zap.Logger, key string, pages [][]byte) bool
+	// Get returns the serialized responses of the split reading
+	Get(logger *zap.Logger, key string) ([][]byte, bool)
+	Metrics() *Metrics
+	// Close releases the cache resources and waits for the background disk writes
+	Close()
+}
@@ -0,0 +1,83 @@
+package read_cache
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// credentialsHMACKey protects the credentials digest kept in the cache keys (and in the names of the spilled files).
+// The cache doesn't outlive the process, so the secret is generated on startup.
+var credentialsHMACKey = func() []byte {
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Errorf("generate credentials HMAC key: %w", err))
+	}
+
+	return key
+}()
+
+// MakeKey builds the cache key from the split and the request settings affecting the response format.
+// The clients with different credentials may have access to different data, so the key includes
+// the HMAC of the credentials. The refreshed credentials (e. g. IAM tokens) just cause cache misses.
+func MakeKey(request *api_service_protos.TReadSplitsRequest, split *api_service_protos.TSplit) (string, error) {
+	normalizedSplit := proto.Clone(split).(*api_service_protos.TSplit)
+	// sequential ID depends only on the split position within the request
+	normalizedSplit.Id = 0
+
+	credentialsMAC := hmac.New(sha256.New, credentialsHMACKey)
+
+	if dsi := normalizedSplit.GetSelect().GetDataSourceInstance(); dsi != nil {
+		credentials, err := proto.MarshalOptions{Deterministic: true}.Marshal(dsi.Credentials)
+		if err != nil {
+			return "", fmt.Errorf("marshal credentials: %w", err)
+		}
+
+		credentialsMAC.Write(credentials)
+
+		// the credentials must not get into the key in the clear
+		dsi.Credentials = nil
+	}
+
+	normalizedRequest := &api_service_protos.TReadSplitsRequest{
+		Splits:             []*api_service_protos.TSplit{normalizedSplit},
+		Format:             request.Format,
+		Compression:        request.Compression,
+		Filtering:          request.Filtering,
+		DictionaryEncoding: request.DictionaryEncoding,
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(normalizedRequest)
+	if err != nil {
+		return "", fmt.Errorf("marshal normalized request: %w", err)
+	}
+
+	hash := sha256.New()
+
+	hash.Write(data)
+	hash.Write(credentialsMAC.Sum(nil))
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// IsBypassed checks if the client asked to read the data source directly.
+// The fresh result replaces the cached one anyway.
+func IsBypassed(ctx context.Context) bool {
+	md, exists := metadata.FromIncomingContext(ctx)
+	if !exists {
+		return false
+	}
+
+	_, flagSet := md[common.BypassReadCache]
+
+	return flagSet
+}
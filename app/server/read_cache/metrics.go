@@ -0,0 +1,41 @@
+package read_cache
+
+import (
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/solomon"
+)
+
+// RegisterMetrics registers cache metrics with the provided registry.
+// It uses the read_cache_ prefix for all metrics.
+func RegisterMetrics(registry metrics.Registry, cache Cache) {
+	if cache.Metrics() == nil {
+		// noop cache returns nil metrics
+		return
+	}
+
+	metric := func(f func(m *Metrics) uint64) func() int64 {
+		return func() int64 {
+			m := cache.Metrics()
+			if m == nil {
+				return 0
+			}
+
+			return int64(f(m))
+		}
+	}
+
+	cacheHits := registry.FuncCounter("read_cache_hits_total", metric(func(m *Metrics) uint64 { return m.Hits }))
+	cacheMisses := registry.FuncCounter("read_cache_misses_total", metric(func(m *Metrics) uint64 { return m.Misses }))
+	cacheKeysAdded := registry.FuncCounter("read_cache_keys_added_total", metric(func(m *Metrics) uint64 { return m.KeysAdded }))
+	cacheKeysEvicted := registry.FuncCounter("read_cache_keys_evicted_total", metric(func(m *Metrics) uint64 { return m.KeysEvicted }))
+
+	_ = registry.FuncIntGauge("read_cache_memory_size", metric(func(m *Metrics) uint64 { return m.MemorySize }))
+	_ = registry.FuncIntGauge("read_cache_disk_size", metric(func(m *Metrics) uint64 { return m.DiskSize }))
+	_ = registry.FuncIntGauge("read_cache_disk_files", metric(func(m *Metrics) uint64 { return m.DiskFileCount }))
+
+	// Mark counters as rated for proper visualization
+	solomon.Rated(cacheHits)
+	solomon.Rated(cacheMisses)
+	solomon.Rated(cacheKeysAdded)
+	solomon.Rated(cacheKeysEvicted)
+}
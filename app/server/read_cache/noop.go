@@ -0,0 +1,30 @@
+package read_cache
+
+import (
+	"go.uber.org/zap"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+)
+
+var _ Cache = (*noopCache)(nil)
+
+type noopCache struct {
+}
+
+func (noopCache) Enabled(_ api_common.EGenericDataSourceKind) bool {
+	return false
+}
+
+func (noopCache) Put(_ *zap.Logger, _ string, _ [][]byte) bool {
+	return true
+}
+
+func (noopCache) Get(_ *zap.Logger, _ string) ([][]byte, bool) {
+	return nil, false
+}
+
+func (noopCache) Metrics() *Metrics {
+	return nil
+}
+
+func (noopCache) Close() {}
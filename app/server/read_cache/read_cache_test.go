@@ -0,0 +1,260 @@
+package read_cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+)
+
+func makeSplit(id uint64, password string) *api_service_protos.TSplit {
+	return &api_service_protos.TSplit{
+		Id: id,
+		Select: &api_service_protos.TSelect{
+			DataSourceInstance: &api_common.TGenericDataSourceInstance{
+				Kind:     api_common.EGenericDataSourceKind_POSTGRESQL,
+				Database: "db",
+				Credentials: &api_common.TGenericCredentials{
+					Payload: &api_common.TGenericCredentials_Basic{
+						Basic: &api_common.TGenericCredentials_TBasic{Username: "user", Password: password},
+					},
+				},
+			},
+			From: &api_service_protos.TSelect_TFrom{Table: "tab"},
+		},
+	}
+}
+
+func TestMakeKey(t *testing.T) {
+	request := &api_service_protos.TReadSplitsRequest{Format: api_service_protos.TReadSplitsRequest_ARROW_IPC_STREAMING}
+
+	key1, err := MakeKey(request, makeSplit(1, "secret1"))
+	require.NoError(t, err)
+
+	// split ID doesn't affect the key
+	key2, err := MakeKey(request, makeSplit(2, "secret1"))
+	require.NoError(t, err)
+	require.Equal(t, key1, key2)
+
+	// but credentials do
+	keyOtherCredentials, err := MakeKey(request, makeSplit(1, "secret2"))
+	require.NoError(t, err)
+	require.NotEqual(t, key1, keyOtherCredentials)
+
+	// but the response format does
+	compressedRequest := &api_service_protos.TReadSplitsRequest{
+		Format:      api_service_protos.TReadSplitsRequest_ARROW_IPC_STREAMING,
+		Compression: &api_service_protos.TReadSplitsRequest_TCompression{Codec: api_service_protos.TReadSplitsRequest_TCompression_ZSTD},
+	}
+
+	key3, err := MakeKey(compressedRequest, makeSplit(1, "secret1"))
+	require.NoError(t, err)
+	require.NotEqual(t, key1, key3)
+
+	// the original split stays untouched
+	split := makeSplit(1, "secret1")
+	_, err = MakeKey(request, split)
+	require.NoError(t, err)
+	require.Equal(t, "secret1", split.Select.DataSourceInstance.Credentials.GetBasic().Password)
+}
+
+func TestRistrettoCache(t *testing.T) {
+	logger := zap.NewNop()
+
+	cfg := &config.TReadCacheConfig{
+		DataSourceKinds: []api_common.EGenericDataSourceKind{api_common.EGenericDataSourceKind_MYSQL},
+		Ttl:             "1m",
+		Ristretto:       &config.TReadCacheConfig_TRistretto{MaxKeys: 100, MaxSizeBytes: 1024},
+	}
+
+	t.Run("memory", func(t *testing.T) {
+		cache, err := newRistrettoCache(logger, cfg)
+		require.NoError(t, err)
+
+		require.True(t, cache.Enabled(api_common.EGenericDataSourceKind_MYSQL))
+		require.False(t, cache.Enabled(api_common.EGenericDataSourceKind_POSTGRESQL))
+
+		_, found := cache.Get(logger, "key")
+		require.False(t, found)
+
+		pages := [][]byte{[]byte("page1"), []byte("page2")}
+		require.True(t, cache.Put(logger, "key", pages))
+		cache.cache.Wait()
+
+		actual, found := cache.Get(logger, "key")
+		require.True(t, found)
+		require.Equal(t, pages, actual)
+
+		metrics := cache.Metrics()
+		require.Equal(t, uint64(1), metrics.Hits)
+		require.Equal(t, uint64(1), metrics.Misses)
+	})
+
+	t.Run("disk_spill", func(t *testing.T) {
+		diskCfg := &config.TReadCacheConfig{
+			DataSourceKinds: cfg.DataSourceKinds,
+			Ttl:             cfg.Ttl,
+			Ristretto:       cfg.Ristretto,
+			DiskSpill:       &config.TReadCacheConfig_TDiskSpill{Directory: t.TempDir(), MaxSizeBytes: 1 << 20},
+		}
+
+		cache, err := newRistrettoCache(logger, diskCfg)
+		require.NoError(t, err)
+
+		defer cache.Close()
+
+		// the result doesn't fit into memory, so it's written on disk in the background
+		pages := [][]byte{make([]byte, 2048)}
+		cache.Put(logger, "key", pages)
+		cache.cache.Wait()
+
+		require.Eventually(t, func() bool {
+			return cache.Metrics().DiskFileCount == 1
+		}, time.Second, 10*time.Millisecond)
+
+		actual, found := cache.Get(logger, "key")
+		require.True(t, found)
+		require.Equal(t, pages, actual)
+		require.Equal(t, uint64(1), cache.Metrics().DiskFileCount)
+	})
+}
+
+func TestDiskStorage(t *testing.T) {
+	directory := filepath.Join(t.TempDir(), "spill")
+
+	disk, err := newDiskStorage(directory, 25)
+	require.NoError(t, err)
+
+	expiration := time.Now().Add(time.Minute)
+
+	require.NoError(t, disk.put(&entry{key: "a", pages: [][]byte{[]byte("12345")}, size: 5, expiration: expiration}))
+
+	// cached results are available to the server user only
+	for path, mode := range map[string]os.FileMode{
+		directory: 0o700,
+		filepath.Join(directory, "a"+diskFileExtension): 0o600,
+	} {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		require.Equal(t, mode, info.Mode().Perm(), path)
+	}
+
+	require.NoError(t, disk.put(&entry{key: "b", pages: [][]byte{[]byte("1"), []byte("23")}, size: 3, expiration: expiration}))
+
+	pages, found, err := disk.get("b")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, [][]byte{[]byte("1"), []byte("23")}, pages)
+
+	// the oldest file is removed when the size limit is exceeded
+	require.NoError(t, disk.put(&entry{key: "c", pages: [][]byte{[]byte("1234567")}, size: 7, expiration: expiration}))
+
+	_, found, err = disk.get("a")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	size, count := disk.stats()
+	require.Equal(t, uint64(4+1+4+2+4+7), size)
+	require.Equal(t, uint64(2), count)
+
+	// expired files are not returned
+	require.NoError(t, disk.put(&entry{key: "d", pages: [][]byte{[]byte("1")}, size: 1, expiration: time.Now()}))
+
+	_, found, err = disk.get("d")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// the files of the previous run are removed on startup
+	require.FileExists(t, filepath.Join(directory, "c"+diskFileExtension))
+
+	_, err = newDiskStorage(directory, 25)
+	require.NoError(t, err)
+	require.NoFileExists(t, filepath.Join(directory, "c"+diskFileExtension))
+}
+
+type readSplitsStreamMock struct {
+	grpc.ServerStream
+	responses []*api_service_protos.TReadSplitsResponse
+}
+
+func (s *readSplitsStreamMock) Send(response *api_service_protos.TReadSplitsResponse) error {
+	s.responses = append(s.responses, response)
+
+	return nil
+}
+
+func (*readSplitsStreamMock) Context() context.Context {
+	return context.Background()
+}
+
+func TestRecorder(t *testing.T) {
+	responses := []*api_service_protos.TReadSplitsResponse{
+		{
+			Payload: &api_service_protos.TReadSplitsResponse_ArrowIpcStreaming{ArrowIpcStreaming: []byte("page1")},
+			Stats:   &api_service_protos.TReadSplitsResponse_TStats{Rows: 1},
+		},
+		{
+			Payload: &api_service_protos.TReadSplitsResponse_ArrowIpcStreaming{ArrowIpcStreaming: []byte("page2")},
+			Stats:   &api_service_protos.TReadSplitsResponse_TStats{Rows: 2},
+		},
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		stream := &readSplitsStreamMock{}
+		memoryBudget := paging.NewMemoryBudget(nil, memory.NewGoAllocator())
+		memoryQuota := memoryBudget.MakeQuota()
+		recorder := NewRecorder(stream, 1024, memoryQuota)
+
+		for _, response := range responses {
+			require.NoError(t, recorder.Send(response))
+		}
+
+		pages, ok := recorder.Pages()
+		require.True(t, ok)
+		require.Len(t, pages, 2)
+
+		// the recorded pages are accounted until the quota is closed
+		require.Equal(t, uint64(len(pages[0])+len(pages[1])), memoryBudget.Allocated())
+		memoryQuota.Close()
+		require.Zero(t, memoryBudget.Allocated())
+
+		replayStream := &readSplitsStreamMock{}
+		require.NoError(t, Replay(replayStream, pages))
+		require.Len(t, replayStream.responses, 2)
+
+		for i := range responses {
+			require.Equal(t, responses[i].GetArrowIpcStreaming(), replayStream.responses[i].GetArrowIpcStreaming())
+			require.Equal(t, responses[i].GetStats().GetRows(), replayStream.responses[i].GetStats().GetRows())
+		}
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		stream := &readSplitsStreamMock{}
+		memoryBudget := paging.NewMemoryBudget(nil, memory.NewGoAllocator())
+		recorder := NewRecorder(stream, 16, memoryBudget.MakeQuota())
+
+		for _, response := range responses {
+			require.NoError(t, recorder.Send(response))
+		}
+
+		// the client receives everything even if the result is too large to be cached
+		require.Len(t, stream.responses, 2)
+
+		_, ok := recorder.Pages()
+		require.False(t, ok)
+
+		// the dropped pages are not accounted anymore
+		require.Zero(t, memoryBudget.Allocated())
+	})
+}
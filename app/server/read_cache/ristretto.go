@@ -0,0 +1,162 @@
+package read_cache
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"go.uber.org/zap"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+var _ Cache = (*ristrettoCache)(nil)
+
+// entry is a value of the memory cache; the key is kept within the value
+// to spill the evicted entries on disk, since Ristretto provides only key hashes on eviction.
+type entry struct {
+	key        string
+	pages      [][]byte
+	size       int64
+	expiration time.Time
+}
+
+type ristrettoCache struct {
+	cache *ristretto.Cache[string, *entry]
+	disk  *diskStorage // nil if disk spilling is disabled
+	spill *diskWriter  // nil if disk spilling is disabled
+	ttl   time.Duration
+	kinds map[api_common.EGenericDataSourceKind]struct{}
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+func (r *ristrettoCache) Enabled(kind api_common.EGenericDataSourceKind) bool {
+	_, exists := r.kinds[kind]
+
+	return exists
+}
+
+func (r *ristrettoCache) Put(_ *zap.Logger, key string, pages [][]byte) bool {
+	e := &entry{
+		key:        key,
+		pages:      pages,
+		expiration: time.Now().Add(r.ttl),
+	}
+
+	for _, page := range pages {
+		e.size += int64(len(page))
+	}
+
+	return r.cache.SetWithTTL(key, e, e.size, r.ttl)
+}
+
+func (r *ristrettoCache) Get(logger *zap.Logger, key string) ([][]byte, bool) {
+	if e, found := r.cache.Get(key); found {
+		r.hits.Add(1)
+
+		return e.pages, true
+	}
+
+	if r.disk != nil {
+		pages, found, err := r.disk.get(key)
+		if err != nil {
+			logger.Error("read cached result from disk", zap.Error(err))
+		}
+
+		if found {
+			r.hits.Add(1)
+
+			return pages, true
+		}
+	}
+
+	r.misses.Add(1)
+
+	return nil, false
+}
+
+func (r *ristrettoCache) Metrics() *Metrics {
+	m := r.cache.Metrics
+
+	out := &Metrics{
+		Hits:        r.hits.Load(),
+		Misses:      r.misses.Load(),
+		KeysAdded:   m.KeysAdded(),
+		KeysEvicted: m.KeysEvicted(),
+		MemorySize:  m.CostAdded() - m.CostEvicted(),
+	}
+
+	if r.disk != nil {
+		out.DiskSize, out.DiskFileCount = r.disk.stats()
+	}
+
+	return out
+}
+
+func (r *ristrettoCache) Close() {
+	// the memory cache is closed first, so that no more entries are spilled
+	r.cache.Close()
+
+	if r.spill != nil {
+		r.spill.close()
+	}
+}
+
+func newRistrettoCache(logger *zap.Logger, cfg *config.TReadCacheConfig) (*ristrettoCache, error) {
+	r := &ristrettoCache{
+		ttl:   common.MustDurationFromString(cfg.GetTtl()),
+		kinds: make(map[api_common.EGenericDataSourceKind]struct{}, len(cfg.GetDataSourceKinds())),
+	}
+
+	for _, kind := range cfg.GetDataSourceKinds() {
+		r.kinds[kind] = struct{}{}
+	}
+
+	cacheCfg := &ristretto.Config[string, *entry]{
+		NumCounters: cfg.GetRistretto().GetMaxKeys(),
+		MaxCost:     cfg.GetRistretto().GetMaxSizeBytes(),
+		BufferItems: 64, // reasonable default
+		Metrics:     true,
+	}
+
+	if diskCfg := cfg.GetDiskSpill(); diskCfg != nil {
+		var err error
+
+		r.disk, err = newDiskStorage(diskCfg.GetDirectory(), diskCfg.GetMaxSizeBytes())
+		if err != nil {
+			return nil, fmt.Errorf("new disk storage: %w", err)
+		}
+
+		r.spill = newDiskWriter(logger, r.disk)
+
+		// entries that don't fit into memory are moved to disk
+		spill := func(item *ristretto.Item[*entry]) {
+			if item.Value == nil || !time.Now().Before(item.Value.expiration) {
+				return
+			}
+
+			r.spill.enqueue(item.Value)
+		}
+
+		cacheCfg.OnEvict = spill
+		cacheCfg.OnReject = spill
+	}
+
+	var err error
+
+	r.cache, err = ristretto.NewCache(cacheCfg)
+	if err != nil {
+		if r.spill != nil {
+			r.spill.close()
+		}
+
+		return nil, fmt.Errorf("ristretto new cache: %w", err)
+	}
+
+	return r, nil
+}
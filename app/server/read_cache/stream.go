@@ -0,0 +1,79 @@
+package read_cache
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	api_service "github.com/ydb-platform/fq-connector-go/api/service"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+)
+
+var _ api_service.Connector_ReadSplitsServer = (*Recorder)(nil)
+
+// Recorder keeps a copy of the responses sent to the client, so that they could be put into the cache
+// after the split reading is finished. The copies are accounted by the memory quota of the request.
+type Recorder struct {
+	api_service.Connector_ReadSplitsServer
+	memoryQuota *paging.MemoryQuota
+	pages       [][]byte
+	size        uint64
+	maxSize     uint64
+	overflow    bool // the result is too large to be cached
+}
+
+func (r *Recorder) Send(response *api_service_protos.TReadSplitsResponse) error {
+	if !r.overflow {
+		page, err := proto.Marshal(response)
+		if err != nil {
+			return fmt.Errorf("marshal response: %w", err)
+		}
+
+		r.size += uint64(len(page))
+		r.memoryQuota.Track(int64(len(page)))
+
+		if r.size > r.maxSize {
+			r.overflow = true
+			r.pages = nil
+			r.memoryQuota.Track(-int64(r.size))
+		} else {
+			r.pages = append(r.pages, page)
+		}
+	}
+
+	return r.Connector_ReadSplitsServer.Send(response)
+}
+
+// Pages returns the recorded responses, if they fit into the size limit
+func (r *Recorder) Pages() ([][]byte, bool) {
+	if r.overflow {
+		return nil, false
+	}
+
+	return r.pages, true
+}
+
+func NewRecorder(stream api_service.Connector_ReadSplitsServer, maxSize uint64, memoryQuota *paging.MemoryQuota) *Recorder {
+	return &Recorder{
+		Connector_ReadSplitsServer: stream,
+		memoryQuota:                memoryQuota,
+		maxSize:                    maxSize,
+	}
+}
+
+// Replay sends the cached responses to the client
+func Replay(stream api_service.Connector_ReadSplitsServer, pages [][]byte) error {
+	for i, page := range pages {
+		response := &api_service_protos.TReadSplitsResponse{}
+		if err := proto.Unmarshal(page, response); err != nil {
+			return fmt.Errorf("unmarshal response #%d: %w", i, err)
+		}
+
+		if err := stream.Send(response); err != nil {
+			return fmt.Errorf("stream send: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,4 @@
+// Package schema_cache contains the cache of table schemas returned by DescribeTable requests.
+// Schema discovery may be expensive (e. g. document sampling in MongoDB or key scanning in Redis),
+// while the schemas change rarely.
+package schema_cache
@@ -0,0 +1,13 @@
+package schema_cache
+
+import (
+	"github.com/ydb-platform/fq-connector-go/app/config"
+)
+
+func NewCache(cfg *config.TSchemaCacheConfig) (Cache, error) {
+	if cfg == nil {
+		return &noopCache{}, nil
+	}
+
+	return newRistrettoCache(cfg)
+}
@@ -0,0 +1,22 @@
+package schema_cache
+
+import (
+	"go.uber.org/zap"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/ydb/table_metadata_cache"
+)
+
+type Cache interface {
+	// MakeKey builds the key of the DescribeTable request.
+	// The key must be obtained before the table is described, so that the concurrent invalidation
+	// wouldn't be overwritten with the outdated schema.
+	MakeKey(request *api_service_protos.TDescribeTableRequest) (string, error)
+	Put(logger *zap.Logger, key string, value *api_service_protos.TDescribeTableResponse) bool
+	Get(logger *zap.Logger, key string) (*api_service_protos.TDescribeTableResponse, bool)
+	// Invalidate drops the schema of the table, or the schemas of all the tables of the data source instance
+	// if the table is empty, or the whole cache if the data source instance is nil.
+	Invalidate(dsi *api_common.TGenericDataSourceInstance, table string) error
+	Metrics() *table_metadata_cache.Metrics
+}
@@ -0,0 +1,34 @@
+package schema_cache
+
+import (
+	"go.uber.org/zap"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/ydb/table_metadata_cache"
+)
+
+var _ Cache = (*noopCache)(nil)
+
+type noopCache struct {
+}
+
+func (noopCache) MakeKey(_ *api_service_protos.TDescribeTableRequest) (string, error) {
+	return "", nil
+}
+
+func (noopCache) Put(_ *zap.Logger, _ string, _ *api_service_protos.TDescribeTableResponse) bool {
+	return true
+}
+
+func (noopCache) Get(_ *zap.Logger, _ string) (*api_service_protos.TDescribeTableResponse, bool) {
+	return nil, false
+}
+
+func (noopCache) Invalidate(_ *api_common.TGenericDataSourceInstance, _ string) error {
+	return nil
+}
+
+func (noopCache) Metrics() *table_metadata_cache.Metrics {
+	return nil
+}
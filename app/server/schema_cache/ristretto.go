@@ -0,0 +1,200 @@
+package schema_cache
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/ydb/table_metadata_cache"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+var _ Cache = (*ristrettoCache)(nil)
+
+// credentialsHMACKey protects the credentials digest kept in the cache keys.
+// The cache doesn't outlive the process, so the secret is generated on startup.
+var credentialsHMACKey = func() []byte {
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Errorf("generate credentials HMAC key: %w", err))
+	}
+
+	return key
+}()
+
+// ristrettoCache keeps serialized DescribeTable responses.
+// Ristretto can't enumerate keys, so the invalidation of the instance or the table
+// is implemented with generation numbers which are the part of the key:
+// once the generation is incremented, the old values become unreachable and expire later.
+type ristrettoCache struct {
+	cache       *ristretto.Cache[string, []byte]
+	ttl         time.Duration
+	mutex       sync.Mutex
+	generation  uint64            // the number of invalidations of the whole cache
+	generations map[string]uint64 // instance or table ID -> the number of invalidations
+}
+
+// MakeKey builds the cache key from the table and the request settings affecting the schema.
+// The clients with different credentials may have access to different tables, so the key includes
+// the HMAC of the credentials. The refreshed credentials (e. g. IAM tokens) just cause cache misses.
+func (r *ristrettoCache) MakeKey(request *api_service_protos.TDescribeTableRequest) (string, error) {
+	instanceID, err := makeInstanceID(request.DataSourceInstance)
+	if err != nil {
+		return "", fmt.Errorf("make instance ID: %w", err)
+	}
+
+	credentials, err := proto.MarshalOptions{Deterministic: true}.Marshal(request.GetDataSourceInstance().GetCredentials())
+	if err != nil {
+		return "", fmt.Errorf("marshal credentials: %w", err)
+	}
+
+	credentialsMAC := hmac.New(sha256.New, credentialsHMACKey)
+	credentialsMAC.Write(credentials)
+
+	settings, err := proto.MarshalOptions{Deterministic: true}.Marshal(request.TypeMappingSettings)
+	if err != nil {
+		return "", fmt.Errorf("marshal type mapping settings: %w", err)
+	}
+
+	tableID := makeTableID(instanceID, request.Table)
+
+	r.mutex.Lock()
+	generation := r.generation
+	instanceGeneration, tableGeneration := r.generations[instanceID], r.generations[tableID]
+	r.mutex.Unlock()
+
+	hash := sha256.New()
+
+	hash.Write([]byte(tableID))
+	_ = binary.Write(hash, binary.BigEndian, generation)
+	_ = binary.Write(hash, binary.BigEndian, instanceGeneration)
+	_ = binary.Write(hash, binary.BigEndian, tableGeneration)
+	hash.Write(settings)
+	hash.Write([]byte(request.Query))
+	hash.Write(credentialsMAC.Sum(nil))
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (r *ristrettoCache) Put(logger *zap.Logger, key string, value *api_service_protos.TDescribeTableResponse) bool {
+	data, err := proto.Marshal(value)
+	if err != nil {
+		logger.Error("marshal schema", zap.Error(err))
+
+		return false
+	}
+
+	return r.cache.SetWithTTL(key, data, int64(len(data)), r.ttl)
+}
+
+func (r *ristrettoCache) Get(logger *zap.Logger, key string) (*api_service_protos.TDescribeTableResponse, bool) {
+	data, found := r.cache.Get(key)
+	if !found {
+		return nil, false
+	}
+
+	value := &api_service_protos.TDescribeTableResponse{}
+	if err := proto.Unmarshal(data, value); err != nil {
+		logger.Error("unmarshal cached schema", zap.Error(err))
+
+		// the broken value is dropped, so that the schema would be obtained from the data source again
+		r.cache.Del(key)
+
+		return nil, false
+	}
+
+	return value, true
+}
+
+func (r *ristrettoCache) Invalidate(dsi *api_common.TGenericDataSourceInstance, table string) error {
+	if dsi == nil {
+		r.mutex.Lock()
+		r.generation++
+		r.mutex.Unlock()
+
+		// unreachable values are dropped at once to free memory
+		r.cache.Clear()
+
+		return nil
+	}
+
+	instanceID, err := makeInstanceID(dsi)
+	if err != nil {
+		return fmt.Errorf("make instance ID: %w", err)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if table == "" {
+		r.generations[instanceID]++
+	} else {
+		r.generations[makeTableID(instanceID, table)]++
+	}
+
+	return nil
+}
+
+func (r *ristrettoCache) Metrics() *table_metadata_cache.Metrics {
+	m := r.cache.Metrics
+
+	return &table_metadata_cache.Metrics{
+		Hits:        m.Hits(),
+		Misses:      m.Misses(),
+		Ratio:       m.Ratio(),
+		KeysAdded:   m.KeysAdded(),
+		KeysEvicted: m.KeysEvicted(),
+		KeysDropped: 0, // Ristretto doesn't expose this metric
+		Size:        m.CostAdded() - m.CostEvicted(),
+	}
+}
+
+// makeInstanceID serializes the data source instance without credentials,
+// so that the invalidation of the instance affects the schemas cached for all the clients
+func makeInstanceID(dsi *api_common.TGenericDataSourceInstance) (string, error) {
+	normalized := proto.Clone(dsi).(*api_common.TGenericDataSourceInstance)
+	normalized.Credentials = nil
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(normalized)
+	if err != nil {
+		return "", fmt.Errorf("marshal data source instance: %w", err)
+	}
+
+	hash := sha256.Sum256(data)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func makeTableID(instanceID, table string) string {
+	return fmt.Sprintf("%s/%s", instanceID, table)
+}
+
+func newRistrettoCache(cfg *config.TSchemaCacheConfig) (*ristrettoCache, error) {
+	cache, err := ristretto.NewCache(&ristretto.Config[string, []byte]{
+		NumCounters: cfg.GetRistretto().GetMaxKeys(),
+		MaxCost:     cfg.GetRistretto().GetMaxSizeBytes(),
+		BufferItems: 64, // reasonable default
+		Metrics:     true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ristretto new cache: %w", err)
+	}
+
+	return &ristrettoCache{
+		cache:       cache,
+		ttl:         common.MustDurationFromString(cfg.GetTtl()),
+		generations: make(map[string]uint64),
+	}, nil
+}
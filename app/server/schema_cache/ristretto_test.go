@@ -0,0 +1,160 @@
+package schema_cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+)
+
+func makeDataSourceInstance(database, password string) *api_common.TGenericDataSourceInstance {
+	return &api_common.TGenericDataSourceInstance{
+		Kind:     api_common.EGenericDataSourceKind_MONGO_DB,
+		Endpoint: &api_common.TGenericEndpoint{Host: "localhost", Port: 27017},
+		Database: database,
+		Credentials: &api_common.TGenericCredentials{
+			Payload: &api_common.TGenericCredentials_Basic{
+				Basic: &api_common.TGenericCredentials_TBasic{Username: "user", Password: password},
+			},
+		},
+	}
+}
+
+func makeRequest(database, table string) *api_service_protos.TDescribeTableRequest {
+	return &api_service_protos.TDescribeTableRequest{
+		DataSourceInstance: makeDataSourceInstance(database, "secret"),
+		Table:              table,
+	}
+}
+
+func TestRistrettoCache(t *testing.T) {
+	logger := zap.NewNop()
+
+	newCache := func() *ristrettoCache {
+		cache, err := newRistrettoCache(&config.TSchemaCacheConfig{
+			Ttl:       "1m",
+			Ristretto: &config.TSchemaCacheConfig_TRistretto{MaxKeys: 100, MaxSizeBytes: 1 << 20},
+		})
+		require.NoError(t, err)
+
+		return cache
+	}
+
+	mustMakeKey := func(cache *ristrettoCache, request *api_service_protos.TDescribeTableRequest) string {
+		key, err := cache.MakeKey(request)
+		require.NoError(t, err)
+
+		return key
+	}
+
+	put := func(cache *ristrettoCache, request *api_service_protos.TDescribeTableRequest) {
+		response := &api_service_protos.TDescribeTableResponse{Schema: &api_service_protos.TSchema{}}
+
+		require.True(t, cache.Put(logger, mustMakeKey(cache, request), response))
+		cache.cache.Wait()
+	}
+
+	found := func(cache *ristrettoCache, request *api_service_protos.TDescribeTableRequest) bool {
+		_, ok := cache.Get(logger, mustMakeKey(cache, request))
+
+		return ok
+	}
+
+	t.Run("key", func(t *testing.T) {
+		cache := newCache()
+
+		// the same request produces the same key
+		request1 := makeRequest("db", "tab")
+		require.Equal(t, mustMakeKey(cache, request1), mustMakeKey(cache, makeRequest("db", "tab")))
+
+		// credentials affect the key
+		request2 := makeRequest("db", "tab")
+		request2.DataSourceInstance = makeDataSourceInstance("db", "another_secret")
+		require.NotEqual(t, mustMakeKey(cache, request1), mustMakeKey(cache, request2))
+
+		// type mapping settings do as well
+		request3 := makeRequest("db", "tab")
+		request3.TypeMappingSettings = &api_service_protos.TTypeMappingSettings{
+			DateTimeFormat: api_service_protos.EDateTimeFormat_STRING_FORMAT,
+		}
+		require.NotEqual(t, mustMakeKey(cache, request1), mustMakeKey(cache, request3))
+
+		require.NotEqual(t, mustMakeKey(cache, request1), mustMakeKey(cache, makeRequest("db", "another_tab")))
+		require.NotEqual(t, mustMakeKey(cache, request1), mustMakeKey(cache, makeRequest("another_db", "tab")))
+	})
+
+	t.Run("credentials", func(t *testing.T) {
+		cache := newCache()
+
+		put(cache, makeRequest("db", "tab"))
+		require.True(t, found(cache, makeRequest("db", "tab")))
+
+		// the client with different credentials may have no access to the table,
+		// so the schema must be obtained from the data source
+		request := makeRequest("db", "tab")
+		request.DataSourceInstance = makeDataSourceInstance("db", "another_secret")
+		require.False(t, found(cache, request))
+	})
+
+	t.Run("invalidate_table", func(t *testing.T) {
+		cache := newCache()
+
+		put(cache, makeRequest("db", "tab1"))
+		put(cache, makeRequest("db", "tab2"))
+
+		require.NoError(t, cache.Invalidate(makeDataSourceInstance("db", "another_secret"), "tab1"))
+
+		require.False(t, found(cache, makeRequest("db", "tab1")))
+		require.True(t, found(cache, makeRequest("db", "tab2")))
+	})
+
+	t.Run("invalidate_instance", func(t *testing.T) {
+		cache := newCache()
+
+		put(cache, makeRequest("db1", "tab1"))
+		put(cache, makeRequest("db1", "tab2"))
+		put(cache, makeRequest("db2", "tab1"))
+
+		require.NoError(t, cache.Invalidate(makeDataSourceInstance("db1", "secret"), ""))
+
+		require.False(t, found(cache, makeRequest("db1", "tab1")))
+		require.False(t, found(cache, makeRequest("db1", "tab2")))
+		require.True(t, found(cache, makeRequest("db2", "tab1")))
+	})
+
+	t.Run("invalidate_all", func(t *testing.T) {
+		cache := newCache()
+
+		request := makeRequest("db", "tab")
+
+		// the key is obtained before the invalidation, like it happens with the concurrent DescribeTable
+		key := mustMakeKey(cache, request)
+
+		require.NoError(t, cache.Invalidate(nil, ""))
+
+		cache.Put(logger, key, &api_service_protos.TDescribeTableResponse{})
+		cache.cache.Wait()
+
+		require.False(t, found(cache, request))
+	})
+
+	t.Run("broken_value", func(t *testing.T) {
+		cache := newCache()
+
+		request := makeRequest("db", "tab")
+		key := mustMakeKey(cache, request)
+
+		require.True(t, cache.cache.Set(key, []byte("garbage"), 7))
+		cache.cache.Wait()
+
+		// the broken value is treated as a miss and dropped
+		require.False(t, found(cache, request))
+
+		_, ok := cache.cache.Get(key)
+		require.False(t, ok)
+	})
+}
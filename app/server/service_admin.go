@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	api_admin "github.com/ydb-platform/fq-connector-go/api/admin"
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/schema_cache"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+var _ api_admin.AdminServiceServer = (*serviceAdmin)(nil)
+
+// serviceAdmin serves administrative requests on a separate GRPC server,
+// so that the endpoint could be hidden from the clients of the Connector service.
+type serviceAdmin struct {
+	api_admin.UnimplementedAdminServiceServer
+	schemaCache schema_cache.Cache
+	grpcServer  *grpc.Server
+	listener    net.Listener
+	logger      *zap.Logger
+}
+
+func (s *serviceAdmin) InvalidateSchemaCache(
+	ctx context.Context,
+	request *api_admin.InvalidateSchemaCacheRequest,
+) (*api_admin.InvalidateSchemaCacheResponse, error) {
+	logger := utils.LoggerMustFromContext(ctx)
+
+	if request.DataSourceInstance != nil {
+		logger = common.AnnotateLoggerWithDataSourceInstance(logger, request.DataSourceInstance)
+	}
+
+	logger.Info("request handling started", zap.String("table", request.GetTable()))
+
+	if err := s.doInvalidateSchemaCache(request); err != nil {
+		logger.Error("request handling failed", zap.Error(err))
+
+		return &api_admin.InvalidateSchemaCacheResponse{
+			Error: common.NewAPIErrorFromStdError(err, request.GetDataSourceInstance().GetKind()),
+		}, nil
+	}
+
+	logger.Info("request handling finished")
+
+	return &api_admin.InvalidateSchemaCacheResponse{Error: common.NewSuccess()}, nil
+}
+
+func (s *serviceAdmin) doInvalidateSchemaCache(request *api_admin.InvalidateSchemaCacheRequest) error {
+	if request.DataSourceInstance == nil && request.Table != "" {
+		return fmt.Errorf("table is set without data source instance: %w", common.ErrInvalidRequest)
+	}
+
+	if request.DataSourceInstance != nil &&
+		request.DataSourceInstance.Kind == api_common.EGenericDataSourceKind_DATA_SOURCE_KIND_UNSPECIFIED {
+		return fmt.Errorf("data source kind is not set: %w", common.ErrInvalidRequest)
+	}
+
+	if err := s.schemaCache.Invalidate(request.DataSourceInstance, request.Table); err != nil {
+		return fmt.Errorf("invalidate schema cache: %w", err)
+	}
+
+	return nil
+}
+
+func (s *serviceAdmin) Start() error {
+	s.logger.Info("starting GRPC server", zap.String("address", s.listener.Addr().String()))
+
+	if err := s.grpcServer.Serve(s.listener); err != nil {
+		return fmt.Errorf("listener serve: %w", err)
+	}
+
+	return nil
+}
+
+func (s *serviceAdmin) Stop() {
+	s.grpcServer.GracefulStop()
+}
+
+func newServiceAdmin(
+	logger *zap.Logger,
+	cfg *config.TAdminServerConfig,
+	schemaCache schema_cache.Cache,
+) (utils.Service, error) {
+	//nolint:noctx
+	listener, err := net.Listen("tcp", common.EndpointToString(cfg.Endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("net listen: %w", err)
+	}
+
+	options := []grpc.ServerOption{grpc.ChainUnaryInterceptor(utils.UnaryServerMetadata(logger))}
+
+	if cfg.Tls != nil {
+		creds, err := makeTLSCredentials(logger, cfg.Tls)
+		if err != nil {
+			return nil, fmt.Errorf("make TLS credentials: %w", err)
+		}
+
+		options = append(options, grpc.Creds(creds))
+	} else {
+		logger.Warn("server will use insecure connections")
+	}
+
+	s := &serviceAdmin{
+		schemaCache: schemaCache,
+		grpcServer:  grpc.NewServer(options...),
+		listener:    listener,
+		logger:      logger,
+	}
+
+	api_admin.RegisterAdminServiceServer(s.grpcServer, s)
+
+	return s, nil
+}
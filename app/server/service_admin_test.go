@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_admin "github.com/ydb-platform/fq-connector-go/api/admin"
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/schema_cache"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestServiceAdmin(t *testing.T) {
+	logger := common.NewTestLogger(t)
+
+	schemaCache, err := schema_cache.NewCache(&config.TSchemaCacheConfig{
+		Ttl:       "1m",
+		Ristretto: &config.TSchemaCacheConfig_TRistretto{MaxKeys: 100, MaxSizeBytes: 1 << 20},
+	})
+	require.NoError(t, err)
+
+	service, err := newServiceAdmin(
+		logger,
+		&config.TAdminServerConfig{Endpoint: &api_common.TGenericEndpoint{Host: "localhost", Port: 0}},
+		schemaCache,
+	)
+	require.NoError(t, err)
+
+	go func() {
+		if err := service.Start(); err != nil {
+			logger.Error(err.Error())
+		}
+	}()
+
+	defer service.Stop()
+
+	conn, err := grpc.NewClient(
+		service.(*serviceAdmin).listener.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	client := api_admin.NewAdminServiceClient(conn)
+	ctx := context.Background()
+
+	dsi := &api_common.TGenericDataSourceInstance{
+		Kind:     api_common.EGenericDataSourceKind_POSTGRESQL,
+		Endpoint: &api_common.TGenericEndpoint{Host: "localhost", Port: 5432},
+		Database: "db",
+	}
+
+	describeTableRequest := &api_service_protos.TDescribeTableRequest{DataSourceInstance: dsi, Table: "tab"}
+
+	t.Run("invalidate_table", func(t *testing.T) {
+		key, err := schemaCache.MakeKey(describeTableRequest)
+		require.NoError(t, err)
+
+		response, err := client.InvalidateSchemaCache(ctx, &api_admin.InvalidateSchemaCacheRequest{
+			DataSourceInstance: dsi,
+			Table:              "tab",
+		})
+		require.NoError(t, err)
+		require.True(t, common.IsSuccess(response.Error), response.Error)
+
+		// the schema obtained before the invalidation is not reachable anymore
+		newKey, err := schemaCache.MakeKey(describeTableRequest)
+		require.NoError(t, err)
+		require.NotEqual(t, key, newKey)
+	})
+
+	t.Run("invalid_requests", func(t *testing.T) {
+		requests := []*api_admin.InvalidateSchemaCacheRequest{
+			{Table: "tab"},
+			{DataSourceInstance: &api_common.TGenericDataSourceInstance{Database: "db"}},
+		}
+
+		for _, request := range requests {
+			response, err := client.InvalidateSchemaCache(ctx, request)
+			require.NoError(t, err)
+			require.Equal(t, Ydb.StatusIds_BAD_REQUEST, response.Error.Status)
+		}
+	})
+}
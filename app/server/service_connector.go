@@ -21,6 +21,9 @@ import (
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/ydb/table_metadata_cache"
 	"github.com/ydb-platform/fq-connector-go/app/server/observation"
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+	"github.com/ydb-platform/fq-connector-go/app/server/read_cache"
+	"github.com/ydb-platform/fq-connector-go/app/server/schema_cache"
+	"github.com/ydb-platform/fq-connector-go/app/server/streaming"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils"
 	"github.com/ydb-platform/fq-connector-go/common"
 	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/solomon"
@@ -29,6 +32,7 @@ import (
 type serviceConnector struct {
 	api_service.UnimplementedConnectorServer
 	dataSourceCollection *DataSourceCollection
+	memoryBudget         *paging.MemoryBudget
 	cfg                  *config.TServerConfig
 	grpcServer           *grpc.Server
 	listener             net.Listener
@@ -49,7 +53,7 @@ func (s *serviceConnector) DescribeTable(
 	logger = common.AnnotateLoggerWithDataSourceInstance(logger, request.DataSourceInstance)
 	logger.Info("request handling started", zap.String("table", request.GetTable()))
 
-	if err := ValidateDescribeTableRequest(request); err != nil {
+	if err := ValidateDescribeTableRequest(request, s.cfg.Datasources.NativeQuery); err != nil {
 		logger.Error("request handling failed", zap.Error(err))
 
 		response := &api_service_protos.TDescribeTableResponse{
@@ -59,6 +63,8 @@ func (s *serviceConnector) DescribeTable(
 		return response, nil
 	}
 
+	request.TypeMappingSettings = withServerTypeMappingRules(request.TypeMappingSettings, s.cfg.TypeMapping)
+
 	out, err := s.dataSourceCollection.DescribeTable(ctx, logger, request)
 	if err != nil {
 		logger.Error("request handling failed", zap.Error(err))
@@ -81,7 +87,7 @@ func (s *serviceConnector) ListSplits(
 	logger := utils.LoggerMustFromContext(stream.Context())
 	logger.Info("request handling started", zap.Int("total selects", len(request.Selects)))
 
-	if err := ValidateListSplitsRequest(request); err != nil {
+	if err := ValidateListSplitsRequest(request, s.cfg.Datasources.NativeQuery); err != nil {
 		return s.doListSplitsResponse(logger, stream,
 			&api_service_protos.TListSplitsResponse{
 				Error: common.NewAPIErrorFromStdError(
@@ -159,26 +165,34 @@ func (s *serviceConnector) doReadSplits(
 	request *api_service_protos.TReadSplitsRequest,
 	stream api_service.Connector_ReadSplitsServer,
 ) (*zap.Logger, error) {
-	if err := ValidateReadSplitsRequest(request); err != nil {
+	if err := ValidateReadSplitsRequest(request, s.cfg.Datasources.NativeQuery); err != nil {
 		return logger, fmt.Errorf("validate read splits request: %w", err)
 	}
 
-	for _, split := range request.Splits {
+	request.TypeMappingSettings = withServerTypeMappingRules(request.TypeMappingSettings, s.cfg.TypeMapping)
+
+	readSplit := func(stream api_service.Connector_ReadSplitsServer, split *api_service_protos.TSplit) error {
 		splitLogger := common.AnnotateLoggerWithDataSourceInstance(logger, split.Select.DataSourceInstance)
 
 		if len(request.Splits) > 1 {
 			splitLogger = splitLogger.With(zap.Uint64("split_sequential_id", split.Id))
 		}
 
-		err := s.dataSourceCollection.ReadSplit(
+		return s.dataSourceCollection.ReadSplit(
 			splitLogger,
 			stream,
 			request,
 			split,
 		)
-		if err != nil {
-			return splitLogger, fmt.Errorf("read split %d: %w", split.Id, err)
-		}
+	}
+
+	// the next splits are read in advance only while there is memory to keep their data
+	canPrefetch := func() bool { return !s.memoryBudget.Exhausted() }
+
+	prefetcher := streaming.NewSplitPrefetcher(stream, int(s.cfg.Paging.PrefetchSplits), canPrefetch, readSplit)
+
+	if err := prefetcher.Run(request.Splits); err != nil {
+		return logger, err
 	}
 
 	return logger, nil
@@ -219,6 +233,17 @@ func makeGRPCOptions(logger *zap.Logger, cfg *config.TServerConfig, registry *so
 		return opts, nil
 	}
 
+	creds, err := makeTLSCredentials(logger, tlsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("make TLS credentials: %w", err)
+	}
+
+	opts = append(opts, grpc.Creds(creds))
+
+	return opts, nil
+}
+
+func makeTLSCredentials(logger *zap.Logger, tlsConfig *config.TServerTLSConfig) (credentials.TransportCredentials, error) {
 	logger.Info("server will use TLS connections")
 
 	logger.Debug("reading key pair", zap.String("cert", tlsConfig.Cert), zap.String("key", tlsConfig.Key))
@@ -229,11 +254,7 @@ func makeGRPCOptions(logger *zap.Logger, cfg *config.TServerConfig, registry *so
 	}
 
 	// for security reasons we do not allow TLS < 1.2, see YQ-1877
-	creds := credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12})
-
-	opts = append(opts, grpc.Creds(creds))
-
-	return opts, nil
+	return credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}), nil
 }
 
 func (s *serviceConnector) Stop() {
@@ -247,7 +268,8 @@ func newServiceConnector(
 	registry *solomon.Registry,
 	observationStorage observation.Storage,
 	ydbTableMetadataCache table_metadata_cache.Cache,
-) (utils.Service, error) {
+	schemaCache schema_cache.Cache,
+) (*serviceConnector, error) {
 	queryLoggerFactory := common.NewQueryLoggerFactory(cfg.Logger)
 
 	// TODO: drop deprecated fields after YQ-2057
@@ -274,13 +296,25 @@ func newServiceConnector(
 	grpcServer := grpc.NewServer(options...)
 	reflection.Register(grpcServer)
 
+	memoryBudget := paging.NewMemoryBudget(cfg.Memory, memory.DefaultAllocator)
+	memoryBudget.RegisterMetrics(registry)
+
+	readCache, err := read_cache.NewCache(logger, cfg.ReadCache)
+	if err != nil {
+		return nil, fmt.Errorf("new read cache: %w", err)
+	}
+
+	read_cache.RegisterMetrics(registry, readCache)
+
 	dataSourceCollection, err := NewDataSourceCollection(
 		queryLoggerFactory,
-		memory.DefaultAllocator,
+		memoryBudget,
 		paging.NewReadLimiterFactory(cfg.Datasources),
 		conversion.NewCollection(cfg.Conversion),
 		observationStorage,
 		ydbTableMetadataCache,
+		readCache,
+		schemaCache,
 		cfg,
 	)
 	if err != nil {
@@ -289,6 +323,7 @@ func newServiceConnector(
 
 	s := &serviceConnector{
 		dataSourceCollection: dataSourceCollection,
+		memoryBudget:         memoryBudget,
 		logger:               logger,
 		grpcServer:           grpcServer,
 		listener:             listener,
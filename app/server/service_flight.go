@@ -0,0 +1,400 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/apache/arrow/go/v13/arrow/flight"
+	"github.com/apache/arrow/go/v13/arrow/ipc"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	ydb_proto "github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	api_service "github.com/ydb-platform/fq-connector-go/api/service"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// serviceFlight exposes the data sources via Arrow Flight protocol,
+// so that Arrow-native clients (pyarrow, DuckDB, Spark) could read the data without any additional conversion.
+// All the requests are delegated to the Connector service:
+//   - `GetFlightInfo` and `GetSchema` accept a command containing `TDescribeTableRequest` serialized into JSON;
+//   - `GetFlightInfo` lists the splits of the table, every split becomes a separate endpoint;
+//   - `DoGet` reads the split stored in the ticket and streams the same Arrow pages as `ReadSplits`.
+//
+// Tickets contain the data source instance (including credentials), so they must never be shared with third parties.
+type serviceFlight struct {
+	flight.BaseFlightServer
+	connector  api_service.ConnectorServer
+	grpcServer flight.Server
+	listener   net.Listener
+	logger     *zap.Logger
+}
+
+func (s *serviceFlight) GetFlightInfo(ctx context.Context, descriptor *flight.FlightDescriptor) (*flight.FlightInfo, error) {
+	logger := utils.LoggerMustFromContext(ctx)
+
+	describeTableRequest, describeTableResponse, err := s.describeTable(ctx, descriptor)
+	if err != nil {
+		logger.Error("describe table failed", zap.Error(err))
+
+		return nil, err
+	}
+
+	slct := &api_service_protos.TSelect{
+		DataSourceInstance: describeTableRequest.DataSourceInstance,
+		What:               common.SchemaToSelectWhatItems(describeTableResponse.Schema, nil),
+		From: &api_service_protos.TSelect_TFrom{
+			Table: describeTableRequest.Table,
+			Query: describeTableRequest.Query,
+		},
+	}
+
+	splits, err := s.listSplits(ctx, slct)
+	if err != nil {
+		logger.Error("list splits failed", zap.Error(err))
+
+		return nil, err
+	}
+
+	schema, err := common.SelectWhatToArrowSchema(slct.What)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "select what to arrow schema: %v", err)
+	}
+
+	info := &flight.FlightInfo{
+		Schema:           flight.SerializeSchema(schema, memory.DefaultAllocator),
+		FlightDescriptor: descriptor,
+		TotalRecords:     -1,
+		TotalBytes:       -1,
+	}
+
+	for _, split := range splits {
+		ticket, err := makeFlightTicket(describeTableRequest, split)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "make flight ticket: %v", err)
+		}
+
+		info.Endpoint = append(info.Endpoint, &flight.FlightEndpoint{Ticket: ticket})
+	}
+
+	logger.Info("flight info prepared", zap.Int("total_endpoints", len(info.Endpoint)))
+
+	return info, nil
+}
+
+func (s *serviceFlight) GetSchema(ctx context.Context, descriptor *flight.FlightDescriptor) (*flight.SchemaResult, error) {
+	_, describeTableResponse, err := s.describeTable(ctx, descriptor)
+	if err != nil {
+		utils.LoggerMustFromContext(ctx).Error("describe table failed", zap.Error(err))
+
+		return nil, err
+	}
+
+	schema, err := common.SelectWhatToArrowSchema(common.SchemaToSelectWhatItems(describeTableResponse.Schema, nil))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "select what to arrow schema: %v", err)
+	}
+
+	return &flight.SchemaResult{Schema: flight.SerializeSchema(schema, memory.DefaultAllocator)}, nil
+}
+
+func (s *serviceFlight) describeTable(
+	ctx context.Context,
+	descriptor *flight.FlightDescriptor,
+) (*api_service_protos.TDescribeTableRequest, *api_service_protos.TDescribeTableResponse, error) {
+	if descriptor.GetType() != flight.DescriptorCMD {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "unsupported descriptor type: %v", descriptor.GetType())
+	}
+
+	request := &api_service_protos.TDescribeTableRequest{}
+
+	if err := protojson.Unmarshal(descriptor.Cmd, request); err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "unmarshal describe table request: %v", err)
+	}
+
+	response, err := s.connector.DescribeTable(ctx, request)
+	if err != nil {
+		return nil, nil, fmt.Errorf("describe table: %w", err)
+	}
+
+	if !common.IsSuccess(response.Error) {
+		return nil, nil, apiErrorToGRPCStatus(response.Error)
+	}
+
+	return request, response, nil
+}
+
+func (s *serviceFlight) listSplits(ctx context.Context, slct *api_service_protos.TSelect) ([]*api_service_protos.TSplit, error) {
+	stream := &flightListSplitsStream{ctx: ctx}
+
+	request := &api_service_protos.TListSplitsRequest{Selects: []*api_service_protos.TSelect{slct}}
+
+	if err := s.connector.ListSplits(request, stream); err != nil {
+		return nil, fmt.Errorf("list splits: %w", err)
+	}
+
+	var splits []*api_service_protos.TSplit
+
+	for _, response := range stream.responses {
+		if !common.IsSuccess(response.Error) {
+			return nil, apiErrorToGRPCStatus(response.Error)
+		}
+
+		splits = append(splits, response.Splits...)
+	}
+
+	return splits, nil
+}
+
+func (s *serviceFlight) DoGet(ticket *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+	logger := utils.LoggerMustFromContext(stream.Context())
+
+	request := &api_service_protos.TReadSplitsRequest{}
+
+	if err := proto.Unmarshal(ticket.Ticket, request); err != nil {
+		return status.Errorf(codes.InvalidArgument, "unmarshal ticket: %v", err)
+	}
+
+	if len(request.Splits) != 1 {
+		return status.Errorf(codes.InvalidArgument, "ticket must contain exactly one split, got %d", len(request.Splits))
+	}
+
+	// Flight clients can consume only Arrow data
+	request.Format = api_service_protos.TReadSplitsRequest_ARROW_IPC_STREAMING
+
+	// Flight stream has the same schema for all the records, while the dictionaries differ from page to page
+	request.DictionaryEncoding = nil
+
+	schema, err := common.SelectWhatToArrowSchema(request.Splits[0].GetSelect().GetWhat())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "select what to arrow schema: %v", err)
+	}
+
+	writer := flight.NewRecordWriter(stream, ipc.WithSchema(schema))
+	defer common.LogCloserError(logger, writer, "closing flight record writer")
+
+	readSplitsStream := &flightReadSplitsStream{stream: stream, writer: writer}
+
+	if err := s.connector.ReadSplits(request, readSplitsStream); err != nil {
+		return fmt.Errorf("read splits: %w", err)
+	}
+
+	if readSplitsStream.err != nil {
+		return readSplitsStream.err
+	}
+
+	return nil
+}
+
+func (s *serviceFlight) Start() error {
+	s.logger.Info("starting Arrow Flight server", zap.String("address", s.listener.Addr().String()))
+
+	if err := s.grpcServer.Serve(); err != nil {
+		return fmt.Errorf("flight server serve: %w", err)
+	}
+
+	return nil
+}
+
+func (s *serviceFlight) Stop() {
+	s.grpcServer.Shutdown()
+}
+
+// makeFlightTicket wraps a split into the ReadSplits request, so that DoGet call could be stateless
+func makeFlightTicket(
+	describeTableRequest *api_service_protos.TDescribeTableRequest,
+	split *api_service_protos.TSplit,
+) (*flight.Ticket, error) {
+	request := &api_service_protos.TReadSplitsRequest{
+		Splits:              []*api_service_protos.TSplit{split},
+		Format:              api_service_protos.TReadSplitsRequest_ARROW_IPC_STREAMING,
+		Filtering:           api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+		TypeMappingSettings: describeTableRequest.TypeMappingSettings,
+	}
+
+	data, err := proto.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshal read splits request: %w", err)
+	}
+
+	return &flight.Ticket{Ticket: data}, nil
+}
+
+func apiErrorToGRPCStatus(apiErr *api_service_protos.TError) error {
+	var code codes.Code
+
+	switch apiErr.Status {
+	case ydb_proto.StatusIds_BAD_REQUEST, ydb_proto.StatusIds_SCHEME_ERROR:
+		code = codes.InvalidArgument
+	case ydb_proto.StatusIds_UNAUTHORIZED:
+		code = codes.Unauthenticated
+	case ydb_proto.StatusIds_NOT_FOUND:
+		code = codes.NotFound
+	case ydb_proto.StatusIds_UNSUPPORTED:
+		code = codes.Unimplemented
+	case ydb_proto.StatusIds_UNAVAILABLE, ydb_proto.StatusIds_OVERLOADED:
+		code = codes.Unavailable
+	case ydb_proto.StatusIds_TIMEOUT:
+		code = codes.DeadlineExceeded
+	case ydb_proto.StatusIds_CANCELLED:
+		code = codes.Canceled
+	default:
+		code = codes.Internal
+	}
+
+	return status.Error(code, apiErr.Message)
+}
+
+var _ api_service.Connector_ListSplitsServer = (*flightListSplitsStream)(nil)
+
+// flightListSplitsStream collects the splits listed by the Connector service.
+// There is no underlying gRPC stream, so the metadata set by the Connector service is discarded.
+type flightListSplitsStream struct {
+	ctx       context.Context
+	responses []*api_service_protos.TListSplitsResponse
+}
+
+func (s *flightListSplitsStream) Send(response *api_service_protos.TListSplitsResponse) error {
+	s.responses = append(s.responses, response)
+
+	return nil
+}
+
+func (*flightListSplitsStream) SetHeader(metadata.MD) error { return nil }
+
+func (*flightListSplitsStream) SendHeader(metadata.MD) error { return nil }
+
+func (*flightListSplitsStream) SetTrailer(metadata.MD) {}
+
+func (s *flightListSplitsStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *flightListSplitsStream) SendMsg(m any) error {
+	response, ok := m.(*api_service_protos.TListSplitsResponse)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", m)
+	}
+
+	return s.Send(response)
+}
+
+// RecvMsg reports the end of the client stream: the request has already been passed to the Connector service
+func (*flightListSplitsStream) RecvMsg(any) error { return io.EOF }
+
+var _ api_service.Connector_ReadSplitsServer = (*flightReadSplitsStream)(nil)
+
+// flightReadSplitsStream forwards the Arrow pages produced by the Connector service to the Flight client.
+// The metadata is forwarded to the Flight stream as is.
+type flightReadSplitsStream struct {
+	stream flight.FlightService_DoGetServer
+	writer *flight.Writer
+	err    error // the error reported by the Connector service within the response
+}
+
+func (s *flightReadSplitsStream) SetHeader(md metadata.MD) error { return s.stream.SetHeader(md) }
+
+func (s *flightReadSplitsStream) SendHeader(md metadata.MD) error { return s.stream.SendHeader(md) }
+
+func (s *flightReadSplitsStream) SetTrailer(md metadata.MD) { s.stream.SetTrailer(md) }
+
+func (s *flightReadSplitsStream) Context() context.Context {
+	return s.stream.Context()
+}
+
+func (s *flightReadSplitsStream) SendMsg(m any) error {
+	response, ok := m.(*api_service_protos.TReadSplitsResponse)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", m)
+	}
+
+	return s.Send(response)
+}
+
+// RecvMsg reports the end of the client stream: the request has already been decoded from the ticket
+func (*flightReadSplitsStream) RecvMsg(any) error { return io.EOF }
+
+func (s *flightReadSplitsStream) Send(response *api_service_protos.TReadSplitsResponse) error {
+	if !common.IsSuccess(response.Error) {
+		s.err = apiErrorToGRPCStatus(response.Error)
+
+		return nil
+	}
+
+	reader, err := ipc.NewReader(bytes.NewReader(response.GetArrowIpcStreaming()))
+	if err != nil {
+		return fmt.Errorf("new arrow reader: %w", err)
+	}
+
+	defer reader.Release()
+
+	for reader.Next() {
+		if err := s.writer.Write(reader.Record()); err != nil {
+			return fmt.Errorf("write record: %w", err)
+		}
+	}
+
+	if err := reader.Err(); err != nil {
+		return fmt.Errorf("read record: %w", err)
+	}
+
+	return nil
+}
+
+func newServiceFlight(
+	logger *zap.Logger,
+	cfg *config.TServerConfig,
+	connector api_service.ConnectorServer,
+) (utils.Service, error) {
+	//nolint:noctx
+	listener, err := net.Listen("tcp", common.EndpointToString(cfg.FlightServer.Endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("net listen: %w", err)
+	}
+
+	middleware := []flight.ServerMiddleware{
+		{Unary: utils.UnaryServerMetadata(logger), Stream: utils.StreamServerMetadata(logger)},
+	}
+
+	var opts []grpc.ServerOption
+
+	tlsConfig := cfg.FlightServer.GetTls()
+	if tlsConfig == nil {
+		tlsConfig = cfg.GetConnectorServer().GetTls()
+	}
+
+	if tlsConfig != nil {
+		creds, err := makeTLSCredentials(logger, tlsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("make TLS credentials: %w", err)
+		}
+
+		opts = append(opts, grpc.Creds(creds))
+	} else {
+		logger.Warn("server will use insecure connections")
+	}
+
+	s := &serviceFlight{
+		connector:  connector,
+		grpcServer: flight.NewServerWithMiddleware(middleware, opts...),
+		listener:   listener,
+		logger:     logger,
+	}
+
+	s.grpcServer.InitListener(listener)
+	s.grpcServer.RegisterFlightService(s)
+
+	return s, nil
+}
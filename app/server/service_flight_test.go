@@ -0,0 +1,249 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/flight"
+	"github.com/apache/arrow/go/v13/arrow/ipc"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service "github.com/ydb-platform/fq-connector-go/api/service"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// flightTestConnector serves a table with a single Int32 column split into two parts
+type flightTestConnector struct {
+	api_service.UnimplementedConnectorServer
+	record    arrow.Record
+	readError *api_service_protos.TError // reported by ReadSplits if set
+}
+
+func (*flightTestConnector) DescribeTable(
+	_ context.Context,
+	request *api_service_protos.TDescribeTableRequest,
+) (*api_service_protos.TDescribeTableResponse, error) {
+	if request.Table != "tab" {
+		return &api_service_protos.TDescribeTableResponse{
+			Error: &api_service_protos.TError{Status: Ydb.StatusIds_NOT_FOUND, Message: "table not found"},
+		}, nil
+	}
+
+	return &api_service_protos.TDescribeTableResponse{
+		Schema: &api_service_protos.TSchema{
+			Columns: []*Ydb.Column{{Name: "id", Type: common.MakePrimitiveType(Ydb.Type_INT32)}},
+		},
+	}, nil
+}
+
+func (*flightTestConnector) ListSplits(
+	request *api_service_protos.TListSplitsRequest,
+	stream api_service.Connector_ListSplitsServer,
+) error {
+	response := &api_service_protos.TListSplitsResponse{}
+
+	for i, slct := range request.Selects {
+		for j := 0; j < 2; j++ {
+			response.Splits = append(response.Splits, &api_service_protos.TSplit{
+				Select:  slct,
+				Payload: &api_service_protos.TSplit_Description{Description: []byte{byte(j)}},
+				Id:      uint64(i*2 + j),
+			})
+		}
+	}
+
+	return stream.Send(response)
+}
+
+func (c *flightTestConnector) ReadSplits(
+	_ *api_service_protos.TReadSplitsRequest,
+	stream api_service.Connector_ReadSplitsServer,
+) error {
+	if c.readError != nil {
+		return stream.Send(&api_service_protos.TReadSplitsResponse{Error: c.readError})
+	}
+
+	var buf bytes.Buffer
+
+	writer := ipc.NewWriter(&buf, ipc.WithSchema(c.record.Schema()))
+
+	if err := writer.Write(c.record); err != nil {
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return stream.Send(&api_service_protos.TReadSplitsResponse{
+		Payload: &api_service_protos.TReadSplitsResponse_ArrowIpcStreaming{ArrowIpcStreaming: buf.Bytes()},
+	})
+}
+
+func TestServiceFlight(t *testing.T) {
+	logger := common.NewTestLogger(t)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int32}}, nil)
+
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+
+	builder.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2, 3}, nil)
+
+	record := builder.NewRecord()
+	defer record.Release()
+
+	connector := &flightTestConnector{record: record}
+
+	cfg := &config.TServerConfig{
+		FlightServer: &config.TFlightServerConfig{
+			Endpoint: &api_common.TGenericEndpoint{Host: "localhost", Port: 0},
+		},
+	}
+
+	service, err := newServiceFlight(logger, cfg, connector)
+	require.NoError(t, err)
+
+	go func() {
+		if err := service.Start(); err != nil {
+			logger.Error(err.Error())
+		}
+	}()
+
+	defer service.Stop()
+
+	client, err := flight.NewClientWithMiddleware(
+		service.(*serviceFlight).listener.Addr().String(),
+		nil,
+		nil,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	defer client.Close()
+
+	ctx := context.Background()
+
+	makeDescriptor := func(table string) *flight.FlightDescriptor {
+		cmd, err := protojson.Marshal(&api_service_protos.TDescribeTableRequest{
+			DataSourceInstance: &api_common.TGenericDataSourceInstance{Kind: api_common.EGenericDataSourceKind_POSTGRESQL},
+			Table:              table,
+		})
+		require.NoError(t, err)
+
+		return &flight.FlightDescriptor{Type: flight.DescriptorCMD, Cmd: cmd}
+	}
+
+	t.Run("GetFlightInfo", func(t *testing.T) {
+		info, err := client.GetFlightInfo(ctx, makeDescriptor("tab"))
+		require.NoError(t, err)
+		require.Len(t, info.Endpoint, 2)
+
+		actualSchema, err := flight.DeserializeSchema(info.Schema, memory.DefaultAllocator)
+		require.NoError(t, err)
+		require.True(t, schema.Equal(actualSchema), actualSchema)
+	})
+
+	t.Run("GetFlightInfo_not_found", func(t *testing.T) {
+		_, err := client.GetFlightInfo(ctx, makeDescriptor("missing"))
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("GetFlightInfo_invalid_descriptor", func(t *testing.T) {
+		_, err := client.GetFlightInfo(ctx, &flight.FlightDescriptor{Type: flight.DescriptorPATH, Path: []string{"tab"}})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("DoGet", func(t *testing.T) {
+		info, err := client.GetFlightInfo(ctx, makeDescriptor("tab"))
+		require.NoError(t, err)
+
+		for _, endpoint := range info.Endpoint {
+			stream, err := client.DoGet(ctx, endpoint.Ticket)
+			require.NoError(t, err)
+
+			reader, err := flight.NewRecordReader(stream)
+			require.NoError(t, err)
+
+			require.True(t, reader.Next())
+			require.True(t, array.RecordEqual(record, reader.Record()))
+			require.False(t, reader.Next())
+			require.NoError(t, reader.Err())
+
+			reader.Release()
+		}
+	})
+
+	t.Run("DoGet_invalid_ticket", func(t *testing.T) {
+		stream, err := client.DoGet(ctx, &flight.Ticket{Ticket: []byte("garbage")})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("DoGet_read_error", func(t *testing.T) {
+		info, err := client.GetFlightInfo(ctx, makeDescriptor("tab"))
+		require.NoError(t, err)
+
+		connector.readError = &api_service_protos.TError{Status: Ydb.StatusIds_UNAVAILABLE, Message: "connection refused"}
+		defer func() { connector.readError = nil }()
+
+		stream, err := client.DoGet(ctx, info.Endpoint[0].Ticket)
+		require.NoError(t, err)
+
+		for err == nil {
+			_, err = stream.Recv()
+		}
+
+		require.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
+
+func TestAPIErrorToGRPCStatus(t *testing.T) {
+	type testCase struct {
+		status Ydb.StatusIds_StatusCode
+		code   codes.Code
+	}
+
+	tcs := []testCase{
+		{status: Ydb.StatusIds_BAD_REQUEST, code: codes.InvalidArgument},
+		{status: Ydb.StatusIds_SCHEME_ERROR, code: codes.InvalidArgument},
+		{status: Ydb.StatusIds_UNAUTHORIZED, code: codes.Unauthenticated},
+		{status: Ydb.StatusIds_NOT_FOUND, code: codes.NotFound},
+		{status: Ydb.StatusIds_UNSUPPORTED, code: codes.Unimplemented},
+		{status: Ydb.StatusIds_UNAVAILABLE, code: codes.Unavailable},
+		{status: Ydb.StatusIds_OVERLOADED, code: codes.Unavailable},
+		{status: Ydb.StatusIds_TIMEOUT, code: codes.DeadlineExceeded},
+		{status: Ydb.StatusIds_CANCELLED, code: codes.Canceled},
+		{status: Ydb.StatusIds_INTERNAL_ERROR, code: codes.Internal},
+		{status: Ydb.StatusIds_GENERIC_ERROR, code: codes.Internal},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.status.String(), func(t *testing.T) {
+			err := apiErrorToGRPCStatus(&api_service_protos.TError{Status: tc.status, Message: "message"})
+
+			grpcStatus, ok := status.FromError(err)
+			require.True(t, ok)
+			require.Equal(t, tc.code, grpcStatus.Code())
+			require.Equal(t, "message", grpcStatus.Message())
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -66,7 +67,10 @@ func (s *ReadSplitsStreamer[T]) sendResultToStream(result *paging.ReadResult[T])
 	var err error
 
 	if result.Data != nil {
-		// Handle the case where we have serialized Arrow data
+		// Handle the case where we have serialized Arrow data;
+		// its memory is returned to the budget once it is sent
+		defer result.Release()
+
 		resp = &api_service_protos.TReadSplitsResponse{
 			Payload: &api_service_protos.TReadSplitsResponse_ArrowIpcStreaming{
 				ArrowIpcStreaming: result.Data,
@@ -86,6 +90,12 @@ func (s *ReadSplitsStreamer[T]) sendResultToStream(result *paging.ReadResult[T])
 		return errors.New("result contains neither Data nor ColumnarBuffer")
 	}
 
+	// The size of the payload is known only after the buffer serialization
+	if resp.Stats != nil && result.Stats != nil {
+		result.Stats.PayloadBytes = resp.Stats.PayloadBytes
+		result.Stats.UncompressedPayloadBytes = resp.Stats.UncompressedPayloadBytes
+	}
+
 	resp.Stats = result.Stats
 
 	// if stream is finished, assign successful operation code
@@ -95,10 +105,14 @@ func (s *ReadSplitsStreamer[T]) sendResultToStream(result *paging.ReadResult[T])
 
 	dumpReadSplitsResponse(result.Logger, resp)
 
+	startTime := time.Now()
+
 	if err := s.stream.Send(resp); err != nil {
 		return fmt.Errorf("stream send: %w", err)
 	}
 
+	s.sinkFactory.ReportPageSent(time.Since(startTime))
+
 	return nil
 }
 
@@ -271,10 +271,17 @@ func (tc testCaseStreaming) execute(t *testing.T) {
 
 	dataSource := rdbms.NewDataSource(logger, dataSourcePreset, converterCollection, observationStorage)
 
+	memoryQuota := paging.NewMemoryBudget(nil, memory.NewGoAllocator()).MakeQuota()
+	defer memoryQuota.Close()
+
+	ipcWriter, err := paging.NewArrowIPCWriter(memoryQuota, nil, nil, split.Select.What)
+	require.NoError(t, err)
+
 	columnarBufferFactory, err := paging.NewColumnarBufferFactory[any](
 		logger,
-		memory.NewGoAllocator(),
+		memoryQuota,
 		api_service_protos.TReadSplitsRequest_ARROW_IPC_STREAMING,
+		ipcWriter,
 		split.Select.What)
 	require.NoError(t, err)
 
@@ -282,7 +289,7 @@ func (tc testCaseStreaming) execute(t *testing.T) {
 	readLimiterFactory := paging.NewReadLimiterFactory(nil)
 	readLimiter := readLimiterFactory.MakeReadLimiter(logger, split.Select.DataSourceInstance.Kind)
 
-	sinkFactory := paging.NewSinkFactory(ctx, logger, pagingCfg, columnarBufferFactory, readLimiter)
+	sinkFactory := paging.NewSinkFactory(ctx, logger, pagingCfg, columnarBufferFactory, ipcWriter, readLimiter, memoryQuota)
 
 	request := &api_service_protos.TReadSplitsRequest{}
 	streamer := NewReadSplitsStreamer(logger, "test-query-id", stream, request, split, sinkFactory, dataSource)
@@ -0,0 +1,161 @@
+package streaming
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	api_service "github.com/ydb-platform/fq-connector-go/api/service"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+// SplitReader reads a single split and sends the data into the stream
+type SplitReader func(stream api_service.Connector_ReadSplitsServer, split *api_service_protos.TSplit) error
+
+// SplitPrefetcher reads the splits one after another, but starts reading up to `depth` next splits
+// while the current one is being sent to the client. The responses of the prefetched splits
+// are held back until all the preceding splits are sent, so the order of the splits is preserved.
+type SplitPrefetcher struct {
+	stream      api_service.Connector_ReadSplitsServer
+	depth       int
+	canPrefetch func() bool // reports if there are resources for one more prefetched split
+	readSplit   SplitReader
+}
+
+// Run reads the splits and returns the first error that happened
+func (p *SplitPrefetcher) Run(splits []*api_service_protos.TSplit) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	// stops the prefetched splits if the current one failed
+	ctx, cancel := context.WithCancel(p.stream.Context())
+	defer cancel()
+
+	streams := make([]*splitStream, len(splits))
+
+	start := func(i int) {
+		stream := newSplitStream(ctx, p.stream)
+		streams[i] = stream
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			stream.done <- p.readSplit(stream, splits[i])
+		}()
+	}
+
+	for i, split := range splits {
+		if streams[i] == nil {
+			start(i)
+		}
+
+		if err := streams[i].activate(); err != nil {
+			return fmt.Errorf("split %d: send prefetched data: %w", split.Id, err)
+		}
+
+		for j := i + 1; j < len(splits) && j <= i+p.depth; j++ {
+			if streams[j] != nil {
+				continue
+			}
+
+			if !p.canPrefetch() {
+				break
+			}
+
+			start(j)
+		}
+
+		if err := <-streams[i].done; err != nil {
+			return fmt.Errorf("read split %d: %w", split.Id, err)
+		}
+	}
+
+	return nil
+}
+
+func NewSplitPrefetcher(
+	stream api_service.Connector_ReadSplitsServer,
+	depth int,
+	canPrefetch func() bool,
+	readSplit SplitReader,
+) *SplitPrefetcher {
+	return &SplitPrefetcher{
+		stream:      stream,
+		depth:       depth,
+		canPrefetch: canPrefetch,
+		readSplit:   readSplit,
+	}
+}
+
+var _ api_service.Connector_ReadSplitsServer = (*splitStream)(nil)
+
+// splitStream passes the responses of a single split into the underlying stream.
+// Until the split becomes current, the stream buffers its first response and blocks
+// on the next ones, so that the reading of the split is suspended.
+type splitStream struct {
+	api_service.Connector_ReadSplitsServer
+	ctx       context.Context
+	mutex     sync.Mutex
+	active    bool
+	buffer    *api_service_protos.TReadSplitsResponse
+	activated chan struct{}
+	done      chan error
+}
+
+func (s *splitStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *splitStream) Send(response *api_service_protos.TReadSplitsResponse) error {
+	s.mutex.Lock()
+
+	if !s.active && s.buffer == nil {
+		s.buffer = response
+		s.mutex.Unlock()
+
+		return nil
+	}
+
+	s.mutex.Unlock()
+
+	select {
+	case <-s.activated:
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+
+	return s.Connector_ReadSplitsServer.Send(response)
+}
+
+// activate sends the buffered response and lets the split write into the underlying stream
+func (s *splitStream) activate() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.active = true
+
+	if s.buffer != nil {
+		response := s.buffer
+		s.buffer = nil
+
+		if err := s.Connector_ReadSplitsServer.Send(response); err != nil {
+			// the split remains blocked until the context is canceled
+			return fmt.Errorf("stream send: %w", err)
+		}
+	}
+
+	close(s.activated)
+
+	return nil
+}
+
+func newSplitStream(ctx context.Context, stream api_service.Connector_ReadSplitsServer) *splitStream {
+	return &splitStream{
+		Connector_ReadSplitsServer: stream,
+		ctx:                        ctx,
+		activated:                  make(chan struct{}),
+		done:                       make(chan error, 1),
+	}
+}
@@ -0,0 +1,156 @@
+package streaming
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	api_service "github.com/ydb-platform/fq-connector-go/api/service"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+var _ api_service.Connector_ReadSplitsServer = (*recordingStream)(nil)
+
+type recordingStream struct {
+	api_service.Connector_ReadSplitsServer
+	ctx       context.Context
+	mutex     sync.Mutex
+	responses []uint64
+}
+
+func (s *recordingStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *recordingStream) Send(response *api_service_protos.TReadSplitsResponse) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.responses = append(s.responses, response.Stats.Rows)
+
+	return nil
+}
+
+// makeResponse encodes split id and page number in the response
+func makeResponse(split *api_service_protos.TSplit, page uint64) *api_service_protos.TReadSplitsResponse {
+	return &api_service_protos.TReadSplitsResponse{
+		Stats: &api_service_protos.TReadSplitsResponse_TStats{Rows: split.Id*10 + page},
+	}
+}
+
+func makeSplits(n int) []*api_service_protos.TSplit {
+	splits := make([]*api_service_protos.TSplit, 0, n)
+
+	for i := 0; i < n; i++ {
+		splits = append(splits, &api_service_protos.TSplit{Id: uint64(i + 1)})
+	}
+
+	return splits
+}
+
+func TestSplitPrefetcher(t *testing.T) {
+	t.Run("order is preserved", func(t *testing.T) {
+		stream := &recordingStream{ctx: context.Background()}
+
+		readSplit := func(stream api_service.Connector_ReadSplitsServer, split *api_service_protos.TSplit) error {
+			// the first splits are the slowest ones
+			time.Sleep(time.Duration(4-split.Id) * 10 * time.Millisecond)
+
+			for page := uint64(1); page <= 3; page++ {
+				if err := stream.Send(makeResponse(split, page)); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+
+		prefetcher := NewSplitPrefetcher(stream, 2, func() bool { return true }, readSplit)
+		require.NoError(t, prefetcher.Run(makeSplits(3)))
+		require.Equal(t, []uint64{11, 12, 13, 21, 22, 23, 31, 32, 33}, stream.responses)
+	})
+
+	t.Run("next split is read while the current one is streaming", func(t *testing.T) {
+		stream := &recordingStream{ctx: context.Background()}
+		secondSplitStarted := make(chan struct{})
+
+		readSplit := func(stream api_service.Connector_ReadSplitsServer, split *api_service_protos.TSplit) error {
+			if split.Id == 2 {
+				close(secondSplitStarted)
+
+				return stream.Send(makeResponse(split, 1))
+			}
+
+			// the first split can't finish until the second one is started
+			select {
+			case <-secondSplitStarted:
+			case <-time.After(10 * time.Second):
+				return errors.New("second split was not prefetched")
+			}
+
+			return stream.Send(makeResponse(split, 1))
+		}
+
+		prefetcher := NewSplitPrefetcher(stream, 1, func() bool { return true }, readSplit)
+		require.NoError(t, prefetcher.Run(makeSplits(2)))
+		require.Equal(t, []uint64{11, 21}, stream.responses)
+	})
+
+	t.Run("no prefetching without resources", func(t *testing.T) {
+		stream := &recordingStream{ctx: context.Background()}
+
+		var (
+			mutex      sync.Mutex
+			running    int
+			maxRunning int
+		)
+
+		readSplit := func(stream api_service.Connector_ReadSplitsServer, split *api_service_protos.TSplit) error {
+			mutex.Lock()
+			running++
+			maxRunning = max(maxRunning, running)
+			mutex.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mutex.Lock()
+			running--
+			mutex.Unlock()
+
+			return stream.Send(makeResponse(split, 1))
+		}
+
+		prefetcher := NewSplitPrefetcher(stream, 2, func() bool { return false }, readSplit)
+		require.NoError(t, prefetcher.Run(makeSplits(3)))
+		require.Equal(t, []uint64{11, 21, 31}, stream.responses)
+		require.Equal(t, 1, maxRunning)
+	})
+
+	t.Run("error stops prefetched splits", func(t *testing.T) {
+		stream := &recordingStream{ctx: context.Background()}
+		readErr := errors.New("read error")
+
+		readSplit := func(stream api_service.Connector_ReadSplitsServer, split *api_service_protos.TSplit) error {
+			if split.Id == 1 {
+				// let the second split fill its buffer
+				time.Sleep(10 * time.Millisecond)
+
+				return readErr
+			}
+
+			for page := uint64(1); ; page++ {
+				if err := stream.Send(makeResponse(split, page)); err != nil {
+					return err
+				}
+			}
+		}
+
+		prefetcher := NewSplitPrefetcher(stream, 1, func() bool { return true }, readSplit)
+		require.ErrorIs(t, prefetcher.Run(makeSplits(2)), readErr)
+		require.Empty(t, stream.responses)
+	})
+}
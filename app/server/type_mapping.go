@@ -0,0 +1,39 @@
+package server
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// withServerTypeMappingRules complements the type mapping settings of the request
+// with the rules from the server config. The rules of the request are consulted first.
+func withServerTypeMappingRules(
+	settings *api_service_protos.TTypeMappingSettings,
+	cfg *config.TTypeMappingConfig,
+) *api_service_protos.TTypeMappingSettings {
+	if len(cfg.GetRules()) == 0 && !cfg.GetUnsupportedTypesAsText() {
+		return settings
+	}
+
+	out := &api_service_protos.TTypeMappingSettings{}
+	if settings != nil {
+		out = proto.Clone(settings).(*api_service_protos.TTypeMappingSettings)
+	}
+
+	for _, rule := range cfg.GetRules() {
+		out.Rules = append(out.Rules, &api_service_protos.TTypeMappingRule{
+			Kind:       rule.Kind,
+			SourceType: rule.SourceType,
+			YdbType:    common.MakePrimitiveType(Ydb.Type_UTF8),
+		})
+	}
+
+	out.UnsupportedTypesAsText = out.UnsupportedTypesAsText || cfg.GetUnsupportedTypesAsText()
+
+	return out
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestWithServerTypeMappingRules(t *testing.T) {
+	requestRule := &api_service_protos.TTypeMappingRule{SourceType: "citext"}
+
+	t.Run("no server rules", func(t *testing.T) {
+		settings := &api_service_protos.TTypeMappingSettings{Rules: []*api_service_protos.TTypeMappingRule{requestRule}}
+
+		require.Same(t, settings, withServerTypeMappingRules(settings, nil))
+		require.Same(t, settings, withServerTypeMappingRules(settings, &config.TTypeMappingConfig{}))
+	})
+
+	t.Run("server rules are appended", func(t *testing.T) {
+		settings := &api_service_protos.TTypeMappingSettings{
+			DateTimeFormat: api_service_protos.EDateTimeFormat_STRING_FORMAT,
+			Rules:          []*api_service_protos.TTypeMappingRule{requestRule},
+		}
+
+		cfg := &config.TTypeMappingConfig{
+			Rules: []*config.TTypeMappingConfig_TRule{
+				{Kind: api_common.EGenericDataSourceKind_CLICKHOUSE, SourceType: "Decimal256*"},
+			},
+			UnsupportedTypesAsText: true,
+		}
+
+		expected := &api_service_protos.TTypeMappingSettings{
+			DateTimeFormat: api_service_protos.EDateTimeFormat_STRING_FORMAT,
+			Rules: []*api_service_protos.TTypeMappingRule{
+				requestRule,
+				{
+					Kind:       api_common.EGenericDataSourceKind_CLICKHOUSE,
+					SourceType: "Decimal256*",
+					YdbType:    common.MakePrimitiveType(Ydb.Type_UTF8),
+				},
+			},
+			UnsupportedTypesAsText: true,
+		}
+
+		actual := withServerTypeMappingRules(settings, cfg)
+		require.True(t, proto.Equal(expected, actual), actual.String())
+
+		// the settings of the request are left intact
+		require.Len(t, settings.Rules, 1)
+		require.False(t, settings.UnsupportedTypesAsText)
+	})
+
+	t.Run("request without settings", func(t *testing.T) {
+		cfg := &config.TTypeMappingConfig{UnsupportedTypesAsText: true}
+
+		actual := withServerTypeMappingRules(nil, cfg)
+		require.True(t, actual.UnsupportedTypesAsText)
+	})
+}
@@ -2,17 +2,35 @@ package server
 
 import (
 	"fmt"
+	"slices"
 
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	app_server_config "github.com/ydb-platform/fq-connector-go/app/server/config"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
-func ValidateDescribeTableRequest(request *api_service_protos.TDescribeTableRequest) error {
+func ValidateDescribeTableRequest(
+	request *api_service_protos.TDescribeTableRequest,
+	nativeQueryCfg *config.TNativeQueryConfig,
+) error {
 	if err := validateDataSourceInstance(request.GetDataSourceInstance()); err != nil {
 		return fmt.Errorf("validate data source instance: %w", err)
 	}
 
+	if request.GetQuery() != "" {
+		if request.GetTable() != "" {
+			return fmt.Errorf("both table and query are set: %w", common.ErrInvalidRequest)
+		}
+
+		if err := validateNativeQuery(request.DataSourceInstance.Kind, nativeQueryCfg); err != nil {
+			return fmt.Errorf("validate native query: %w", err)
+		}
+
+		return nil
+	}
+
 	if request.GetTable() == "" {
 		return fmt.Errorf("empty table: %w", common.ErrInvalidRequest)
 	}
@@ -20,13 +38,16 @@ func ValidateDescribeTableRequest(request *api_service_protos.TDescribeTableRequ
 	return nil
 }
 
-func ValidateListSplitsRequest(request *api_service_protos.TListSplitsRequest) error {
+func ValidateListSplitsRequest(
+	request *api_service_protos.TListSplitsRequest,
+	nativeQueryCfg *config.TNativeQueryConfig,
+) error {
 	if len(request.Selects) == 0 {
 		return fmt.Errorf("empty select list: %w", common.ErrInvalidRequest)
 	}
 
 	for i, slct := range request.Selects {
-		if err := validateSelect(slct); err != nil {
+		if err := validateSelect(slct, nativeQueryCfg); err != nil {
 			return fmt.Errorf("validate select %d: %w", i, err)
 		}
 	}
@@ -56,29 +77,67 @@ func ValidateListSplitsRequest(request *api_service_protos.TListSplitsRequest) e
 	return nil
 }
 
-func ValidateReadSplitsRequest(request *api_service_protos.TReadSplitsRequest) error {
+func ValidateReadSplitsRequest(
+	request *api_service_protos.TReadSplitsRequest,
+	nativeQueryCfg *config.TNativeQueryConfig,
+) error {
 	if len(request.Splits) == 0 {
 		return fmt.Errorf("splits are empty: %w", common.ErrInvalidRequest)
 	}
 
+	if err := validateCompression(request.Compression); err != nil {
+		return fmt.Errorf("validate compression: %w", err)
+	}
+
+	if err := validatePaging(request.Paging); err != nil {
+		return fmt.Errorf("validate paging: %w", err)
+	}
+
 	for i, split := range request.Splits {
-		if err := validateSplit(split); err != nil {
+		if err := validateSplit(split, nativeQueryCfg); err != nil {
 			return fmt.Errorf("validate split #%d: %w", i, err)
 		}
+
+		// Sorted splits multiplexed in the response stream would lose their order
+		if len(split.Select.GetOrderBy().GetKeys()) > 0 && len(request.Splits) > 1 &&
+			request.Mode == api_service_protos.TReadSplitsRequest_UNORDERED {
+			return fmt.Errorf("split #%d: ordering requires ORDERED mode: %w", i, common.ErrInvalidRequest)
+		}
 	}
 
 	return nil
 }
 
-func validateSplit(split *api_service_protos.TSplit) error {
-	if err := validateSelect(split.Select); err != nil {
+func validateCompression(compression *api_service_protos.TReadSplitsRequest_TCompression) error {
+	switch compression.GetCodec() {
+	case api_service_protos.TReadSplitsRequest_TCompression_CODEC_UNSPECIFIED,
+		api_service_protos.TReadSplitsRequest_TCompression_LZ4_FRAME,
+		api_service_protos.TReadSplitsRequest_TCompression_ZSTD:
+	default:
+		return fmt.Errorf("unknown codec %v: %w", compression.GetCodec(), common.ErrInvalidRequest)
+	}
+
+	return nil
+}
+
+func validatePaging(paging *api_service_protos.TReadSplitsRequest_TPaging) error {
+	// the same limit is applied to the server-wide paging config
+	if limit := uint64(app_server_config.MaxInterconnectMessageSize); paging.GetBytesPerPage() > limit {
+		return fmt.Errorf("bytes per page limit exceeds %d bytes: %w", limit, common.ErrInvalidRequest)
+	}
+
+	return nil
+}
+
+func validateSplit(split *api_service_protos.TSplit, nativeQueryCfg *config.TNativeQueryConfig) error {
+	if err := validateSelect(split.Select, nativeQueryCfg); err != nil {
 		return fmt.Errorf("validate select: %w", err)
 	}
 
 	return nil
 }
 
-func validateSelect(slct *api_service_protos.TSelect) error {
+func validateSelect(slct *api_service_protos.TSelect, nativeQueryCfg *config.TNativeQueryConfig) error {
 	if slct == nil {
 		return fmt.Errorf("select is empty: %w", common.ErrInvalidRequest)
 	}
@@ -87,9 +146,87 @@ func validateSelect(slct *api_service_protos.TSelect) error {
 		return fmt.Errorf("validate data source instance: %w", err)
 	}
 
+	if err := validateAggregations(slct); err != nil {
+		return fmt.Errorf("validate aggregations: %w", err)
+	}
+
+	if err := validateOrderBy(slct); err != nil {
+		return fmt.Errorf("validate order by: %w", err)
+	}
+
+	if err := validateFrom(slct, nativeQueryCfg); err != nil {
+		return fmt.Errorf("validate from: %w", err)
+	}
+
+	return nil
+}
+
+func validateFrom(slct *api_service_protos.TSelect, nativeQueryCfg *config.TNativeQueryConfig) error {
+	from := slct.GetFrom()
+
+	if from.GetQuery() == "" {
+		return nil
+	}
+
+	if from.GetTable() != "" || len(from.GetJoins()) > 0 {
+		return fmt.Errorf("query cannot be combined with table or joins: %w", common.ErrInvalidRequest)
+	}
+
+	if err := validateNativeQuery(slct.DataSourceInstance.Kind, nativeQueryCfg); err != nil {
+		return fmt.Errorf("validate native query: %w", err)
+	}
+
+	return nil
+}
+
+// validateNativeQuery checks if the passthrough of native queries is allowed for the data source kind
+func validateNativeQuery(kind api_common.EGenericDataSourceKind, nativeQueryCfg *config.TNativeQueryConfig) error {
+	if !app_server_config.NativeQuerySupported(kind) || !slices.Contains(nativeQueryCfg.GetAllowedKinds(), kind) {
+		return fmt.Errorf(
+			"native queries are not allowed for data source kind %s: %w",
+			kind, common.ErrUnimplementedOperation)
+	}
+
 	return nil
 }
 
+func validateOrderBy(slct *api_service_protos.TSelect) error {
+	if len(slct.GetOrderBy().GetKeys()) == 0 {
+		return nil
+	}
+
+	switch slct.DataSourceInstance.Kind {
+	case api_common.EGenericDataSourceKind_CLICKHOUSE, api_common.EGenericDataSourceKind_POSTGRESQL,
+		api_common.EGenericDataSourceKind_YDB, api_common.EGenericDataSourceKind_MS_SQL_SERVER,
+		api_common.EGenericDataSourceKind_MYSQL, api_common.EGenericDataSourceKind_GREENPLUM,
+		api_common.EGenericDataSourceKind_ORACLE, api_common.EGenericDataSourceKind_MONGO_DB,
+		api_common.EGenericDataSourceKind_OPENSEARCH:
+		return nil
+	default:
+		return fmt.Errorf(
+			"ordering is not supported for data source kind %s: %w",
+			slct.DataSourceInstance.Kind, common.ErrUnimplementedOperation)
+	}
+}
+
+func validateAggregations(slct *api_service_protos.TSelect) error {
+	if !common.SelectWhatHasAggregations(slct.GetWhat()) && len(slct.GetGroupBy().GetColumns()) == 0 {
+		return nil
+	}
+
+	// Aggregations are pushed down only into the relational databases whose SQL formatters support them
+	switch slct.DataSourceInstance.Kind {
+	case api_common.EGenericDataSourceKind_CLICKHOUSE, api_common.EGenericDataSourceKind_POSTGRESQL,
+		api_common.EGenericDataSourceKind_YDB, api_common.EGenericDataSourceKind_MS_SQL_SERVER,
+		api_common.EGenericDataSourceKind_MYSQL, api_common.EGenericDataSourceKind_GREENPLUM:
+		return nil
+	default:
+		return fmt.Errorf(
+			"aggregations are not supported for data source kind %s: %w",
+			slct.DataSourceInstance.Kind, common.ErrUnimplementedOperation)
+	}
+}
+
 type dataSourceInstancesValidator func(dsi *api_common.TGenericDataSourceInstance) error
 
 func validateDataSourceInstance(dsi *api_common.TGenericDataSourceInstance) error {
@@ -0,0 +1,110 @@
+package common //nolint:revive
+
+import (
+	"fmt"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+// SelectWhatHasAggregations returns true if any of the requested items is an aggregate function
+func SelectWhatHasAggregations(selectWhat *api_service_protos.TSelect_TWhat) bool {
+	for _, item := range selectWhat.GetItems() {
+		if item.GetAggregation() != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SelectWhatItemToYDBColumn returns the name and the type of the column
+// that will be returned by the data source for the given item.
+func SelectWhatItemToYDBColumn(item *api_service_protos.TSelect_TWhat_TItem) (*Ydb.Column, error) {
+	switch t := item.GetPayload().(type) {
+	case *api_service_protos.TSelect_TWhat_TItem_Column:
+		if t.Column.GetType() == nil {
+			return nil, fmt.Errorf("column '%s' has no type: %w", t.Column.GetName(), ErrInvalidRequest)
+		}
+
+		return t.Column, nil
+	case *api_service_protos.TSelect_TWhat_TItem_Aggregation:
+		return AggregationToYDBColumn(t.Aggregation)
+	default:
+		return nil, fmt.Errorf("unexpected item type %T: %w", t, ErrInvalidRequest)
+	}
+}
+
+// AggregationToYDBColumn derives the type of the aggregate function result
+func AggregationToYDBColumn(agg *api_service_protos.TSelect_TAggregation) (*Ydb.Column, error) {
+	if agg.GetAlias() == "" {
+		return nil, fmt.Errorf("empty alias for aggregation %v: %w", agg, ErrInvalidRequest)
+	}
+
+	if agg.Function == api_service_protos.TSelect_TAggregation_COUNT {
+		return &Ydb.Column{Name: agg.Alias, Type: MakePrimitiveType(Ydb.Type_INT64)}, nil
+	}
+
+	if agg.Argument == nil {
+		return nil, fmt.Errorf("function %s requires an argument: %w", agg.Function, ErrInvalidRequest)
+	}
+
+	typeID, err := YdbTypeToYdbPrimitiveTypeID(agg.Argument.GetType())
+	if err != nil {
+		return nil, fmt.Errorf("argument '%s': %w", agg.Argument.GetName(), err)
+	}
+
+	var resultTypeID Ydb.Type_PrimitiveTypeId
+
+	switch agg.Function {
+	case api_service_protos.TSelect_TAggregation_SUM:
+		switch {
+		case isSignedInteger(typeID):
+			resultTypeID = Ydb.Type_INT64
+		case isUnsignedInteger(typeID):
+			resultTypeID = Ydb.Type_UINT64
+		case isFloatingPoint(typeID):
+			resultTypeID = Ydb.Type_DOUBLE
+		default:
+			return nil, fmt.Errorf("function %s over type %s: %w", agg.Function, typeID, ErrDataTypeNotSupported)
+		}
+	case api_service_protos.TSelect_TAggregation_AVG:
+		if !isSignedInteger(typeID) && !isUnsignedInteger(typeID) && !isFloatingPoint(typeID) {
+			return nil, fmt.Errorf("function %s over type %s: %w", agg.Function, typeID, ErrDataTypeNotSupported)
+		}
+
+		resultTypeID = Ydb.Type_DOUBLE
+	case api_service_protos.TSelect_TAggregation_MIN, api_service_protos.TSelect_TAggregation_MAX:
+		resultTypeID = typeID
+	default:
+		return nil, fmt.Errorf("unexpected function %s: %w", agg.Function, ErrInvalidRequest)
+	}
+
+	return &Ydb.Column{
+		Name: agg.Alias,
+		Type: MakeOptionalType(MakePrimitiveType(resultTypeID)),
+	}, nil
+}
+
+func isSignedInteger(typeID Ydb.Type_PrimitiveTypeId) bool {
+	switch typeID {
+	case Ydb.Type_INT8, Ydb.Type_INT16, Ydb.Type_INT32, Ydb.Type_INT64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isUnsignedInteger(typeID Ydb.Type_PrimitiveTypeId) bool {
+	switch typeID {
+	case Ydb.Type_UINT8, Ydb.Type_UINT16, Ydb.Type_UINT32, Ydb.Type_UINT64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isFloatingPoint(typeID Ydb.Type_PrimitiveTypeId) bool {
+	return typeID == Ydb.Type_FLOAT || typeID == Ydb.Type_DOUBLE
+}
@@ -0,0 +1,98 @@
+package common //nolint:revive
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+func TestAggregationToYDBColumn(t *testing.T) {
+	type testCase struct {
+		name     string
+		function api_service_protos.TSelect_TAggregation_EFunction
+		argument *Ydb.Type
+		output   *Ydb.Type
+		err      error
+	}
+
+	tcs := []testCase{
+		{
+			name:     "count",
+			function: api_service_protos.TSelect_TAggregation_COUNT,
+			output:   MakePrimitiveType(Ydb.Type_INT64),
+		},
+		{
+			name:     "sum_signed",
+			function: api_service_protos.TSelect_TAggregation_SUM,
+			argument: MakeOptionalType(MakePrimitiveType(Ydb.Type_INT16)),
+			output:   MakeOptionalType(MakePrimitiveType(Ydb.Type_INT64)),
+		},
+		{
+			name:     "sum_unsigned",
+			function: api_service_protos.TSelect_TAggregation_SUM,
+			argument: MakePrimitiveType(Ydb.Type_UINT32),
+			output:   MakeOptionalType(MakePrimitiveType(Ydb.Type_UINT64)),
+		},
+		{
+			name:     "sum_float",
+			function: api_service_protos.TSelect_TAggregation_SUM,
+			argument: MakePrimitiveType(Ydb.Type_FLOAT),
+			output:   MakeOptionalType(MakePrimitiveType(Ydb.Type_DOUBLE)),
+		},
+		{
+			name:     "sum_string",
+			function: api_service_protos.TSelect_TAggregation_SUM,
+			argument: MakePrimitiveType(Ydb.Type_UTF8),
+			err:      ErrDataTypeNotSupported,
+		},
+		{
+			name:     "avg",
+			function: api_service_protos.TSelect_TAggregation_AVG,
+			argument: MakePrimitiveType(Ydb.Type_INT32),
+			output:   MakeOptionalType(MakePrimitiveType(Ydb.Type_DOUBLE)),
+		},
+		{
+			name:     "max",
+			function: api_service_protos.TSelect_TAggregation_MAX,
+			argument: MakePrimitiveType(Ydb.Type_UTF8),
+			output:   MakeOptionalType(MakePrimitiveType(Ydb.Type_UTF8)),
+		},
+		{
+			name:     "min_without_argument",
+			function: api_service_protos.TSelect_TAggregation_MIN,
+			err:      ErrInvalidRequest,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			agg := &api_service_protos.TSelect_TAggregation{
+				Function: tc.function,
+				Alias:    "result",
+			}
+
+			if tc.argument != nil {
+				agg.Argument = &Ydb.Column{Name: "col", Type: tc.argument}
+			}
+
+			column, err := AggregationToYDBColumn(agg)
+			if tc.err != nil {
+				require.True(t, errors.Is(err, tc.err), err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "result", column.Name)
+			require.True(t, proto.Equal(tc.output, column.Type), column.Type)
+		})
+	}
+}
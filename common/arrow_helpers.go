@@ -2,6 +2,7 @@ package common //nolint:revive
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/apache/arrow/go/v13/arrow"
@@ -40,14 +41,18 @@ type ArrowBuilder[VT ValueType] interface {
 }
 
 func SelectWhatToArrowSchema(selectWhat *api_service_protos.TSelect_TWhat) (*arrow.Schema, error) {
-	fields := make([]arrow.Field, 0, len(selectWhat.Items))
+	columns, err := SelectWhatToYDBColumns(selectWhat)
+	if err != nil {
+		return nil, err
+	}
 
-	for i, item := range selectWhat.Items {
-		column := item.GetColumn()
-		if column == nil {
-			return nil, fmt.Errorf("item #%d (%v) is not a column", i, item)
-		}
+	return YDBColumnsToArrowSchema(columns)
+}
+
+func YDBColumnsToArrowSchema(columns []*Ydb.Column) (*arrow.Schema, error) {
+	fields := make([]arrow.Field, 0, len(columns))
 
+	for _, column := range columns {
 		field, err := ydbTypeToArrowField(column.GetType(), column)
 		if err != nil {
 			return nil, err
@@ -120,9 +125,16 @@ func ydbTypeToArrowBuilder(ydbType *Ydb.Type, arrowAllocator memory.Allocator) (
 		builder = array.NewStructBuilder(arrowAllocator, structType)
 	case *Ydb.Type_DecimalType:
 		builder = array.NewFixedSizeBinaryBuilder(arrowAllocator, &arrow.FixedSizeBinaryType{ByteWidth: 16})
+	case *Ydb.Type_ListType, *Ydb.Type_TupleType, *Ydb.Type_DictType:
+		field, err := ydbTypeToArrowField(ydbType, &Ydb.Column{})
+		if err != nil {
+			return nil, fmt.Errorf("container YDB type to Arrow field: %w", err)
+		}
+
+		builder = array.NewBuilder(arrowAllocator, field.Type)
 	default:
 		err := fmt.Errorf(
-			"only primitive, optional, tagged, struct and decimal types are supported, got '%T' instead: %w",
+			"only primitive, optional, tagged, struct, list, tuple, dict and decimal types are supported, got '%T' instead: %w",
 			t, ErrDataTypeNotSupported,
 		)
 
@@ -177,7 +189,13 @@ func ydbTypeIdToArrowBuilder(typeID Ydb.Type_PrimitiveTypeId, arrowAllocator mem
 		builder = array.NewUint32Builder(arrowAllocator)
 	case Ydb.Type_TIMESTAMP:
 		builder = array.NewUint64Builder(arrowAllocator)
-	case Ydb.Type_JSON_DOCUMENT:
+	case Ydb.Type_DATE32:
+		builder = array.NewInt32Builder(arrowAllocator)
+	case Ydb.Type_INTERVAL, Ydb.Type_DATETIME64, Ydb.Type_TIMESTAMP64, Ydb.Type_INTERVAL64:
+		builder = array.NewInt64Builder(arrowAllocator)
+	case Ydb.Type_UUID:
+		builder = array.NewFixedSizeBinaryBuilder(arrowAllocator, &arrow.FixedSizeBinaryType{ByteWidth: 16})
+	case Ydb.Type_JSON_DOCUMENT, Ydb.Type_DYNUMBER:
 		builder = array.NewBinaryBuilder(arrowAllocator, arrow.BinaryTypes.Binary)
 	default:
 		return nil, fmt.Errorf("register type '%v': %w", typeID, ErrDataTypeNotSupported)
@@ -236,9 +254,57 @@ func ydbTypeToArrowField(ydbType *Ydb.Type, column *Ydb.Column) (arrow.Field, er
 			Name: column.Name,
 			Type: &arrow.FixedSizeBinaryType{ByteWidth: 16},
 		}
+	case *Ydb.Type_ListType:
+		itemField, err := ydbTypeToArrowField(t.ListType.Item, &Ydb.Column{Name: "item"})
+		if err != nil {
+			return arrow.Field{}, fmt.Errorf("map YDB type to Arrow field for list item: %w", err)
+		}
+
+		itemField.Nullable = true
+
+		field = arrow.Field{
+			Name:     column.Name,
+			Type:     arrow.ListOfField(itemField),
+			Nullable: true,
+		}
+	case *Ydb.Type_TupleType:
+		// Tuples are represented as structs with the positional field names
+		fields := make([]arrow.Field, 0, len(t.TupleType.Elements))
+
+		for i, element := range t.TupleType.Elements {
+			innerField, err := ydbTypeToArrowField(element, &Ydb.Column{Name: strconv.Itoa(i)})
+			if err != nil {
+				return arrow.Field{}, fmt.Errorf("map YDB type to Arrow field for tuple element %d: %w", i, err)
+			}
+
+			innerField.Nullable = true
+			fields = append(fields, innerField)
+		}
+
+		field = arrow.Field{
+			Name:     column.Name,
+			Type:     arrow.StructOf(fields...),
+			Nullable: true,
+		}
+	case *Ydb.Type_DictType:
+		keyField, err := ydbTypeToArrowField(t.DictType.Key, &Ydb.Column{Name: "key"})
+		if err != nil {
+			return arrow.Field{}, fmt.Errorf("map YDB type to Arrow field for dict key: %w", err)
+		}
+
+		payloadField, err := ydbTypeToArrowField(t.DictType.Payload, &Ydb.Column{Name: "value"})
+		if err != nil {
+			return arrow.Field{}, fmt.Errorf("map YDB type to Arrow field for dict payload: %w", err)
+		}
+
+		field = arrow.Field{
+			Name:     column.Name,
+			Type:     arrow.MapOf(keyField.Type, payloadField.Type),
+			Nullable: true,
+		}
 	default:
 		err := fmt.Errorf(
-			"only primitive, optional, tagged, struct and decimal types are supported, got '%T' instead: %w",
+			"only primitive, optional, tagged, struct, list, tuple, dict and decimal types are supported, got '%T' instead: %w",
 			t, ErrDataTypeNotSupported,
 		)
 
@@ -289,7 +355,13 @@ func ydbTypeIdToArrowField(typeID Ydb.Type_PrimitiveTypeId, column *Ydb.Column)
 		field = arrow.Field{Name: column.Name, Type: arrow.PrimitiveTypes.Uint32}
 	case Ydb.Type_TIMESTAMP:
 		field = arrow.Field{Name: column.Name, Type: arrow.PrimitiveTypes.Uint64}
-	case Ydb.Type_JSON_DOCUMENT:
+	case Ydb.Type_DATE32:
+		field = arrow.Field{Name: column.Name, Type: arrow.PrimitiveTypes.Int32}
+	case Ydb.Type_INTERVAL, Ydb.Type_DATETIME64, Ydb.Type_TIMESTAMP64, Ydb.Type_INTERVAL64:
+		field = arrow.Field{Name: column.Name, Type: arrow.PrimitiveTypes.Int64}
+	case Ydb.Type_UUID:
+		field = arrow.Field{Name: column.Name, Type: &arrow.FixedSizeBinaryType{ByteWidth: 16}}
+	case Ydb.Type_JSON_DOCUMENT, Ydb.Type_DYNUMBER:
 		field = arrow.Field{Name: column.Name, Type: arrow.BinaryTypes.Binary}
 	default:
 		return arrow.Field{}, fmt.Errorf("register type '%v': %w", typeID, ErrDataTypeNotSupported)
@@ -48,6 +48,19 @@ func WithFiltering(filtering api_service_protos.TReadSplitsRequest_EFiltering) R
 	return readSplitsFilteringOption{filtering: filtering}
 }
 
+type readSplitsTypeMappingSettingsOption struct {
+	typeMappingSettings *api_service_protos.TTypeMappingSettings
+}
+
+func (o readSplitsTypeMappingSettingsOption) apply(request *api_service_protos.TReadSplitsRequest) {
+	request.TypeMappingSettings = o.typeMappingSettings
+}
+
+// WithTypeMappingSettings passes the same type mapping settings that were used in DescribeTable
+func WithTypeMappingSettings(typeMappingSettings *api_service_protos.TTypeMappingSettings) ReadSplitsOption {
+	return readSplitsTypeMappingSettingsOption{typeMappingSettings: typeMappingSettings}
+}
+
 func (c *clientBasic) Close() {
 	LogCloserError(c.logger, c.conn, "client GRPC connection")
 }
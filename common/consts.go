@@ -1,6 +1,7 @@
 package common //nolint:revive
 
 const (
-	ForbidRetries = "forbid_retries"
-	TestName      = "test_name"
+	ForbidRetries   = "forbid_retries"
+	TestName        = "test_name"
+	BypassReadCache = "bypass_read_cache"
 )
@@ -9,8 +9,14 @@ var (
 	// According to https://ydb.tech/en/docs/yql/reference/types/primitive#datetime
 	minYDBTime = time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
 	maxYDBTime = time.Date(2106, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	// Value range of the wide date and time types (Date32, Datetime64, Timestamp64)
+	minYDBWideTime = time.Date(-144169, time.January, 1, 0, 0, 0, 0, time.UTC)
+	maxYDBWideTime = time.Date(148108, time.January, 1, 0, 0, 0, 0, time.UTC)
 )
 
+const secondsPerDay = 24 * 60 * 60
+
 func TimeToYDBDate(t *time.Time) (uint16, error) {
 	if t.Before(minYDBTime) || t.After(maxYDBTime) {
 		return 0, fmt.Errorf("convert '%v' to YDB Date: %w", t, ErrValueOutOfTypeBounds)
@@ -41,8 +47,43 @@ func TimeToYDBTimestamp(t *time.Time) (uint64, error) {
 	return uint64(seconds), nil
 }
 
+// TimeToYDBDate32 returns the number of days since the Unix epoch (may be negative)
+func TimeToYDBDate32(t *time.Time) (int32, error) {
+	if t.Before(minYDBWideTime) || !t.Before(maxYDBWideTime) {
+		return 0, fmt.Errorf("convert '%v' to YDB Date32: %w", t, ErrValueOutOfTypeBounds)
+	}
+
+	seconds := t.Unix()
+	days := seconds / secondsPerDay
+
+	// round towards negative infinity for the dates before the epoch
+	if seconds%secondsPerDay < 0 {
+		days--
+	}
+
+	return int32(days), nil
+}
+
+// TimeToYDBDatetime64 returns the number of seconds since the Unix epoch (may be negative)
+func TimeToYDBDatetime64(t *time.Time) (int64, error) {
+	if t.Before(minYDBWideTime) || !t.Before(maxYDBWideTime) {
+		return 0, fmt.Errorf("convert '%v' to YDB Datetime64: %w", t, ErrValueOutOfTypeBounds)
+	}
+
+	return t.Unix(), nil
+}
+
+// TimeToYDBTimestamp64 returns the number of microseconds since the Unix epoch (may be negative)
+func TimeToYDBTimestamp64(t *time.Time) (int64, error) {
+	if t.Before(minYDBWideTime) || !t.Before(maxYDBWideTime) {
+		return 0, fmt.Errorf("convert '%v' to YDB Timestamp64: %w", t, ErrValueOutOfTypeBounds)
+	}
+
+	return t.UnixMicro(), nil
+}
+
 type ydbTime interface {
-	uint16 | uint32 | uint64
+	uint16 | uint32 | uint64 | int32 | int64
 }
 
 func MustTimeToYDBType[OUT ydbTime](f func(t *time.Time) (OUT, error), t time.Time) OUT {
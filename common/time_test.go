@@ -145,3 +145,141 @@ func TestTimeToYDBTimestamp(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeToYDBDate32(t *testing.T) {
+	type testCase struct {
+		input  time.Time
+		output int32
+		err    error
+	}
+
+	tcs := []testCase{
+		{
+			input:  time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+			output: 0,
+		},
+		{
+			input:  time.Date(1969, 12, 31, 23, 59, 0, 0, time.UTC),
+			output: -1,
+		},
+		{
+			input:  time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC),
+			output: -25567,
+		},
+		{
+			input:  time.Date(2299, 12, 31, 0, 0, 0, 0, time.UTC),
+			output: 120529,
+		},
+		{
+			input:  time.Date(-144170, 12, 31, 0, 0, 0, 0, time.UTC),
+			output: 0,
+			err:    ErrValueOutOfTypeBounds,
+		},
+		{
+			input:  time.Date(148108, 1, 1, 0, 0, 0, 0, time.UTC),
+			output: 0,
+			err:    ErrValueOutOfTypeBounds,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.input.String(), func(t *testing.T) {
+			output, err := TimeToYDBDate32(&tc.input)
+			require.Equal(t, tc.output, output)
+
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTimeToYDBDatetime64(t *testing.T) {
+	type testCase struct {
+		input  time.Time
+		output int64
+		err    error
+	}
+
+	tcs := []testCase{
+		{
+			input:  time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+			output: 0,
+		},
+		{
+			input:  time.Date(1969, 12, 31, 23, 59, 0, 0, time.UTC),
+			output: -60,
+		},
+		{
+			input:  time.Date(2299, 12, 31, 0, 0, 0, 0, time.UTC),
+			output: 10413705600,
+		},
+		{
+			input:  time.Date(148108, 1, 1, 0, 0, 0, 0, time.UTC),
+			output: 0,
+			err:    ErrValueOutOfTypeBounds,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.input.String(), func(t *testing.T) {
+			output, err := TimeToYDBDatetime64(&tc.input)
+			require.Equal(t, tc.output, output)
+
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTimeToYDBTimestamp64(t *testing.T) {
+	type testCase struct {
+		input  time.Time
+		output int64
+		err    error
+	}
+
+	tcs := []testCase{
+		{
+			input:  time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+			output: 0,
+		},
+		{
+			input:  time.Date(1969, 12, 31, 23, 59, 59, 999999000, time.UTC),
+			output: -1,
+		},
+		{
+			input:  time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC),
+			output: -2208988800000000,
+		},
+		{
+			input:  time.Date(-144170, 12, 31, 0, 0, 0, 0, time.UTC),
+			output: 0,
+			err:    ErrValueOutOfTypeBounds,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.input.String(), func(t *testing.T) {
+			output, err := TimeToYDBTimestamp64(&tc.input)
+			require.Equal(t, tc.output, output)
+
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
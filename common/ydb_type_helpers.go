@@ -69,25 +69,30 @@ func SelectWhatToYDBTypes(selectWhat *api_service_protos.TSelect_TWhat) ([]*Ydb.
 	var ydbTypes []*Ydb.Type
 
 	for i, item := range selectWhat.Items {
-		ydbType := item.GetColumn().GetType()
-		if ydbType == nil {
-			return nil, fmt.Errorf("item #%d (%v) is not a column", i, item)
+		column, err := SelectWhatItemToYDBColumn(item)
+		if err != nil {
+			return nil, fmt.Errorf("item #%d (%v): %w", i, item, err)
 		}
 
-		ydbTypes = append(ydbTypes, ydbType)
+		ydbTypes = append(ydbTypes, column.Type)
 	}
 
 	return ydbTypes, nil
 }
 
-func SelectWhatToYDBColumns(selectWhat *api_service_protos.TSelect_TWhat) []*Ydb.Column {
+func SelectWhatToYDBColumns(selectWhat *api_service_protos.TSelect_TWhat) ([]*Ydb.Column, error) {
 	var ydbColumns []*Ydb.Column
 
-	for _, item := range selectWhat.Items {
-		ydbColumns = append(ydbColumns, item.GetColumn())
+	for i, item := range selectWhat.Items {
+		column, err := SelectWhatItemToYDBColumn(item)
+		if err != nil {
+			return nil, fmt.Errorf("item #%d (%v): %w", i, item, err)
+		}
+
+		ydbColumns = append(ydbColumns, column)
 	}
 
-	return ydbColumns
+	return ydbColumns, nil
 }
 
 func YDBColumnsToYDBTypes(ydbColumns []*Ydb.Column) []*Ydb.Type {
@@ -123,6 +128,17 @@ func MakeYdbDateTimeType(ydbTypeID Ydb.Type_PrimitiveTypeId, format api_service_
 		return MakePrimitiveType(ydbTypeID), nil
 	case api_service_protos.EDateTimeFormat_STRING_FORMAT:
 		return MakePrimitiveType(Ydb.Type_UTF8), nil
+	case api_service_protos.EDateTimeFormat_YQL_WIDE_FORMAT:
+		switch ydbTypeID {
+		case Ydb.Type_DATE:
+			return MakePrimitiveType(Ydb.Type_DATE32), nil
+		case Ydb.Type_DATETIME:
+			return MakePrimitiveType(Ydb.Type_DATETIME64), nil
+		case Ydb.Type_TIMESTAMP:
+			return MakePrimitiveType(Ydb.Type_TIMESTAMP64), nil
+		default:
+			return nil, fmt.Errorf("unexpected date or time type '%s': %w", ydbTypeID, ErrDataTypeNotSupported)
+		}
 	default:
 		return nil, fmt.Errorf("unexpected datetime format '%s': %w", format, ErrInvalidRequest)
 	}
@@ -172,7 +188,8 @@ func TypesEqual(lhs, rhs *Ydb.Type) bool {
 	case *Ydb.Type_TaggedType:
 		rhsType := rhs.GetTaggedType()
 
-		return rhsType.Tag == lhsType.TaggedType.Tag &&
+		return rhsType != nil &&
+			rhsType.Tag == lhsType.TaggedType.Tag &&
 			TypesEqual(rhsType.Type, lhsType.TaggedType.Type)
 	case *Ydb.Type_VoidType:
 		return rhs.GetVoidType() != structpb.NullValue(0)
@@ -392,6 +392,16 @@ def main():
             [ydb_github_root, connector_github_root, protobuf_includes],
             True,
         )
+        # Generate Connector Admin API
+        run_protoc(
+            connector_github_root.joinpath(
+                "api/admin"
+            ).rglob("*.proto"),
+            connector_github_root.joinpath("api"),
+            "github.com/ydb-platform/fq-connector-go/api",
+            [ydb_github_root, connector_github_root, protobuf_includes],
+            True,
+        )
 
     finally:
         # Revert changes in YDB sources